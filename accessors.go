@@ -0,0 +1,353 @@
+package inifile
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ValueAsInt attempts to convert the specified property to an int (the size of which depends on the platform).
+//
+// Returns an error if the section or property does not exist, if the value could not be converted to an int or is
+// outside the range of an int
+func (ic *IniConfig) ValueAsInt(sectionName, propertyName string) (int, error) {
+	v, err := ic.valueAsSizedInt(sectionName, propertyName, strconv.IntSize, "an int")
+
+	return int(v), err
+}
+
+// ValueOrZeroAsInt returns the value of the specified property in the specified section as an int or
+// the int zero value (0) if the value could not be found or converted
+func (ic *IniConfig) ValueOrZeroAsInt(sectionName, propertyName string) int {
+	v, _ := ic.ValueAsInt(sectionName, propertyName)
+
+	return v
+}
+
+// ValueAsInt32 attempts to convert the specified property to an int32.
+//
+// Returns an error if the section or property does not exist, if the value could not be converted to an int32 or is
+// outside the range of an int32
+func (ic *IniConfig) ValueAsInt32(sectionName, propertyName string) (int32, error) {
+	v, err := ic.valueAsSizedInt(sectionName, propertyName, 32, "an int32")
+
+	return int32(v), err
+}
+
+// ValueOrZeroAsInt32 returns the value of the specified property in the specified section as an int32 or
+// the int32 zero value (0) if the value could not be found or converted
+func (ic *IniConfig) ValueOrZeroAsInt32(sectionName, propertyName string) int32 {
+	v, _ := ic.ValueAsInt32(sectionName, propertyName)
+
+	return v
+}
+
+// ValueAsUint32 attempts to convert the specified property to a uint32.
+//
+// Returns an error if the section or property does not exist, if the value could not be converted to a uint32 or is
+// outside the range of a uint32
+func (ic *IniConfig) ValueAsUint32(sectionName, propertyName string) (uint32, error) {
+	v, err := ic.valueAsSizedUint(sectionName, propertyName, 32, "a uint32")
+
+	return uint32(v), err
+}
+
+// ValueOrZeroAsUint32 returns the value of the specified property in the specified section as a uint32 or
+// the uint32 zero value (0) if the value could not be found or converted
+func (ic *IniConfig) ValueOrZeroAsUint32(sectionName, propertyName string) uint32 {
+	v, _ := ic.ValueAsUint32(sectionName, propertyName)
+
+	return v
+}
+
+// ValueAsUint16 attempts to convert the specified property to a uint16.
+//
+// Returns an error if the section or property does not exist, if the value could not be converted to a uint16 or is
+// outside the range of a uint16
+func (ic *IniConfig) ValueAsUint16(sectionName, propertyName string) (uint16, error) {
+	v, err := ic.valueAsSizedUint(sectionName, propertyName, 16, "a uint16")
+
+	return uint16(v), err
+}
+
+// ValueOrZeroAsUint16 returns the value of the specified property in the specified section as a uint16 or
+// the uint16 zero value (0) if the value could not be found or converted
+func (ic *IniConfig) ValueOrZeroAsUint16(sectionName, propertyName string) uint16 {
+	v, _ := ic.ValueAsUint16(sectionName, propertyName)
+
+	return v
+}
+
+// ValueAsFloat32 attempts to convert the specified property to a float32.
+//
+// Returns an error if the section or property does not exist, if the value could not be converted to a float32 or is
+// outside the range of a float32
+func (ic *IniConfig) ValueAsFloat32(sectionName, propertyName string) (float32, error) {
+
+	sv, err := ic.Value(sectionName, propertyName)
+
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := ic.parseFloat(sectionName, propertyName, sv, 32)
+
+	return float32(v), err
+}
+
+// ValueOrZeroAsFloat32 returns the value of the specified property in the specified section as a float32 or
+// the float32 zero value (0) if the value could not be found or converted
+func (ic *IniConfig) ValueOrZeroAsFloat32(sectionName, propertyName string) float32 {
+	v, _ := ic.ValueAsFloat32(sectionName, propertyName)
+
+	return v
+}
+
+// parseFloat converts a value to a float of the specified size, applying the exponent and non-finite rules in the IniOptions.
+func (ic *IniConfig) parseFloat(sectionName, propertyName, sv string, bits int) (float64, error) {
+
+	options := ic.options
+	typeName := "a float" + strconv.Itoa(bits)
+
+	v, err := strconv.ParseFloat(sv, bits)
+
+	if err != nil {
+		return 0, ic.conversionError(sectionName, propertyName, sv, err, typeName)
+	}
+
+	if !options.AllowNonFiniteFloats && (math.IsInf(v, 0) || math.IsNaN(v)) {
+		return 0, ic.conversionFailed(sectionName, propertyName, wrapf(ErrConversion, nil, "Value of [%s].%s (%s) is not a finite number (forbidden in IniOptions).", sectionName, propertyName, ic.redact(sectionName, propertyName, sv)))
+	}
+
+	if !options.AllowFloatExponent && !math.IsInf(v, 0) && !math.IsNaN(v) && strings.ContainsAny(sv, "eEpP") {
+		return 0, ic.conversionFailed(sectionName, propertyName, wrapf(ErrConversion, nil, "Value of [%s].%s (%s) uses exponent notation (forbidden in IniOptions).", sectionName, propertyName, ic.redact(sectionName, propertyName, sv)))
+	}
+
+	return v, nil
+}
+
+// valueAsSizedInt converts a property to a signed integer that must fit in the specified number of bits.
+func (ic *IniConfig) valueAsSizedInt(sectionName, propertyName string, bits int, typeName string) (int64, error) {
+
+	sv, err := ic.Value(sectionName, propertyName)
+
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := strconv.ParseInt(sv, 10, bits)
+
+	if err != nil {
+		return 0, ic.conversionError(sectionName, propertyName, sv, err, typeName)
+	}
+
+	return v, nil
+}
+
+// valueAsSizedUint converts a property to an unsigned integer that must fit in the specified number of bits.
+func (ic *IniConfig) valueAsSizedUint(sectionName, propertyName string, bits int, typeName string) (uint64, error) {
+
+	sv, err := ic.Value(sectionName, propertyName)
+
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := strconv.ParseUint(sv, 10, bits)
+
+	if err != nil {
+		return 0, ic.conversionError(sectionName, propertyName, sv, err, typeName)
+	}
+
+	return v, nil
+}
+
+// conversionError describes a failure to convert a value with one of the strconv.Parse functions, distinguishing values
+// that are out of range from values that could not be interpreted at all.
+func (ic *IniConfig) conversionError(sectionName, propertyName, sv string, err error, typeName string) error {
+
+	sv = ic.redact(sectionName, propertyName, sv)
+
+	if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+		return ic.conversionFailed(sectionName, propertyName, wrapf(ErrConversion, ic.conversionCause(sectionName, propertyName, err), "Value of [%s].%s (%s) is outside the range of %s.", sectionName, propertyName, sv, typeName))
+	}
+
+	return ic.conversionFailed(sectionName, propertyName, wrapf(ErrConversion, ic.conversionCause(sectionName, propertyName, err), "Unable to interpret [%s].%s (%s) as %s.", sectionName, propertyName, sv, typeName))
+}
+
+// The prefix used to escape separators in values interpreted by ValueAsMap
+const valueEscape = '\\'
+
+// ValueAsMap interprets the specified property as a list of key/value pairs like:
+//
+//		labels=env=prod;team=core
+//
+// where pairSep separates the pairs (; in the example) and kvSep separates each key from its value (= in the example).
+// Keys and values are trimmed of surrounding whitespace and empty pairs are ignored. A separator that is part of a key or
+// value can be escaped with a backslash (\; or \=) and a backslash itself with \\.
+//
+// Returns an error if the section or property does not exist, if either separator is empty or if a pair does not
+// contain kvSep.
+func (ic *IniConfig) ValueAsMap(sectionName, propertyName string, pairSep, kvSep string) (map[string]string, error) {
+
+	if pairSep == "" || kvSep == "" {
+		return nil, errorf("Separators for ValueAsMap cannot be empty")
+	}
+
+	sv, err := ic.Value(sectionName, propertyName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]string)
+
+	for _, pair := range splitEscaped(sv, pairSep, -1) {
+
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		kv := splitEscaped(pair, kvSep, 2)
+
+		if len(kv) != 2 {
+			return nil, ic.conversionFailed(sectionName, propertyName, wrapf(ErrConversion, nil, "Unable to interpret [%s].%s as a map: %s does not contain %s", sectionName, propertyName, ic.redact(sectionName, propertyName, pair), kvSep))
+		}
+
+		m[strings.TrimSpace(unescape(kv[0]))] = strings.TrimSpace(unescape(kv[1]))
+	}
+
+	return m, nil
+}
+
+// splitEscaped splits s around instances of sep that are not preceded by valueEscape, returning at most n parts (or all
+// parts if n < 0). Escape sequences are left in place.
+func splitEscaped(s, sep string, n int) []string {
+
+	var parts []string
+
+	start := 0
+
+	for i := 0; i < len(s); i++ {
+
+		if n >= 0 && len(parts) == n-1 {
+			break
+		}
+
+		if s[i] == valueEscape {
+			//Skip the escaped character
+			i++
+			continue
+		}
+
+		if strings.HasPrefix(s[i:], sep) {
+			parts = append(parts, s[start:i])
+			i += len(sep) - 1
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+// unescape removes valueEscape from in front of the character it escapes.
+func unescape(s string) string {
+
+	if strings.IndexByte(s, valueEscape) < 0 {
+		return s
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+
+		if s[i] == valueEscape && i+1 < len(s) {
+			i++
+		}
+
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
+
+// ValueAsBase64 decodes the specified property from base64. Standard and URL-safe alphabets are accepted, with or without
+// padding.
+//
+// Returns an error if the section or property does not exist or if the value is not valid base64
+func (ic *IniConfig) ValueAsBase64(sectionName, propertyName string) ([]byte, error) {
+
+	sv, err := ic.Value(sectionName, propertyName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	encodings := []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding}
+
+	for _, enc := range encodings {
+		if b, err := enc.DecodeString(sv); err == nil {
+			return b, nil
+		}
+	}
+
+	return nil, ic.conversionFailed(sectionName, propertyName, wrapf(ErrConversion, nil, "Unable to decode [%s].%s (%s) as base64.", sectionName, propertyName, ic.redact(sectionName, propertyName, sv)))
+}
+
+// ValueAsHex decodes the specified property from hexadecimal (e.g. 0a1b2c). An optional 0x prefix is ignored.
+//
+// Returns an error if the section or property does not exist or if the value is not valid hexadecimal
+func (ic *IniConfig) ValueAsHex(sectionName, propertyName string) ([]byte, error) {
+
+	sv, err := ic.Value(sectionName, propertyName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(sv, "0x"), "0X")
+
+	b, err := hex.DecodeString(trimmed)
+
+	if err != nil {
+		return nil, ic.conversionFailed(sectionName, propertyName, wrapf(ErrConversion, ic.conversionCause(sectionName, propertyName, err), "Unable to decode [%s].%s (%s) as hexadecimal: %s", sectionName, propertyName, ic.redact(sectionName, propertyName, sv), ic.redact(sectionName, propertyName, err.Error())))
+	}
+
+	return b, nil
+}
+
+// ValueAsPercent interprets the specified property as a percentage or ratio and returns it as a fraction (e.g. 0.75).
+// Values ending with % are always treated as percentages, so 75% is returned as 0.75. Values without a % symbol are
+// treated as fractions (0.75 is returned unchanged) unless PercentWithoutSymbolIsFraction is false in the IniOptions,
+// in which case they are treated as percentages (75 is returned as 0.75).
+//
+// Returns an error if the section or property does not exist or if the value could not be interpreted as a number
+func (ic *IniConfig) ValueAsPercent(sectionName, propertyName string) (float64, error) {
+
+	sv, err := ic.Value(sectionName, propertyName)
+
+	if err != nil {
+		return 0, err
+	}
+
+	number := strings.TrimSpace(sv)
+	percent := strings.HasSuffix(number, "%")
+
+	if percent {
+		number = strings.TrimSpace(strings.TrimSuffix(number, "%"))
+	}
+
+	v, err := ic.parseFloat(sectionName, propertyName, number, 64)
+
+	if err != nil {
+		return 0, errorf("Unable to interpret [%s].%s (%s) as a percentage: %w", sectionName, propertyName, ic.redact(sectionName, propertyName, sv), err)
+	}
+
+	if percent || !ic.options.PercentWithoutSymbolIsFraction {
+		v = v / 100
+	}
+
+	return v, nil
+}
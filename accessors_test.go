@@ -0,0 +1,187 @@
+package inifile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNarrowAccessors(t *testing.T) {
+
+	ic, err := NewIniConfigFromPath(typesPath())
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if v, err := ic.ValueAsInt32("narrow", "int32max"); err != nil || v != 2147483647 {
+		t.Errorf("Unexpected result %d %v", v, err)
+	}
+
+	if _, err := ic.ValueAsInt32("narrow", "int32over"); err == nil || !strings.Contains(err.Error(), "outside the range of an int32") {
+		t.Errorf("Expected range error, was %v", err)
+	}
+
+	if v, err := ic.ValueAsUint32("narrow", "int32over"); err != nil || v != 2147483648 {
+		t.Errorf("Unexpected result %d %v", v, err)
+	}
+
+	if v, err := ic.ValueAsUint16("narrow", "uint16max"); err != nil || v != 65535 {
+		t.Errorf("Unexpected result %d %v", v, err)
+	}
+
+	if _, err := ic.ValueAsUint16("narrow", "uint16over"); err == nil {
+		t.Errorf("Expected range error")
+	}
+
+	if _, err := ic.ValueAsUint16("uint", "negative"); err == nil || !strings.Contains(err.Error(), "Unable to interpret") {
+		t.Errorf("Expected conversion error, was %v", err)
+	}
+
+	if v, err := ic.ValueAsInt("int", "negative"); err != nil || v != -1 {
+		t.Errorf("Unexpected result %d %v", v, err)
+	}
+
+	if v, err := ic.ValueAsFloat32("float", "negative"); err != nil || v != float32(-2.3333) {
+		t.Errorf("Unexpected result %v %v", v, err)
+	}
+
+	if _, err := ic.ValueAsFloat32("narrow", "float32over"); err == nil {
+		t.Errorf("Expected range error")
+	}
+
+	if s, _ := ic.Section("narrow"); s.ValueOrZeroAsUint16("uint16over") != 0 {
+		t.Errorf("Expected zero value")
+	}
+}
+
+func TestValueAsMap(t *testing.T) {
+
+	ic, err := NewIniConfigFromPath(typesPath())
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if m, err := ic.ValueAsMap("map", "labels", ";", "="); err != nil {
+		t.Errorf("Unexpected error %s", err.Error())
+	} else if len(m) != 2 || m["env"] != "prod" || m["team"] != "core" {
+		t.Errorf("Unexpected map %v", m)
+	}
+
+	if m, err := ic.ValueAsMap("map", "escaped", ";", "="); err != nil {
+		t.Errorf("Unexpected error %s", err.Error())
+	} else if m["url"] != "http://x/?a=b;c" || m["path"] != `C:\dir` {
+		t.Errorf("Unexpected map %v", m)
+	}
+
+	if _, err := ic.ValueAsMap("map", "bad", ";", "="); err == nil {
+		t.Errorf("Expected pair without separator to fail")
+	}
+
+	if _, err := ic.ValueAsMap("map", "labels", "", "="); err == nil {
+		t.Errorf("Expected empty separator to fail")
+	}
+}
+
+func TestBinaryAccessors(t *testing.T) {
+
+	ic, err := NewIniConfigFromPath(typesPath())
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	for _, p := range []string{"base64", "base64raw"} {
+		if b, err := ic.ValueAsBase64("binary", p); err != nil || string(b) != "hello world" {
+			t.Errorf("Unexpected result for %s: %s %v", p, string(b), err)
+		}
+	}
+
+	if b, err := ic.ValueAsBase64("binary", "base64url"); err != nil || len(b) != 2 || b[0] != 0xfb || b[1] != 0xff {
+		t.Errorf("Unexpected result %v %v", b, err)
+	}
+
+	if b, err := ic.ValueAsHex("binary", "hex"); err != nil || string(b) != "hello" {
+		t.Errorf("Unexpected result %s %v", string(b), err)
+	}
+
+	if _, err := ic.ValueAsHex("binary", "badhex"); err == nil {
+		t.Errorf("Expected invalid hex to fail")
+	}
+
+	if _, err := ic.ValueAsBase64("binary", "badbase64"); err == nil {
+		t.Errorf("Expected invalid base64 to fail")
+	}
+}
+
+func TestValueAsPercent(t *testing.T) {
+
+	options := DefaultIniOptions()
+
+	ic, err := NewIniConfigFromPathWithOptions(typesPath(), options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	expected := map[string]float64{"symbol": 0.75, "fraction": 0.75, "whole": 75}
+
+	for p, e := range expected {
+		if v, err := ic.ValueAsPercent("percent", p); err != nil || v != e {
+			t.Errorf("Expected %v for %s, was %v %v", e, p, v, err)
+		}
+	}
+
+	options.PercentWithoutSymbolIsFraction = false
+
+	expected = map[string]float64{"symbol": 0.75, "fraction": 0.0075, "whole": 0.75}
+
+	for p, e := range expected {
+		if v, err := ic.ValueAsPercent("percent", p); err != nil || v != e {
+			t.Errorf("Expected %v for %s, was %v %v", e, p, v, err)
+		}
+	}
+
+	if _, err := ic.ValueAsPercent("percent", "bad"); err == nil {
+		t.Errorf("Expected invalid percentage to fail")
+	}
+}
+
+func TestFloatPolicy(t *testing.T) {
+
+	options := DefaultIniOptions()
+
+	ic, err := NewIniConfigFromPathWithOptions(typesPath(), options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	for _, p := range []string{"exponent", "inf", "nan", "plain"} {
+		if _, err := ic.ValueAsFloat64("floats", p); err != nil {
+			t.Errorf("Unexpected error for %s: %s", p, err.Error())
+		}
+	}
+
+	options.AllowFloatExponent = false
+
+	if _, err := ic.ValueAsFloat64("floats", "exponent"); err == nil {
+		t.Errorf("Expected exponent notation to fail")
+	}
+
+	if _, err := ic.ValueAsFloat64("floats", "inf"); err != nil {
+		t.Errorf("Did not expect Inf to be treated as exponent notation")
+	}
+
+	options.AllowNonFiniteFloats = false
+
+	for _, p := range []string{"inf", "nan"} {
+		if _, err := ic.ValueAsFloat32("floats", p); err == nil {
+			t.Errorf("Expected %s to fail", p)
+		}
+	}
+
+	if v, err := ic.ValueAsFloat64("floats", "plain"); err != nil || v != 1.5 {
+		t.Errorf("Unexpected result %v %v", v, err)
+	}
+}
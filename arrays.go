@@ -0,0 +1,126 @@
+package inifile
+
+import (
+	"strconv"
+	"strings"
+)
+
+// arraySuffix marks a property name as an element to be appended to an array (servers[]=a)
+const arraySuffix = "[]"
+
+// arrayElementName returns the name a property should be stored under. If AggregateArrayKeys is set in the IniOptions,
+// a name like servers[] is replaced with servers[n], where n is the first index not yet used in the section. Other
+// names (including explicitly indexed names like servers[0]) are returned unchanged.
+func (ic *IniConfig) arrayElementName(section string, storedSection map[string]propertyValue, propertyName string) string {
+
+	if !ic.options.AggregateArrayKeys || !strings.HasSuffix(propertyName, arraySuffix) {
+		return propertyName
+	}
+
+	base := strings.TrimSuffix(propertyName, arraySuffix)
+
+	next := ic.arrayNext[section][base]
+
+	//Start again if the section has been replaced since the index was recorded
+	if _, found := storedSection[elementName(base, next-1)]; next > 0 && !found {
+		next = 0
+	}
+
+	for i := next; ; i++ {
+
+		name := elementName(base, i)
+
+		if _, found := storedSection[name]; !found {
+
+			if ic.arrayNext == nil {
+				ic.arrayNext = make(map[string]map[string]int)
+			}
+
+			if ic.arrayNext[section] == nil {
+				ic.arrayNext[section] = make(map[string]int)
+			}
+
+			ic.arrayNext[section][base] = i + 1
+
+			return name
+		}
+	}
+}
+
+// elementName returns the name of the element of an array property at the supplied index.
+func elementName(propertyName string, index int) string {
+	return propertyName + "[" + strconv.Itoa(index) + "]"
+}
+
+// Values returns the elements of an array property defined with index key syntax:
+//
+//		servers[]=a
+//		servers[]=b
+//
+// or
+//
+//		servers[0]=a
+//		servers[1]=b
+//
+// (the first form requires AggregateArrayKeys to be set in the IniOptions). Elements are returned in index order,
+// starting at zero and stopping at the first missing index. If the property is not an array but is defined as a normal
+// property, a slice containing just its value is returned.
+//
+// Returns an error if the section or property does not exist.
+func (ic *IniConfig) Values(sectionName, propertyName string) ([]string, error) {
+
+	var values []string
+
+	for i := 0; ic.PropertyExists(sectionName, elementName(propertyName, i)); i++ {
+
+		v, err := ic.Value(sectionName, elementName(propertyName, i))
+
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, v)
+	}
+
+	if values != nil {
+		return values, nil
+	}
+
+	v, err := ic.Value(sectionName, propertyName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{v}, nil
+}
+
+// ValueAsStringSlice returns the elements of an array property (see Values) or, if the property is not an array, splits
+// its value around sep. Elements are trimmed of surrounding whitespace and a separator that is part of an element can be
+// escaped with a backslash.
+//
+// Returns an error if the section or property does not exist or if sep is empty.
+func (ic *IniConfig) ValueAsStringSlice(sectionName, propertyName string, sep string) ([]string, error) {
+
+	if sep == "" {
+		return nil, errorf("Separator for ValueAsStringSlice cannot be empty")
+	}
+
+	if ic.PropertyExists(sectionName, elementName(propertyName, 0)) {
+		return ic.Values(sectionName, propertyName)
+	}
+
+	sv, err := ic.Value(sectionName, propertyName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	parts := splitEscaped(sv, sep, -1)
+
+	for i, p := range parts {
+		parts[i] = strings.TrimSpace(unescape(p))
+	}
+
+	return parts, nil
+}
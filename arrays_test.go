@@ -0,0 +1,114 @@
+package inifile
+
+import (
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func arraysPath() string {
+	return filepath.Join(testfiles_base, "arrays.ini")
+}
+
+func TestArrayKeys(t *testing.T) {
+
+	options := DefaultIniOptions()
+	options.AggregateArrayKeys = true
+
+	ic, err := NewIniConfigFromPathWithOptions(arraysPath(), options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if v, _ := ic.Values("cluster", "servers"); !reflect.DeepEqual(v, []string{"alpha", "beta", "gamma"}) {
+		t.Errorf("Unexpected servers %v", v)
+	}
+
+	if v, _ := ic.Values("cluster", "ports"); !reflect.DeepEqual(v, []string{"8080", "8081"}) {
+		t.Errorf("Unexpected ports %v", v)
+	}
+
+	if v, _ := ic.Value("cluster", "servers[1]"); v != "beta" {
+		t.Errorf("Expected individual element to be accessible, was %s", v)
+	}
+
+	ic.Add("cluster", "servers[]", "delta")
+
+	is, _ := ic.Section("cluster")
+
+	if v, _ := is.Values("servers"); len(v) != 4 || v[3] != "delta" {
+		t.Errorf("Expected added element to be appended %v", v)
+	}
+
+	if _, err := ic.Values("cluster", "missing"); err == nil {
+		t.Errorf("Expected missing property to fail")
+	}
+}
+
+func TestArrayKeysAppended(t *testing.T) {
+
+	options := DefaultIniOptions()
+	options.AggregateArrayKeys = true
+
+	var b strings.Builder
+
+	b.WriteString("[a]\nv[1]=explicit\n")
+
+	for i := 0; i < 50000; i++ {
+		b.WriteString("v[]=" + strconv.Itoa(i) + "\n")
+	}
+
+	ic, err := newIniConfigFromReader(strings.NewReader(b.String()), "appended.ini", options)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, "a", "v[0]", "0")
+	checkValue(t, ic, "a", "v[1]", "explicit")
+	checkValue(t, ic, "a", "v[2]", "1")
+	checkValue(t, ic, "a", "v[50000]", "49999")
+
+	ic.Delete("a", "v[2]")
+	ic.Add("a", "v[]", "refilled")
+	ic.Add("a", "v[]", "last")
+
+	checkValue(t, ic, "a", "v[2]", "refilled")
+	checkValue(t, ic, "a", "v[50001]", "last")
+}
+
+func TestArrayKeysNotAggregated(t *testing.T) {
+
+	ic, _ := NewIniConfigFromPath(arraysPath())
+
+	if v, _ := ic.Values("cluster", "servers[]"); !reflect.DeepEqual(v, []string{"gamma"}) {
+		t.Errorf("Expected last definition to win without AggregateArrayKeys %v", v)
+	}
+
+	if v, _ := ic.Values("cluster", "ports"); len(v) != 2 {
+		t.Errorf("Expected explicitly indexed elements to be found %v", v)
+	}
+}
+
+func TestValueAsStringSlice(t *testing.T) {
+
+	options := DefaultIniOptions()
+	options.AggregateArrayKeys = true
+
+	ic, _ := NewIniConfigFromPathWithOptions(arraysPath(), options)
+
+	if v, _ := ic.ValueAsStringSlice("cluster", "hosts", ","); !reflect.DeepEqual(v, []string{"a.example.com", "b.example.com", "c,d.example.com"}) {
+		t.Errorf("Unexpected hosts %v", v)
+	}
+
+	if v, _ := ic.ValueAsStringSlice("cluster", "servers", ","); len(v) != 3 {
+		t.Errorf("Expected array elements %v", v)
+	}
+
+	if _, err := ic.ValueAsStringSlice("cluster", "hosts", ""); err == nil {
+		t.Errorf("Expected empty separator to fail")
+	}
+}
@@ -0,0 +1,119 @@
+package inifile
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+)
+
+// SaveAtomic writes this IniConfig to the file at the supplied path (see WriteTo) in a way that ensures a crash or power
+// failure part-way through never leaves a partially written file. The output is written to a temporary file in the same
+// directory, flushed to disk and then renamed over the target. The file is given the supplied permissions.
+//
+// If keepBackup is true and the file already exists, its previous contents are kept in a file with the same path plus
+// the suffix .bak (replacing any existing backup).
+func (ic *IniConfig) SaveAtomic(path string, perm os.FileMode, keepBackup bool) error {
+	return ic.SaveAtomicWithOptions(path, perm, keepBackup, DefaultIniWriteOptions())
+}
+
+// SaveAtomicWithOptions behaves like SaveAtomic, with the layout of the file controlled by the supplied IniWriteOptions.
+func (ic *IniConfig) SaveAtomicWithOptions(path string, perm os.FileMode, keepBackup bool, wo *IniWriteOptions) error {
+
+	var b bytes.Buffer
+
+	if _, err := ic.WriteToWithOptions(&b, wo); err != nil {
+		return err
+	}
+
+	if err := wo.keepVersions(path); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, b.Bytes(), perm, keepBackup)
+}
+
+// BackupSuffix is appended to the path of a file to create the path of its backup
+const BackupSuffix = ".bak"
+
+// writeFileAtomic writes data to a temporary file in the same directory as path, syncs it and renames it over path.
+func writeFileAtomic(path string, data []byte, perm os.FileMode, keepBackup bool) error {
+
+	dir := filepath.Dir(path)
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp*")
+
+	if err != nil {
+		return err
+	}
+
+	tmpPath := tmp.Name()
+
+	//Remove the temporary file if anything goes wrong before the rename
+	renamed := false
+
+	defer func() {
+		if !renamed {
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if keepBackup {
+		if err := backup(path); err != nil {
+			return errorf("Unable to create backup of %s: %w", path, err)
+		}
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+
+	renamed = true
+
+	//Make sure the rename itself is durable. Not all platforms support syncing a directory, so errors are ignored.
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+
+	return nil
+}
+
+// backup preserves the current contents of the file at path (if it exists) in path + BackupSuffix. A hard link is used
+// where possible, falling back to a copy.
+func backup(path string) error {
+
+	bak := path + BackupSuffix
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	if err := os.Remove(bak); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := os.Link(path, bak); err == nil {
+		return nil
+	}
+
+	return copyFile(path, bak)
+}
@@ -0,0 +1,45 @@
+package inifile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveAtomic(t *testing.T) {
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.ini")
+
+	ic := NewIniConfigFromMap(map[string]map[string]string{"a": {"b": "1"}})
+
+	if err := ic.SaveAtomic(path, 0600, true); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if _, err := os.Stat(path + BackupSuffix); !os.IsNotExist(err) {
+		t.Errorf("Did not expect a backup of a new file")
+	}
+
+	if fi, _ := os.Stat(path); fi.Mode().Perm() != 0600 {
+		t.Errorf("Unexpected permissions %v", fi.Mode())
+	}
+
+	ic.Add("a", "b", "2")
+
+	if err := ic.SaveAtomic(path, 0600, true); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if b, _ := os.ReadFile(path + BackupSuffix); string(b) != "[a]\nb=1\n" {
+		t.Errorf("Unexpected backup contents %s", string(b))
+	}
+
+	if b, _ := os.ReadFile(path); string(b) != "[a]\nb=2\n" {
+		t.Errorf("Unexpected contents %s", string(b))
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+		t.Errorf("Expected temporary files to be removed, found %d files", len(entries))
+	}
+}
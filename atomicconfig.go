@@ -0,0 +1,111 @@
+package inifile
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// AtomicIniConfig holds the current IniConfig of a service whose configuration can change while it is running. Request
+// handlers call Load to obtain the latest configuration while another goroutine replaces it with Store, Reload or
+// Watch, without either side needing a lock. The IniConfigs it holds are shared and must not be modified, so must not
+// be created with NewLazyIniConfig (which parses sections when they are first read).
+type AtomicIniConfig struct {
+	current atomic.Pointer[IniConfig]
+}
+
+// NewAtomicIniConfig creates an AtomicIniConfig holding the supplied IniConfig.
+func NewAtomicIniConfig(ic *IniConfig) *AtomicIniConfig {
+
+	a := new(AtomicIniConfig)
+	a.current.Store(ic)
+
+	return a
+}
+
+// Load returns the most recently stored IniConfig (nil if none has been stored).
+func (a *AtomicIniConfig) Load() *IniConfig {
+	return a.current.Load()
+}
+
+// Store replaces the held IniConfig. Callers of Load that already hold the previous IniConfig continue to use it.
+func (a *AtomicIniConfig) Store(ic *IniConfig) {
+	a.current.Store(ic)
+}
+
+// Reload parses the file at the supplied path with the supplied options and, if parsing succeeds, stores the result.
+// If parsing fails the held IniConfig is not changed and the error is returned.
+func (a *AtomicIniConfig) Reload(path string, options *IniOptions) error {
+	return a.ReloadAndValidate(path, nil, options)
+}
+
+// ReloadAndValidate behaves like Reload, but the new IniConfig is only stored if it is also accepted by validate (see
+// LoadAndValidate). If it is rejected the held IniConfig is not changed and an error wrapping ErrValidationFailed is
+// returned, so callers of Load never see a configuration that failed validation.
+func (a *AtomicIniConfig) ReloadAndValidate(path string, validate func(*IniConfig) error, options *IniOptions) error {
+
+	ic, err := LoadAndValidate(path, validate, options)
+
+	if err != nil {
+		return err
+	}
+
+	a.Store(ic)
+
+	return nil
+}
+
+// Watch checks the file at the supplied path every interval and calls Reload whenever its modification time or size
+// changes (and on the first check), until the supplied context is cancelled. Errors from checking or reloading the file are passed to onError
+// (if it is not nil) and the previously stored IniConfig is kept, so a bad edit never replaces a working configuration.
+//
+// Watch blocks, so it is normally run in its own goroutine:
+//	go current.Watch(ctx, path, options, 5*time.Second, logError)
+func (a *AtomicIniConfig) Watch(ctx context.Context, path string, options *IniOptions, interval time.Duration, onError func(error)) {
+	a.WatchAndValidate(ctx, path, options, interval, nil, onError)
+}
+
+// WatchAndValidate behaves like Watch, but each changed file is reloaded with ReloadAndValidate so that it only replaces
+// the held IniConfig if it is accepted by validate. Validation failures are passed to onError like parse errors.
+func (a *AtomicIniConfig) WatchAndValidate(ctx context.Context, path string, options *IniOptions, interval time.Duration, validate func(*IniConfig) error, onError func(error)) {
+
+	report := func(err error) {
+		if onError != nil {
+			onError(err)
+		}
+	}
+
+	//The file is always reloaded on the first check, so changes made before Watch was called are not missed
+	var modTime time.Time
+	var size int64 = -1
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		fi, err := os.Stat(path)
+
+		if err != nil {
+			report(err)
+			continue
+		}
+
+		if fi.ModTime().Equal(modTime) && fi.Size() == size {
+			continue
+		}
+
+		modTime, size = fi.ModTime(), fi.Size()
+
+		if err := a.ReloadAndValidate(path, validate, options); err != nil {
+			report(err)
+		}
+	}
+}
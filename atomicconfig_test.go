@@ -0,0 +1,116 @@
+package inifile
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAtomicIniConfig(t *testing.T) {
+
+	first := NewIniConfigFromMap(map[string]map[string]string{"a": {"b": "1"}})
+	second := NewIniConfigFromMap(map[string]map[string]string{"a": {"b": "2"}})
+
+	a := NewAtomicIniConfig(first)
+
+	if a.Load() != first {
+		t.Errorf("Expected initial IniConfig")
+	}
+
+	a.Store(second)
+
+	if a.Load() != second {
+		t.Errorf("Expected stored IniConfig")
+	}
+
+	path := filepath.Join(t.TempDir(), "config.ini")
+	os.WriteFile(path, []byte("[a\n"), 0600)
+
+	if err := a.Reload(path, DefaultIniOptions()); err == nil || a.Load() != second {
+		t.Errorf("Expected failed reload to keep the current IniConfig")
+	}
+
+	os.WriteFile(path, []byte("[a]\nb=3\n"), 0600)
+
+	if err := a.Reload(path, DefaultIniOptions()); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, a.Load(), "a", "b", "3")
+}
+
+func TestAtomicIniConfigWatch(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "config.ini")
+	os.WriteFile(path, []byte("[a]\nb=1\n"), 0600)
+
+	ic, _ := NewIniConfigFromPath(path)
+	a := NewAtomicIniConfig(ic)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		a.Watch(ctx, path, DefaultIniOptions(), time.Millisecond, nil)
+	}()
+
+	os.WriteFile(path, []byte("[a]\nb=22\n"), 0600)
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for time.Now().Before(deadline) {
+
+		if v, _ := a.Load().Value("a", "b"); v == "22" {
+			break
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	wg.Wait()
+
+	checkValue(t, a.Load(), "a", "b", "22")
+}
+
+func TestAtomicIniConfigReloadAndValidate(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "config.ini")
+	os.WriteFile(path, []byte("[a]\nb=1\n"), 0600)
+
+	positive := func(ic *IniConfig) error {
+
+		if v, err := ic.ValueAsInt("a", "b"); err != nil || v <= 0 {
+			return errors.New("a.b must be a positive integer")
+		}
+
+		return nil
+	}
+
+	a := NewAtomicIniConfig(nil)
+
+	if err := a.ReloadAndValidate(path, positive, DefaultIniOptions()); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	current := a.Load()
+
+	os.WriteFile(path, []byte("[a]\nb=-1\n"), 0600)
+
+	if err := a.ReloadAndValidate(path, positive, DefaultIniOptions()); !errors.Is(err, ErrValidationFailed) {
+		t.Errorf("Expected validation failure, got %v", err)
+	}
+
+	if a.Load() != current {
+		t.Errorf("Expected rejected configuration not to be stored")
+	}
+
+	checkValue(t, a.Load(), "a", "b", "1")
+}
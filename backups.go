@@ -0,0 +1,103 @@
+package inifile
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// BackupTimeFormat is the layout (see time.Format) of the timestamp appended to copies written to
+// IniWriteOptions.BackupDirectory
+const BackupTimeFormat = "20060102T150405.000000000"
+
+// Returns the current time. Replaced in tests.
+var backupTime = time.Now
+
+// keepVersions preserves the current contents of the file at path (if it exists) as requested in the IniWriteOptions,
+// before the file is replaced.
+func (wo *IniWriteOptions) keepVersions(path string) error {
+
+	if wo.BackupVersions <= 0 && wo.BackupDirectory == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	if wo.BackupVersions > 0 {
+		if err := rotateVersions(path, wo.BackupVersions); err != nil {
+			return errorf("Unable to rotate previous versions of %s: %w", path, err)
+		}
+	}
+
+	if wo.BackupDirectory != "" {
+
+		if err := os.MkdirAll(wo.BackupDirectory, 0755); err != nil {
+			return errorf("Unable to create backup directory %s: %w", wo.BackupDirectory, err)
+		}
+
+		copyPath := filepath.Join(wo.BackupDirectory, filepath.Base(path)+"."+backupTime().UTC().Format(BackupTimeFormat))
+
+		if err := copyFile(path, copyPath); err != nil {
+			return errorf("Unable to copy %s to %s: %w", path, copyPath, err)
+		}
+	}
+
+	return nil
+}
+
+// rotateVersions renames path.1 to path.2 and so on (discarding the oldest version once there are versions copies) and
+// then copies the file at path to path.1.
+func rotateVersions(path string, versions int) error {
+
+	version := func(n int) string {
+		return path + "." + strconv.Itoa(n)
+	}
+
+	if err := os.Remove(version(versions)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	for n := versions - 1; n > 0; n-- {
+		if err := os.Rename(version(n), version(n+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return copyFile(path, version(1))
+}
+
+// copyFile copies the contents of the file at src to a new file at dst with the same permissions, replacing dst if it
+// exists.
+func copyFile(src, dst string) error {
+
+	in, err := os.Open(src)
+
+	if err != nil {
+		return err
+	}
+
+	defer in.Close()
+
+	fi, err := in.Stat()
+
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fi.Mode().Perm())
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,74 @@
+package inifile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackupVersions(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "config.ini")
+
+	wo := DefaultIniWriteOptions()
+	wo.BackupVersions = 2
+
+	ic := NewIniConfigFromMap(map[string]map[string]string{"a": {"b": "1"}})
+
+	for _, v := range []string{"1", "2", "3", "4"} {
+
+		ic.Add("a", "b", v)
+
+		if err := ic.SaveWithOptions(path, wo); err != nil {
+			t.Fatalf("Unexpected error %s", err.Error())
+		}
+	}
+
+	for suffix, expected := range map[string]string{"": "4", ".1": "3", ".2": "2"} {
+		if b, _ := os.ReadFile(path + suffix); string(b) != "[a]\nb="+expected+"\n" {
+			t.Errorf("Unexpected contents of %s: %s", path+suffix, string(b))
+		}
+	}
+
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("Expected no more than two versions to be kept")
+	}
+}
+
+func TestBackupDirectory(t *testing.T) {
+
+	defer func() { backupTime = time.Now }()
+
+	backupTime = func() time.Time {
+		return time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.ini")
+
+	wo := DefaultIniWriteOptions()
+	wo.BackupDirectory = filepath.Join(dir, "backups")
+
+	ic := NewIniConfigFromMap(map[string]map[string]string{"a": {"b": "1"}})
+
+	if err := ic.SaveAtomicWithOptions(path, 0600, false, wo); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if _, err := os.Stat(wo.BackupDirectory); !os.IsNotExist(err) {
+		t.Errorf("Did not expect a copy of a new file")
+	}
+
+	ic.Add("a", "b", "2")
+
+	if err := ic.SaveAtomicWithOptions(path, 0600, false, wo); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	copyPath := filepath.Join(wo.BackupDirectory, "config.ini.20261015T093000.000000000")
+
+	if b, _ := os.ReadFile(copyPath); string(b) != "[a]\nb=1\n" {
+		t.Errorf("Unexpected contents of copy %s", string(b))
+	}
+}
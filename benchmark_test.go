@@ -0,0 +1,143 @@
+package inifile
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+// generatedIni builds an INI document with the supplied number of sections and properties per section, where
+// values are drawn from a small set to mimic machine-generated files.
+func generatedIni(sections, properties int) []byte {
+
+	var b bytes.Buffer
+
+	for s := 0; s < sections; s++ {
+		fmt.Fprintf(&b, "[section%d]\n", s)
+
+		for p := 0; p < properties; p++ {
+			fmt.Fprintf(&b, "property%d=value%d\n", p, p%10)
+		}
+	}
+
+	return b.Bytes()
+}
+
+func BenchmarkParseLargeFile(b *testing.B) {
+
+	content := generatedIni(100, 1000)
+	options := DefaultIniOptions()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := newIniConfigFromReader(bytes.NewReader(content), "generated", options); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValue(b *testing.B) {
+
+	ic, err := newIniConfigFromReader(bytes.NewReader(generatedIni(100, 1000)), "generated", DefaultIniOptions())
+
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ic.Value("section50", "property500")
+	}
+}
+
+func BenchmarkRetainedHeap(b *testing.B) {
+
+	content := generatedIni(100, 1000)
+	options := DefaultIniOptions()
+
+	var before, after runtime.MemStats
+	var retained uint64
+
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		ic, err := newIniConfigFromReader(bytes.NewReader(content), "generated", options)
+
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		runtime.KeepAlive(ic)
+
+		retained += after.HeapAlloc - before.HeapAlloc
+	}
+
+	b.ReportMetric(float64(retained)/float64(b.N), "retained-B/op")
+}
+
+// wideSection builds an IniConfig with a single section containing the supplied number of properties.
+func wideSection(b *testing.B, properties int, options *IniOptions) *IniConfig {
+
+	ic, err := newIniConfigFromReader(bytes.NewReader(generatedIni(1, properties)), "generated", options)
+
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return ic
+}
+
+func BenchmarkValueCaseInsensitiveWideSection(b *testing.B) {
+
+	options := DefaultIniOptions()
+	options.CaseSensitive = false
+
+	ic := wideSection(b, 50000, options)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if !ic.PropertyExists("Section0", "Property25000") {
+			b.Fatal("Property not found")
+		}
+
+		ic.Value("Section0", "Property25000")
+	}
+}
+
+func BenchmarkPropertyNamesWithPrefix(b *testing.B) {
+
+	ic := wideSection(b, 50000, DefaultIniOptions())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if names := ic.PropertyNamesWithPrefix("section0", "property2500"); len(names) != 11 {
+			b.Fatalf("Unexpected names %v", names)
+		}
+	}
+}
+
+func BenchmarkLookupMissing(b *testing.B) {
+
+	ic := wideSection(b, 1000, DefaultIniOptions())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, found := ic.LookupInt64("section0", "missing"); found {
+			b.Fatal("Did not expect property to be found")
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package inifile
+
+import (
+	"errors"
+)
+
+// ValueAsBoolDefault returns the value of the specified property as a bool (see ValueAsBool), or the supplied default
+// if the property does not exist or its value cannot be interpreted as a bool.
+func (ic *IniConfig) ValueAsBoolDefault(sectionName, propertyName string, def bool) bool {
+
+	if v, err := ic.ValueAsBool(sectionName, propertyName); err == nil {
+		return v
+	}
+
+	return def
+}
+
+// ValueAsOptionalBool returns the value of the specified property as a bool (see ValueAsBool) and whether the property
+// is present, distinguishing a property that is false from one that is missing or cannot be interpreted:
+//
+//		false, false, nil		the section or property does not exist
+//		false, true, err		the property exists but its value could not be interpreted as a bool
+//		v, true, nil			the property exists and its value is v
+func (ic *IniConfig) ValueAsOptionalBool(sectionName, propertyName string) (value bool, present bool, err error) {
+
+	v, err := ic.ValueAsBool(sectionName, propertyName)
+
+	if errors.Is(err, ErrSectionNotFound) || errors.Is(err, ErrPropertyNotFound) {
+		return false, false, nil
+	}
+
+	if err != nil {
+		return false, true, err
+	}
+
+	return v, true, nil
+}
@@ -0,0 +1,52 @@
+package inifile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValueAsBoolDefault(t *testing.T) {
+
+	ic, _ := newIniConfigFromReader(strings.NewReader("[flags]\non=true\noff=false\nbad=perhaps\n"), "flags.ini", DefaultIniOptions())
+
+	if !ic.ValueAsBoolDefault("flags", "on", false) || ic.ValueAsBoolDefault("flags", "off", true) {
+		t.Errorf("Expected values from file to be used")
+	}
+
+	if !ic.ValueAsBoolDefault("flags", "missing", true) || !ic.ValueAsBoolDefault("flags", "bad", true) {
+		t.Errorf("Expected default for missing or unparseable values")
+	}
+
+	is, _ := ic.Section("flags")
+
+	if !is.ValueAsBoolDefault("missing", true) {
+		t.Errorf("Expected default for missing value in section")
+	}
+}
+
+func TestValueAsOptionalBool(t *testing.T) {
+
+	ic, _ := newIniConfigFromReader(strings.NewReader("[flags]\non=true\noff=false\nbad=perhaps\n"), "flags.ini", DefaultIniOptions())
+
+	if v, present, err := ic.ValueAsOptionalBool("flags", "off"); v || !present || err != nil {
+		t.Errorf("Expected false and present, got %v %v %v", v, present, err)
+	}
+
+	if v, present, err := ic.ValueAsOptionalBool("flags", "on"); !v || !present || err != nil {
+		t.Errorf("Expected true and present, got %v %v %v", v, present, err)
+	}
+
+	if _, present, err := ic.ValueAsOptionalBool("flags", "missing"); present || err != nil {
+		t.Errorf("Expected missing property to be absent without error, got %v %v", present, err)
+	}
+
+	if _, present, err := ic.ValueAsOptionalBool("other", "on"); present || err != nil {
+		t.Errorf("Expected missing section to be absent without error, got %v %v", present, err)
+	}
+
+	is, _ := ic.Section("flags")
+
+	if _, present, err := is.ValueAsOptionalBool("bad"); !present || err == nil {
+		t.Errorf("Expected unparseable value to be present with an error, got %v %v", present, err)
+	}
+}
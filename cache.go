@@ -0,0 +1,151 @@
+package inifile
+
+import (
+	"container/list"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ConfigCache holds the parsed IniConfigs for a limited number of files so that applications that repeatedly open the
+// same files (e.g. per request or per job) only pay the cost of parsing a file when it changes. A file is re-parsed if
+// its modification time or size differs from when it was last parsed. Once the cache is full, the least recently used
+// file is discarded.
+//
+// The IniConfigs returned by a ConfigCache are shared between callers and must not be modified. A ConfigCache is safe
+// for concurrent use.
+type ConfigCache struct {
+	capacity int
+	options  *IniOptions
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	//Most recently used first
+	order *list.List
+}
+
+// A parsed file held in a ConfigCache
+type cacheEntry struct {
+	path    string
+	modTime time.Time
+	size    int64
+	ic      *IniConfig
+}
+
+// NewConfigCache creates a ConfigCache that holds up to capacity parsed files, parsing them with the supplied options.
+func NewConfigCache(capacity int, options *IniOptions) (*ConfigCache, error) {
+
+	if capacity < 1 {
+		return nil, errorf("ConfigCache capacity must be at least 1 (was %d)", capacity)
+	}
+
+	if options == nil {
+		return nil, errorf("Nil IniOptions provided")
+	}
+
+	cc := new(ConfigCache)
+	cc.capacity = capacity
+	cc.options = options
+	cc.entries = make(map[string]*list.Element)
+	cc.order = list.New()
+
+	return cc, nil
+}
+
+// Get returns the parsed contents of the file at the supplied path, parsing the file if it is not in the cache or has
+// changed since it was parsed. An error is returned if the file cannot be read or parsed, in which case any cached
+// version of the file is discarded.
+func (cc *ConfigCache) Get(path string) (*IniConfig, error) {
+
+	path = filepath.Clean(path)
+
+	fi, err := os.Stat(path)
+
+	if err != nil {
+		cc.Remove(path)
+		return nil, err
+	}
+
+	cc.mu.Lock()
+
+	if e, found := cc.entries[path]; found {
+
+		entry := e.Value.(*cacheEntry)
+
+		if entry.modTime.Equal(fi.ModTime()) && entry.size == fi.Size() {
+			cc.order.MoveToFront(e)
+			cc.mu.Unlock()
+
+			cc.recordLookup(path, true)
+
+			return entry.ic, nil
+		}
+	}
+
+	cc.mu.Unlock()
+
+	cc.recordLookup(path, false)
+
+	//Parsing happens without holding the lock so that different files can be parsed concurrently
+	ic, err := NewIniConfigFromPathWithOptions(path, cc.options)
+
+	if err != nil {
+		cc.Remove(path)
+		return nil, err
+	}
+
+	cc.store(&cacheEntry{path: path, modTime: fi.ModTime(), size: fi.Size(), ic: ic})
+
+	return ic, nil
+}
+
+// recordLookup tells the Metrics in the cache's IniOptions, if set, whether a file was found in the cache.
+func (cc *ConfigCache) recordLookup(path string, hit bool) {
+
+	if m := cc.options.Metrics; m != nil {
+		m.CacheLookup(path, hit)
+	}
+}
+
+// Remove discards any cached version of the file at the supplied path.
+func (cc *ConfigCache) Remove(path string) {
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if e, found := cc.entries[filepath.Clean(path)]; found {
+		cc.order.Remove(e)
+		delete(cc.entries, e.Value.(*cacheEntry).path)
+	}
+}
+
+// Len returns the number of files currently held in the cache.
+func (cc *ConfigCache) Len() int {
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	return cc.order.Len()
+}
+
+// store adds or replaces a parsed file, discarding the least recently used files if the cache is over capacity.
+func (cc *ConfigCache) store(entry *cacheEntry) {
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if e, found := cc.entries[entry.path]; found {
+		e.Value = entry
+		cc.order.MoveToFront(e)
+		return
+	}
+
+	cc.entries[entry.path] = cc.order.PushFront(entry)
+
+	for cc.order.Len() > cc.capacity {
+		oldest := cc.order.Back()
+		cc.order.Remove(oldest)
+		delete(cc.entries, oldest.Value.(*cacheEntry).path)
+	}
+}
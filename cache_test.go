@@ -0,0 +1,87 @@
+package inifile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConfigCache(t *testing.T) {
+
+	dir := t.TempDir()
+
+	paths := make([]string, 3)
+
+	for i, name := range []string{"a.ini", "b.ini", "c.ini"} {
+		paths[i] = filepath.Join(dir, name)
+		os.WriteFile(paths[i], []byte("[s]\nname="+name+"\n"), 0600)
+	}
+
+	cc, err := NewConfigCache(2, DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	first, err := cc.Get(paths[0])
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, first, "s", "name", "a.ini")
+
+	if again, _ := cc.Get(paths[0]); again != first {
+		t.Errorf("Expected unchanged file to be returned from the cache")
+	}
+
+	//Changing the file (size and modification time) causes it to be parsed again
+	os.WriteFile(paths[0], []byte("[s]\nname=changed\n"), 0600)
+	os.Chtimes(paths[0], time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+
+	changed, err := cc.Get(paths[0])
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if changed == first {
+		t.Errorf("Expected changed file to be parsed again")
+	}
+
+	checkValue(t, changed, "s", "name", "changed")
+
+	//Using b and c discards a, the least recently used
+	cc.Get(paths[1])
+	cc.Get(paths[2])
+
+	if cc.Len() != 2 {
+		t.Errorf("Expected 2 cached files, found %d", cc.Len())
+	}
+
+	if reparsed, _ := cc.Get(paths[0]); reparsed == changed {
+		t.Errorf("Expected least recently used file to have been discarded")
+	}
+
+	os.Remove(paths[0])
+
+	if _, err := cc.Get(paths[0]); err == nil {
+		t.Errorf("Expected error for missing file")
+	}
+
+	if cc.Len() != 1 {
+		t.Errorf("Expected missing file to be removed from the cache, found %d files", cc.Len())
+	}
+}
+
+func TestNewConfigCacheErrors(t *testing.T) {
+
+	if _, err := NewConfigCache(0, DefaultIniOptions()); err == nil {
+		t.Errorf("Expected error for zero capacity")
+	}
+
+	if _, err := NewConfigCache(1, nil); err == nil {
+		t.Errorf("Expected error for nil options")
+	}
+}
@@ -0,0 +1,72 @@
+package inifile
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// ChecksumLabel follows the comment symbol in the footer comment that holds the checksum of a file, e.g.
+//	; sha256: 9f86d081884c7d65...
+const ChecksumLabel = "sha256:"
+
+// ErrChecksumMismatch is wrapped by errors returned when content does not match its checksum footer.
+var ErrChecksumMismatch = fmt.Errorf("checksum mismatch")
+
+// AddChecksum returns the supplied INI content with a footer comment holding the SHA-256 checksum of the content as its
+// last line (replacing any existing checksum footer). Content with a checksum footer can be checked when it is parsed by
+// setting VerifyChecksum in the IniOptions.
+func AddChecksum(src []byte, options *IniOptions) ([]byte, error) {
+
+	if options == nil {
+		return nil, errorf("Nil IniOptions provided")
+	}
+
+	body, _ := splitFooter(src, options.CommentStart, ChecksumLabel)
+
+	var b bytes.Buffer
+
+	b.Write(body)
+
+	if len(body) > 0 && body[len(body)-1] != '\n' {
+		b.WriteString(LF)
+	}
+
+	sum := sha256.Sum256(b.Bytes())
+
+	b.WriteString(checksumFooter(sum[:], options.CommentStart, LF))
+
+	return b.Bytes(), nil
+}
+
+// checksumFooter returns the footer comment holding the supplied checksum.
+func checksumFooter(sum []byte, commentStart, eol string) string {
+	return commentStart + " " + ChecksumLabel + " " + hex.EncodeToString(sum) + eol
+}
+
+// checkedContent reads the content to be parsed and checks it matches its checksum footer, returning the content
+// without the footer.
+func (ic *IniConfig) checkedContent(r io.Reader) (io.Reader, error) {
+
+	src, err := io.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, expected := splitFooter(src, ic.options.CommentStart, ChecksumLabel)
+
+	if expected == "" {
+		return nil, wrapf(ErrChecksumMismatch, nil, "No checksum footer found in %s", ic.sourceName())
+	}
+
+	sum := sha256.Sum256(body)
+
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return nil, wrapf(ErrChecksumMismatch, nil, "Content of %s has checksum %s but its footer records %s", ic.sourceName(), actual, expected)
+	}
+
+	return bytes.NewReader(body), nil
+}
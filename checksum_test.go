@@ -0,0 +1,77 @@
+package inifile
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestChecksumFooter(t *testing.T) {
+
+	ic := NewIniConfigFromMap(map[string]map[string]string{"a": {"b": "1"}})
+
+	wo := DefaultIniWriteOptions()
+	wo.ChecksumFooter = true
+
+	var b bytes.Buffer
+
+	if _, err := ic.WriteToWithOptions(&b, wo); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if !strings.HasPrefix(b.String(), "[a]\nb=1\n; sha256: ") {
+		t.Errorf("Unexpected output %s", b.String())
+	}
+
+	if added, _ := AddChecksum([]byte("[a]\nb=1\n"), DefaultIniOptions()); !bytes.Equal(added, b.Bytes()) {
+		t.Errorf("Expected AddChecksum to produce the same footer as the writer, got %s", string(added))
+	}
+
+	opts := DefaultIniOptions()
+	opts.VerifyChecksum = true
+
+	parsed, err := newIniConfigFromReader(bytes.NewReader(b.Bytes()), "checked.ini", opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, parsed, "a", "b", "1")
+
+	edited := bytes.Replace(b.Bytes(), []byte("b=1"), []byte("b=2"), 1)
+
+	if _, err := newIniConfigFromReader(bytes.NewReader(edited), "checked.ini", opts); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Expected edited content to be rejected, got %v", err)
+	}
+
+	if _, err := newIniConfigFromReader(strings.NewReader("[a]\nb=1\n"), "unchecked.ini", opts); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Expected content without a footer to be rejected, got %v", err)
+	}
+}
+
+func TestUpdateFileChecksum(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "checked.ini")
+
+	src, _ := AddChecksum([]byte("[a]\nb=1\n"), DefaultIniOptions())
+	os.WriteFile(path, src, 0600)
+
+	opts := DefaultIniOptions()
+	opts.VerifyChecksum = true
+
+	if err := UpdateFile(path, map[string]map[string]string{"a": {"b": "2"}, "c": {"d": "3"}}, opts); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	ic, err := NewIniConfigFromPathWithOptions(path, opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, "a", "b", "2")
+	checkValue(t, ic, "c", "d", "3")
+}
@@ -0,0 +1,67 @@
+package inifile
+
+import "regexp"
+
+// LineKind identifies how a LineClassifier (see IniOptions) wants a line to be treated.
+type LineKind int
+
+const (
+	// The line is parsed normally, as if no LineClassifier was set
+	LineDefault LineKind = iota
+
+	// The line is ignored
+	LineIgnored
+
+	// The line starts the section called Name
+	LineSection
+
+	// The line defines the property called Name with the value Value in the current section
+	LineProperty
+)
+
+// ClassifiedLine is returned by a LineClassifier to describe a line of a file in a dialect the parser does not
+// understand.
+type ClassifiedLine struct {
+	// How the line should be treated
+	Kind LineKind
+
+	// The name of the section (LineSection) or property (LineProperty)
+	Name string
+
+	// The value of the property (LineProperty)
+	Value string
+}
+
+// classifyLine passes a line to the LineClassifier in this IniConfig's options (if set), returning a LineDefault line if
+// there is no classifier.
+func (ic *IniConfig) classifyLine(line string, lineNumber int) (ClassifiedLine, error) {
+
+	classifier := ic.options.LineClassifier
+
+	if classifier == nil {
+		return ClassifiedLine{}, nil
+	}
+
+	cl, err := classifier(line, lineNumber)
+
+	if err != nil {
+		return cl, &ParseError{Line: lineNumber, Message: "Unable to classify line: " + err.Error(), Err: err}
+	}
+
+	return cl, nil
+}
+
+// matchProperty returns the name and value of a property line in the same form as the submatches of the property
+// regular expression, using the name and value from the LineClassifier if it defined the property.
+func (ic *IniConfig) matchProperty(propRx *regexp.Regexp, line string, classified ClassifiedLine) []string {
+
+	if classified.Kind == LineProperty {
+		return []string{line, classified.Name, classified.Value}
+	}
+
+	if ic.options.EscapedPropertyNames {
+		return matchEscapedProperty(line, ic.assignmentSymbol())
+	}
+
+	return propRx.FindStringSubmatch(line)
+}
@@ -0,0 +1,64 @@
+package inifile
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func vendorClassifier(line string, lineNumber int) (ClassifiedLine, error) {
+
+	fields := strings.Fields(line)
+
+	switch {
+	case len(fields) == 2 && fields[0] == "SECTION":
+		return ClassifiedLine{Kind: LineSection, Name: fields[1]}, nil
+	case len(fields) == 3 && fields[0] == "set":
+		return ClassifiedLine{Kind: LineProperty, Name: fields[1], Value: fields[2]}, nil
+	case strings.HasPrefix(line, "!"):
+		return ClassifiedLine{Kind: LineIgnored}, nil
+	}
+
+	return ClassifiedLine{}, nil
+}
+
+func TestLineClassifier(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "vendor-dialect.cfg")
+
+	if _, err := NewIniConfigFromPath(path); err == nil {
+		t.Errorf("Expected vendor dialect to be unparseable without a LineClassifier")
+	}
+
+	o := DefaultIniOptions()
+	o.LineClassifier = vendorClassifier
+
+	ic, err := NewIniConfigFromPathWithOptions(path, o)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	checkValue(t, ic, GLOBAL_SECTION, "name", "router")
+	checkValue(t, ic, "interfaces", "eth0", "10.0.0.1")
+	checkValue(t, ic, "interfaces", "eth1", "10.0.0.2")
+	checkValue(t, ic, "dns", "server", "10.0.0.53")
+
+	o.LineClassifier = func(line string, lineNumber int) (ClassifiedLine, error) {
+		return ClassifiedLine{}, errors.New("unsupported")
+	}
+
+	if _, err := NewIniConfigFromPathWithOptions(path, o); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("Expected classifier error to be returned with its line number, got %v", err)
+	}
+}
+
+func checkValue(t *testing.T, ic *IniConfig, section, property, expected string) {
+
+	t.Helper()
+
+	if v, err := ic.Value(section, property); err != nil || v != expected {
+		t.Errorf("Expected [%s].%s to be %s, got %s (%v)", section, property, expected, v, err)
+	}
+}
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/graniticio/inifile"
+)
+
+// A property and the Go type inferred for it
+type field struct {
+	name     string
+	property string
+	goType   string
+	accessor string
+	value    string
+	declared bool
+}
+
+// A section and the struct generated for it
+type section struct {
+	name     string
+	field    string
+	typeName string
+	fields   []field
+}
+
+// generate builds formatted Go source for the sections and properties found in the sample IniConfig.
+func generate(ic *inifile.IniConfig, samplePath, pkg, typeName string) ([]byte, error) {
+
+	sections, err := describe(ic, typeName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "// Code generated by inigen from %s; DO NOT EDIT.\n\n", filepath.Base(samplePath))
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	fmt.Fprintf(&b, "import \"github.com/graniticio/inifile\"\n\n")
+
+	fmt.Fprintf(&b, "// %s holds the configuration loaded by Load.\n", typeName)
+	fmt.Fprintf(&b, "type %s struct {\n", typeName)
+
+	for _, s := range sections {
+		fmt.Fprintf(&b, "%s %s\n", s.field, s.typeName)
+	}
+
+	fmt.Fprintf(&b, "}\n\n")
+
+	for _, s := range sections {
+
+		fmt.Fprintf(&b, "// %s holds the properties in the [%s] section.\n", s.typeName, s.name)
+		fmt.Fprintf(&b, "type %s struct {\n", s.typeName)
+
+		for _, f := range s.fields {
+			fmt.Fprintf(&b, "%s %s\n", f.name, f.goType)
+		}
+
+		fmt.Fprintf(&b, "}\n\n")
+	}
+
+	fmt.Fprintf(&b, "// Load parses the INI file at the supplied path. Properties missing from the file are set to their default values.\n")
+	fmt.Fprintf(&b, "func Load(path string) (*%s, error) {\n", typeName)
+	if annotated(sections) {
+		fmt.Fprintf(&b, "opts := inifile.DefaultIniOptions()\nopts.TypeAnnotations = true\n\n")
+		fmt.Fprintf(&b, "ic, err := inifile.NewIniConfigFromPathWithOptions(path, opts)\n\nif err != nil {\nreturn nil, err\n}\n\n")
+	} else {
+		fmt.Fprintf(&b, "ic, err := inifile.NewIniConfigFromPath(path)\n\nif err != nil {\nreturn nil, err\n}\n\n")
+	}
+	fmt.Fprintf(&b, "c := new(%s)\n\n", typeName)
+
+	for _, s := range sections {
+		for _, f := range s.fields {
+
+			target := "c." + s.field + "." + f.name
+
+			fmt.Fprintf(&b, "%s = %s\n\n", target, f.value)
+			fmt.Fprintf(&b, "if ic.PropertyExists(%q, %q) {\n", s.name, f.property)
+			fmt.Fprintf(&b, "if %s, err = ic.%s(%q, %q); err != nil {\nreturn nil, err\n}\n}\n\n", target, f.accessor, s.name, f.property)
+		}
+	}
+
+	fmt.Fprintf(&b, "return c, nil\n}\n")
+
+	return format.Source(b.Bytes())
+}
+
+// describe determines the names and types to generate for each section and property.
+func describe(ic *inifile.IniConfig, typeName string) ([]section, error) {
+
+	var sections []section
+	used := make(map[string]bool)
+
+	for _, name := range ic.SectionNames() {
+
+		is, _ := ic.Section(name)
+
+		s := section{name: name}
+
+		if name == inifile.GLOBAL_SECTION {
+			s.field = uniqueIdentifier("Global", used)
+		} else {
+			s.field = uniqueIdentifier(identifier(name), used)
+		}
+
+		s.typeName = typeName + s.field
+
+		usedFields := make(map[string]bool)
+
+		for _, property := range is.PropertyNames() {
+
+			v := is.ValueOrZero(property)
+
+			var f field
+
+			if declared, found := ic.DeclaredType(name, property); found {
+
+				var err error
+
+				if f, err = declaredField(declared, v); err != nil {
+					return nil, fmt.Errorf("Unable to use the value of [%s].%s as a default: %s", name, property, err.Error())
+				}
+
+			} else {
+				f = infer(v)
+			}
+
+			f.property = property
+			f.name = uniqueIdentifier(identifier(property), usedFields)
+
+			s.fields = append(s.fields, f)
+		}
+
+		sections = append(sections, s)
+	}
+
+	return sections, nil
+}
+
+// annotated returns true if any property in the sample file had a type annotation.
+func annotated(sections []section) bool {
+
+	for _, s := range sections {
+		for _, f := range s.fields {
+			if f.declared {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// declaredField uses the type declared for a property with a type annotation like port:int. Returns an error if the
+// sample value cannot be written as a Go literal of the declared type.
+func declaredField(declared, v string) (field, error) {
+
+	f := field{goType: declared, declared: true, value: v}
+
+	var err error
+
+	switch declared {
+	case "string":
+		f.accessor = "Value"
+		f.value = strconv.Quote(v)
+		return f, nil
+	case "bool":
+
+		var b bool
+
+		b, err = strconv.ParseBool(v)
+		f.value = strconv.FormatBool(b)
+
+	case "int", "int64":
+		f.value, err = intLiteral(v, 64)
+	case "int32":
+		f.value, err = intLiteral(v, 32)
+	case "uint16":
+		f.value, err = uintLiteral(v, 16)
+	case "uint32":
+		f.value, err = uintLiteral(v, 32)
+	case "uint64":
+		f.value, err = uintLiteral(v, 64)
+	case "float32":
+		f.value, err = floatLiteralOf(v, 32)
+	case "float64":
+		f.value, err = floatLiteralOf(v, 64)
+	default:
+		err = fmt.Errorf("unsupported type %s", declared)
+	}
+
+	if err != nil {
+		return f, err
+	}
+
+	f.accessor = "ValueAs" + strings.ToUpper(declared[:1]) + declared[1:]
+
+	return f, nil
+}
+
+// Values that can be written as Go floating point literals (excluding NaN, Inf and hexadecimal forms)
+var floatLiteral = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// intLiteral parses a sample value the same way as inifile (base 10) and formats it as a Go literal. The sample text
+// cannot be used directly as a value with a leading zero (e.g. 0022) would be read by Go as an octal literal.
+func intLiteral(v string, bitSize int) (string, error) {
+
+	i, err := strconv.ParseInt(v, 10, bitSize)
+
+	if err != nil {
+		return v, err
+	}
+
+	return strconv.FormatInt(i, 10), nil
+}
+
+// uintLiteral parses a sample value the same way as inifile (base 10) and formats it as a Go literal.
+func uintLiteral(v string, bitSize int) (string, error) {
+
+	u, err := strconv.ParseUint(v, 10, bitSize)
+
+	if err != nil {
+		return v, err
+	}
+
+	return strconv.FormatUint(u, 10), nil
+}
+
+// floatLiteralOf parses a sample value that is a finite decimal number and formats it as a Go literal.
+func floatLiteralOf(v string, bitSize int) (string, error) {
+
+	if !floatLiteral.MatchString(v) {
+		return v, fmt.Errorf("%s is not a finite decimal number", v)
+	}
+
+	fv, err := strconv.ParseFloat(v, bitSize)
+
+	if err != nil {
+		return v, err
+	}
+
+	return strconv.FormatFloat(fv, 'g', -1, bitSize), nil
+}
+
+// infer chooses the most specific Go type that the sample value can be converted to.
+func infer(v string) field {
+
+	if i, err := intLiteral(v, 64); err == nil {
+		return field{goType: "int64", accessor: "ValueAsInt64", value: i}
+	}
+
+	if f, err := floatLiteralOf(v, 64); err == nil {
+		return field{goType: "float64", accessor: "ValueAsFloat64", value: f}
+	}
+
+	switch strings.ToLower(v) {
+	case "true":
+		return field{goType: "bool", accessor: "ValueAsBool", value: "true"}
+	case "false":
+		return field{goType: "bool", accessor: "ValueAsBool", value: "false"}
+	}
+
+	return field{goType: "string", accessor: "Value", value: strconv.Quote(v)}
+}
+
+// identifier converts an INI name like database-host or max_connections into an exported Go identifier.
+func identifier(name string) string {
+
+	var b strings.Builder
+
+	upper := true
+
+	for _, r := range name {
+
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+
+		b.WriteRune(r)
+	}
+
+	id := b.String()
+
+	if id == "" || unicode.IsDigit(rune(id[0])) {
+		id = "X" + id
+	}
+
+	return id
+}
+
+// uniqueIdentifier appends a number to the identifier if it has already been used.
+func uniqueIdentifier(id string, used map[string]bool) string {
+
+	candidate := id
+
+	for i := 2; used[candidate]; i++ {
+		candidate = id + strconv.Itoa(i)
+	}
+
+	used[candidate] = true
+
+	return candidate
+}
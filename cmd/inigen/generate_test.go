@@ -0,0 +1,139 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/graniticio/inifile"
+)
+
+func TestGenerate(t *testing.T) {
+
+	path := filepath.Join("..", "..", "testfiles", "types.ini")
+
+	ic, err := inifile.NewIniConfigFromPath(path)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	src, err := generate(ic, path, "config", "Config")
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "config_gen.go", src, 0); err != nil {
+		t.Fatalf("Generated source does not parse: %s", err.Error())
+	}
+
+	generated := string(src)
+
+	for _, expected := range []string{"Boolean ConfigBoolean", "Value1 bool", "Negative float64", "String   string", `ic.ValueAsInt64("int", "positive")`} {
+		if !strings.Contains(generated, expected) {
+			t.Errorf("Expected generated source to contain %s", expected)
+		}
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+
+	for name, expected := range map[string]string{"max_connections": "MaxConnections", "database-host": "DatabaseHost", "1st": "X1st"} {
+		if id := identifier(name); id != expected {
+			t.Errorf("Expected %s for %s, was %s", expected, name, id)
+		}
+	}
+}
+
+func TestGenerateAnnotated(t *testing.T) {
+
+	path := filepath.Join("..", "..", "testfiles", "annotated.ini")
+
+	opts := inifile.DefaultIniOptions()
+	opts.TypeAnnotations = true
+
+	ic, err := inifile.NewIniConfigFromPathWithOptions(path, opts)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	src, err := generate(ic, path, "config", "Config")
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	generated := string(src)
+
+	for _, expected := range []string{"Port    int", "Timeout float64", `ic.ValueAsInt("server", "port")`, "opts.TypeAnnotations = true", `c.Server.Name = "example"`} {
+		if !strings.Contains(generated, expected) {
+			t.Errorf("Expected generated source to contain %s", expected)
+		}
+	}
+}
+
+func TestInferNonFiniteFloats(t *testing.T) {
+
+	for _, v := range []string{"NaN", "Inf", "-infinity", "0x1p-2"} {
+		if f := infer(v); f.goType != "string" {
+			t.Errorf("Expected %s to be inferred as a string, was %s", v, f.goType)
+		}
+	}
+
+	for _, v := range []string{"1.5", "-2e10", ".5"} {
+		if f := infer(v); f.goType != "float64" {
+			t.Errorf("Expected %s to be inferred as a float64, was %s", v, f.goType)
+		}
+	}
+}
+
+func TestDeclaredFieldValidation(t *testing.T) {
+
+	if f, err := declaredField("bool", "1"); err != nil || f.value != "true" {
+		t.Errorf("Expected 1 to be a true bool, got %s (%v)", f.value, err)
+	}
+
+	for declared, v := range map[string]string{"float64": "NaN", "int32": "3000000000", "bool": "yes", "uint16": "-1"} {
+		if _, err := declaredField(declared, v); err == nil {
+			t.Errorf("Expected %s to be rejected as a %s", v, declared)
+		}
+	}
+}
+
+func TestGenerateLeadingZeros(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "zeros.ini")
+	os.WriteFile(path, []byte("[server]\nmode=0022\nport:uint16=08080\nratio=007\n"), 0600)
+
+	opts := inifile.DefaultIniOptions()
+	opts.TypeAnnotations = true
+
+	ic, err := inifile.NewIniConfigFromPathWithOptions(path, opts)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	src, err := generate(ic, path, "config", "Config")
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	generated := string(src)
+
+	for _, expected := range []string{"c.Server.Mode = 22\n", "c.Server.Port = 8080\n", "c.Server.Ratio = 7\n"} {
+		if !strings.Contains(generated, expected) {
+			t.Errorf("Expected generated source to contain %s", expected)
+		}
+	}
+
+	if f, err := declaredField("float64", "010"); err != nil || f.value != "10" {
+		t.Errorf("Expected 010 to be written as 10, got %s (%v)", f.value, err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 Granitic. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be found in the LICENSE file at the root of this project.
+
+/*
+The inigen tool reads a sample INI file and generates Go source containing a strongly-typed struct for the configuration
+and a Load function that populates it from an INI file using the github.com/graniticio/inifile package.
+
+Each section becomes a struct type and a field of the top-level type. The type of each property is inferred from its value
+in the sample file (int64, float64, bool or string) unless it is declared with a type annotation like port:int=8080, and
+the sample value is used as the default when a property is missing
+from the file being loaded.
+
+It is intended to be used with go:generate:
+
+	//go:generate inigen -in defaults.ini -out config_gen.go -package config
+
+Flags:
+
+	-in string
+		The sample INI file
+	-out string
+		The file to write generated source to (default stdout)
+	-package string
+		The package name for the generated source (default "config")
+	-type string
+		The name of the generated top-level type (default "Config")
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/graniticio/inifile"
+)
+
+func main() {
+
+	in := flag.String("in", "", "The sample INI file")
+	out := flag.String("out", "", "The file to write generated source to (default stdout)")
+	pkg := flag.String("package", "config", "The package name for the generated source")
+	typeName := flag.String("type", "Config", "The name of the generated top-level type")
+
+	flag.Parse()
+
+	if *in == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opts := inifile.DefaultIniOptions()
+	opts.TypeAnnotations = true
+
+	ic, err := inifile.NewIniConfigFromPathWithOptions(*in, opts)
+
+	if err != nil {
+		exitWithError(err)
+	}
+
+	src, err := generate(ic, *in, *pkg, *typeName)
+
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(src)
+	} else if err := os.WriteFile(*out, src, 0644); err != nil {
+		exitWithError(err)
+	}
+}
+
+func exitWithError(err error) {
+	fmt.Fprintf(os.Stderr, "inigen: %s\n", err.Error())
+	os.Exit(1)
+}
@@ -0,0 +1,234 @@
+// Copyright 2017 Granitic. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be found in the LICENSE file at the root of this project.
+
+/*
+The iniq tool provides command line access to the values in INI files, using the github.com/graniticio/inifile package.
+
+Usage:
+
+	iniq [flags] get file section property
+	iniq [flags] set file section property value
+	iniq [flags] delete file section property
+	iniq [flags] validate file
+	iniq [flags] lint file
+	iniq [flags] tojson file
+	iniq [flags] fromjson file
+
+Use an empty string ("") as the section name to work with properties in the global section. The set command
+changes only the line holding the property (or adds one) and the delete command removes only the lines holding the
+property, keeping the rest of the file as it is. The validate command reports any warnings found while parsing the file
+and, if -schema is set, fails if the file does not match the schema. The tojson and fromjson commands write their output to stdout. The values of sensitive properties are
+redacted by tojson, so they are lost if its output is converted back with fromjson. The lint command reports
+problems of style and correctness and fails if any are warnings or errors.
+
+Flags:
+
+	-comment string
+		The string that starts a comment line (default ";")
+	-colon
+		Properties are assigned with : rather than =
+	-insensitive
+		Section and property names are not case sensitive
+	-inline
+		Allow comments on the same line as sections and properties
+	-schema string
+		The path of an IniSchema file used by the validate command
+	-strip-quotes
+		Remove enclosing quotes from values
+*/
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/graniticio/inifile"
+)
+
+func main() {
+
+	opts := inifile.DefaultIniOptions()
+
+	fs := flag.NewFlagSet("iniq", flag.ContinueOnError)
+	fs.StringVar(&opts.CommentStart, "comment", opts.CommentStart, "The string that starts a comment line")
+	colon := fs.Bool("colon", false, "Properties are assigned with : rather than =")
+	insensitive := fs.Bool("insensitive", false, "Section and property names are not case sensitive")
+	fs.BoolVar(&opts.AllowInlineComments, "inline", false, "Allow comments on the same line as sections and properties")
+	fs.BoolVar(&opts.StripEnclosingQuotes, "strip-quotes", false, "Remove enclosing quotes from values")
+	schema := fs.String("schema", "", "The path of an IniSchema file used by the validate command")
+	fs.Usage = func() { usage(fs) }
+
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
+
+	opts.UseColonAssignment = *colon
+	opts.CaseSensitive = !*insensitive
+
+	if err := run(fs.Args(), opts, *schema, os.Stdout); err != nil {
+
+		if err == errUsage {
+			usage(fs)
+			os.Exit(2)
+		}
+
+		fmt.Fprintf(os.Stderr, "iniq: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+var errUsage = fmt.Errorf("usage")
+
+// The number of arguments (including the command itself) required by each command
+var argCounts = map[string]int{
+	"get":      4,
+	"set":      5,
+	"delete":   4,
+	"validate": 2,
+	"lint":     2,
+	"tojson":   2,
+	"fromjson": 2,
+}
+
+func run(args []string, opts *inifile.IniOptions, schema string, out io.Writer) error {
+
+	if len(args) == 0 || argCounts[args[0]] != len(args) {
+		return errUsage
+	}
+
+	command, path := args[0], args[1]
+
+	if command == "fromjson" {
+		return fromJSON(path, opts, out)
+	}
+
+	if command == "lint" {
+		return lint(path, opts, out)
+	}
+
+	ic, err := inifile.NewIniConfigFromPathWithOptions(path, opts)
+
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "get":
+		v, err := ic.Value(args[2], args[3])
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(out, v)
+
+	case "set":
+		return inifile.UpdateFile(path, map[string]map[string]string{args[2]: {args[3]: args[4]}}, opts)
+
+	case "delete":
+		if !ic.PropertyExists(args[2], args[3]) {
+			return fmt.Errorf("No such property [%s].%s", args[2], args[3])
+		}
+
+		return inifile.RemoveFromFile(path, map[string][]string{args[2]: {args[3]}}, opts)
+
+	case "validate":
+		for _, w := range ic.Warnings() {
+			fmt.Fprintf(out, "warning: %s\n", w.String())
+		}
+
+		if schema != "" {
+
+			s, err := inifile.NewIniSchemaFromPath(schema)
+
+			if err != nil {
+				return err
+			}
+
+			if err := s.Validate(ic); err != nil {
+				return fmt.Errorf("%s does not match the schema in %s:\n%s", path, schema, err.Error())
+			}
+		}
+
+		fmt.Fprintf(out, "%s: OK\n", path)
+
+	case "tojson":
+		b, err := json.MarshalIndent(ic, "", "  ")
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(out, string(b))
+	}
+
+	return nil
+}
+
+func fromJSON(path string, opts *inifile.IniOptions, out io.Writer) error {
+
+	b, err := os.ReadFile(path)
+
+	if err != nil {
+		return err
+	}
+
+	ic, err := inifile.NewIniConfigFromJSON(b, opts)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = ic.WriteTo(out)
+
+	return err
+}
+
+func lint(path string, opts *inifile.IniOptions, out io.Writer) error {
+
+	f, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	findings, err := inifile.Lint(f, opts)
+
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+
+	for _, finding := range findings {
+		fmt.Fprintf(out, "%s:%s\n", path, finding.String())
+
+		if finding.Severity >= inifile.SeverityWarning {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d problems found in %s", failed, path)
+	}
+
+	return nil
+}
+
+func usage(fs *flag.FlagSet) {
+	fmt.Fprintf(os.Stderr, "Usage:\n")
+	fmt.Fprintf(os.Stderr, "  iniq [flags] get file section property\n")
+	fmt.Fprintf(os.Stderr, "  iniq [flags] set file section property value\n")
+	fmt.Fprintf(os.Stderr, "  iniq [flags] delete file section property\n")
+	fmt.Fprintf(os.Stderr, "  iniq [flags] validate file\n")
+	fmt.Fprintf(os.Stderr, "  iniq [flags] lint file\n")
+	fmt.Fprintf(os.Stderr, "  iniq [flags] tojson file\n")
+	fmt.Fprintf(os.Stderr, "  iniq [flags] fromjson file\n\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	fs.PrintDefaults()
+}
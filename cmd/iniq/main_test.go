@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/graniticio/inifile"
+)
+
+func TestCommands(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "test.ini")
+	os.WriteFile(path, []byte("[db]\nhost = localhost ;Primary\nport=5432\n"), 0600)
+
+	opts := inifile.DefaultIniOptions()
+	opts.AllowInlineComments = true
+
+	var out bytes.Buffer
+
+	if err := run([]string{"get", path, "db", "host"}, opts, "", &out); err != nil || out.String() != "localhost\n" {
+		t.Errorf("Unexpected result from get: %v %s", err, out.String())
+	}
+
+	if err := run([]string{"set", path, "db", "host", "remote"}, opts, "", &out); err != nil {
+		t.Errorf("Unexpected error from set: %s", err.Error())
+	}
+
+	if b, _ := os.ReadFile(path); string(b) != "[db]\nhost = remote ;Primary\nport=5432\n" {
+		t.Errorf("Unexpected file contents after set %s", string(b))
+	}
+
+	if err := run([]string{"delete", path, "db", "port"}, opts, "", &out); err != nil {
+		t.Errorf("Unexpected error from delete: %s", err.Error())
+	}
+
+	if b, _ := os.ReadFile(path); string(b) != "[db]\nhost = remote ;Primary\n" {
+		t.Errorf("Unexpected file contents %s", string(b))
+	}
+
+	if err := run([]string{"get", path, "db"}, opts, "", &out); err != errUsage {
+		t.Errorf("Expected usage error")
+	}
+
+	if err := run([]string{"get", path, "db", "port"}, opts, "", &out); err == nil {
+		t.Errorf("Expected missing property to fail")
+	}
+}
+
+func TestLint(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "test.ini")
+	os.WriteFile(path, []byte("[db]\nhost=a\nhost=b\n"), 0600)
+
+	var out bytes.Buffer
+
+	if err := run([]string{"lint", path}, inifile.DefaultIniOptions(), "", &out); err == nil {
+		t.Errorf("Expected lint to fail for duplicate key")
+	}
+
+	if !bytes.Contains(out.Bytes(), []byte(path+":3: warning:")) {
+		t.Errorf("Unexpected lint output %s", out.String())
+	}
+}
+
+func TestValidateWithSchema(t *testing.T) {
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.ini")
+	schema := filepath.Join(dir, "schema.ini")
+
+	os.WriteFile(path, []byte("[db]\nport=abc\n"), 0600)
+	os.WriteFile(schema, []byte("[db.port]\ntype=int\nrequired=true\n"), 0600)
+
+	var out bytes.Buffer
+
+	if err := run([]string{"validate", path}, inifile.DefaultIniOptions(), "", &out); err != nil {
+		t.Errorf("Unexpected error without a schema: %s", err.Error())
+	}
+
+	if err := run([]string{"validate", path}, inifile.DefaultIniOptions(), schema, &out); err == nil {
+		t.Errorf("Expected validation against the schema to fail")
+	}
+
+	os.WriteFile(path, []byte("[db]\nport=5432\n"), 0600)
+
+	if err := run([]string{"validate", path}, inifile.DefaultIniOptions(), schema, &out); err != nil {
+		t.Errorf("Unexpected error %s", err.Error())
+	}
+}
@@ -0,0 +1,52 @@
+package inifile
+
+import (
+	"strings"
+)
+
+// SetInlineComment attaches a comment to a property, which is written after the property's value by WriteTo and Save,
+// e.g. timeout=30 ; seconds. The comment starts with the CommentStart in the IniOptions. If AllowInlineComments is
+// false in the IniOptions the comment would be read back as part of the value, so it is written on its own line above
+// the property instead. An empty comment removes any comment attached to the property.
+//
+// Returns an error if the property does not exist or the comment contains a line break.
+func (ic *IniConfig) SetInlineComment(sectionName, propertyName, comment string) error {
+
+	if !ic.stored(sectionName, propertyName) {
+		ic.lookupMissed(sectionName, propertyName)
+		return wrapf(ErrPropertyNotFound, nil, "Property [%s].%s does not exist", sectionName, propertyName)
+	}
+
+	if strings.ContainsAny(comment, "\r\n") {
+		return errorf("Comment for [%s].%s cannot contain a line break", sectionName, propertyName)
+	}
+
+	section := ic.normalise(sectionName)
+	property := ic.normalise(propertyName)
+
+	if comment == "" {
+		delete(ic.inlineComments[section], property)
+		return nil
+	}
+
+	if ic.inlineComments == nil {
+		ic.inlineComments = make(map[string]map[string]string)
+	}
+
+	if ic.inlineComments[section] == nil {
+		ic.inlineComments[section] = make(map[string]string)
+	}
+
+	ic.inlineComments[section][property] = comment
+
+	return nil
+}
+
+// InlineComment returns the comment attached to a property with SetInlineComment and true, or an empty string and
+// false if the property has no comment.
+func (ic *IniConfig) InlineComment(sectionName, propertyName string) (string, bool) {
+
+	comment, found := ic.inlineComments[ic.normalise(sectionName)][ic.normalise(propertyName)]
+
+	return comment, found
+}
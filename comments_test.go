@@ -0,0 +1,70 @@
+package inifile
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSetInlineComment(t *testing.T) {
+
+	opts := DefaultIniOptions()
+	opts.AllowInlineComments = true
+
+	ic, _ := newIniConfigFromReader(strings.NewReader("[server]\ntimeout=30\nmode=fast\n"), "test", opts)
+
+	if err := ic.SetInlineComment("server", "timeout", "seconds"); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if c, found := ic.InlineComment("server", "timeout"); !found || c != "seconds" {
+		t.Errorf("Unexpected comment %s", c)
+	}
+
+	var b bytes.Buffer
+	ic.WriteTo(&b)
+
+	if b.String() != "[server]\ntimeout=30 ; seconds\nmode=fast\n" {
+		t.Errorf("Unexpected output %q", b.String())
+	}
+
+	reparsed, _ := newIniConfigFromReader(&b, "test", opts)
+	checkValue(t, reparsed, "server", "timeout", "30")
+
+	ic.SetInlineComment("server", "timeout", "")
+
+	if _, found := ic.InlineComment("server", "timeout"); found {
+		t.Errorf("Expected empty comment to remove the comment")
+	}
+
+	if err := ic.SetInlineComment("server", "missing", "x"); !errors.Is(err, ErrPropertyNotFound) {
+		t.Errorf("Expected missing property to fail")
+	}
+
+	if err := ic.SetInlineComment("server", "mode", "a\nb"); err == nil {
+		t.Errorf("Expected comment with a line break to fail")
+	}
+
+	ic.SetInlineComment("server", "mode", "fast or slow")
+	ic.Delete("server", "mode")
+	ic.Add("server", "mode", "slow")
+
+	if _, found := ic.InlineComment("server", "mode"); found {
+		t.Errorf("Expected deleting a property to remove its comment")
+	}
+}
+
+func TestInlineCommentWithoutInlineComments(t *testing.T) {
+
+	ic := NewIniConfigFromMap(map[string]map[string]string{"server": {"timeout": "30"}})
+
+	ic.SetInlineComment("server", "timeout", "seconds")
+
+	var b bytes.Buffer
+	ic.WriteTo(&b)
+
+	if b.String() != "[server]\n; seconds\ntimeout=30\n" {
+		t.Errorf("Unexpected output %q", b.String())
+	}
+}
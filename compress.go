@@ -0,0 +1,56 @@
+package inifile
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// The first bytes of gzip and bzip2 compressed content
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	bzip2Magic = []byte("BZh")
+)
+
+// decompressed returns a reader of the decompressed content of the supplied reader if the content starts with the magic
+// number of gzip or bzip2 compressed data, or the supplied reader (with the same content) if it does not.
+//
+// Returns an error if the name of the source has a .gz or .bz2 extension but the content is not compressed in that
+// format, or if the gzip header is invalid.
+func (ic *IniConfig) decompressed(r io.Reader) (io.Reader, error) {
+
+	br := bufio.NewReader(r)
+
+	magic, _ := br.Peek(len(bzip2Magic))
+
+	ext := strings.ToLower(filepath.Ext(ic.source))
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+
+		zr, err := gzip.NewReader(br)
+
+		if err != nil {
+			return nil, errorf("Unable to decompress %s: %w", ic.sourceName(), err)
+		}
+
+		ic.debugf("Decompressing gzip content from %s", ic.sourceName())
+
+		return zr, nil
+
+	case bytes.HasPrefix(magic, bzip2Magic):
+
+		ic.debugf("Decompressing bzip2 content from %s", ic.sourceName())
+
+		return bzip2.NewReader(br), nil
+
+	case ext == ".gz" || ext == ".bz2":
+		return nil, errorf("Unable to decompress %s: content is not in the format its %s extension suggests", ic.sourceName(), ext)
+	}
+
+	return br, nil
+}
@@ -0,0 +1,75 @@
+package inifile
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDecompressGzip(t *testing.T) {
+
+	var b bytes.Buffer
+
+	zw := gzip.NewWriter(&b)
+	zw.Write([]byte("[db]\nhost=h\n"))
+	zw.Close()
+
+	path := filepath.Join(t.TempDir(), "config.ini.gz")
+	os.WriteFile(path, b.Bytes(), 0600)
+
+	opts := DefaultIniOptions()
+	opts.Decompress = true
+
+	ic, err := NewIniConfigFromPathWithOptions(path, opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, "db", "host", "h")
+
+	is, err := ExtractSection(bytes.NewReader(b.Bytes()), "db", opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if v, _ := is.Value("host"); v != "h" {
+		t.Errorf("Unexpected value %s", v)
+	}
+}
+
+func TestDecompressBzip2(t *testing.T) {
+
+	opts := DefaultIniOptions()
+	opts.Decompress = true
+
+	ic, err := NewIniConfigFromPathWithOptions("testfiles/simple.ini.bz2", opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, "Section1", "name1", "value1")
+}
+
+func TestDecompressUncompressed(t *testing.T) {
+
+	opts := DefaultIniOptions()
+	opts.Decompress = true
+
+	ic, err := newIniConfigFromReader(strings.NewReader("[db]\nhost=h\n"), "config.ini", opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, "db", "host", "h")
+
+	if _, err := newIniConfigFromReader(strings.NewReader("[db]\nhost=h\n"), "config.ini.gz", opts); err == nil {
+		t.Errorf("Expected uncompressed content with a .gz extension to fail")
+	}
+}
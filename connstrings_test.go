@@ -0,0 +1,87 @@
+package inifile
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConnectionStrings(t *testing.T) {
+
+	src := `[mysql]
+host=db.example.com
+user=app
+password=secret
+database=orders
+params=parseTime=true
+
+[mysqlsocket]
+socket=/var/run/mysqld/mysqld.sock
+user=app
+database=orders
+
+[postgres]
+host=::1
+port=5433
+user=app
+password=p@ss word
+database=orders
+sslmode=require
+
+[api]
+host=api.example.com
+port=8443
+path=v1/status
+params=verbose=1
+
+[smtp]
+host=mail.example.com
+
+[incomplete]
+host=db.example.com
+`
+
+	ic, err := newIniConfigFromReader(strings.NewReader(src), "conn.ini", DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	for _, c := range []struct {
+		build    func() (string, error)
+		expected string
+	}{
+		{func() (string, error) { return ic.MySQLDSN("mysql") }, "app:secret@tcp(db.example.com:3306)/orders?parseTime=true"},
+		{func() (string, error) { return ic.MySQLDSN("mysqlsocket") }, "app@unix(/var/run/mysqld/mysqld.sock)/orders"},
+		{func() (string, error) { return ic.PostgresDSN("postgres") }, "postgres://app:p%40ss%20word@[::1]:5433/orders?sslmode=require"},
+		{func() (string, error) { return ic.SMTPAddress("smtp") }, "mail.example.com:25"},
+		{func() (string, error) {
+			u, err := ic.URL("api", "https")
+
+			if err != nil {
+				return "", err
+			}
+
+			return u.String(), nil
+		}, "https://api.example.com:8443/v1/status?verbose=1"},
+	} {
+
+		s, err := c.build()
+
+		if err != nil {
+			t.Errorf("Unexpected error %s", err.Error())
+		} else if s != c.expected {
+			t.Errorf("Expected %s, got %s", c.expected, s)
+		}
+	}
+
+	is, _ := ic.Section("incomplete")
+
+	if _, err := is.MySQLDSN(); !errors.Is(err, ErrPropertyNotFound) || !strings.Contains(err.Error(), "user, database") {
+		t.Errorf("Expected missing properties error, got %v", err)
+	}
+
+	if _, err := ic.PostgresDSN("missing"); !errors.Is(err, ErrSectionNotFound) {
+		t.Errorf("Expected missing section error, got %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+package inifile
+
+// SectionCount returns the number of sections containing at least one property (including GLOBAL_SECTION if it has
+// properties). Sections that only have default values (see SetDefault) are not counted.
+func (ic *IniConfig) SectionCount() int {
+	return len(ic.SectionNames())
+}
+
+// PropertyCount returns the total number of properties in all sections. Properties that only have default values (see
+// SetDefault) are not counted.
+func (ic *IniConfig) PropertyCount() int {
+
+	count := 0
+
+	for _, section := range ic.SectionNames() {
+		count += len(ic.sections[section])
+	}
+
+	return count
+}
@@ -0,0 +1,30 @@
+package inifile
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCounts(t *testing.T) {
+
+	ic, err := NewIniConfigFromPath(filepath.Join(testfiles_base, "global-section.ini"))
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	ic.SetDefault("defaults", "only", "x")
+	ic.Add("section", "added", "y")
+
+	if ic.SectionCount() != 2 || ic.PropertyCount() != 3 {
+		t.Errorf("Expected 2 sections and 3 properties, got %d and %d", ic.SectionCount(), ic.PropertyCount())
+	}
+
+	if is, _ := ic.Section("section"); is.Len() != 2 {
+		t.Errorf("Expected 2 properties in section, got %d", is.Len())
+	}
+
+	if is, _ := ic.Section("defaults"); is.Len() != 0 {
+		t.Errorf("Expected default values not to be counted, got %d", is.Len())
+	}
+}
@@ -0,0 +1,102 @@
+package inifile
+
+// ValueOrigin identifies where the effective value of a property came from
+type ValueOrigin int
+
+const (
+	// The value was registered with SetDefault
+	OriginDefault ValueOrigin = iota
+	// The value was parsed from a file (or merged from an IniConfig that parsed it from a file)
+	OriginFile
+	// The value was set at runtime with Add
+	OriginOverride
+)
+
+// String returns a lower case description of the origin.
+func (vo ValueOrigin) String() string {
+
+	switch vo {
+	case OriginDefault:
+		return "default"
+	case OriginFile:
+		return "file"
+	}
+
+	return "override"
+}
+
+// SetDefault registers an application-level default value for a property. Default values are used by Value (and every
+// accessor built on it), PropertyExists and SectionExists when the property has not been parsed from a file or added
+// with Add, but are not written out by WriteTo or included in SectionNames and PropertyNames.
+func (ic *IniConfig) SetDefault(sectionName, propertyName, value string) {
+
+	if ic.defaults == nil {
+		ic.defaults = make(sectionPropertyMap)
+	}
+
+	sectionName = ic.normalise(sectionName)
+
+	if ic.defaults[sectionName] == nil {
+		ic.defaults[sectionName] = make(map[string]propertyValue)
+	}
+
+	ic.defaults[sectionName][ic.normalise(propertyName)] = propertyValue{nilableString: newNilableString(value)}
+}
+
+// SetSectionDefaults registers a default value (see SetDefault) for each property in the supplied map.
+func (ic *IniConfig) SetSectionDefaults(sectionName string, values map[string]string) {
+
+	for property, value := range values {
+		ic.SetDefault(sectionName, property, value)
+	}
+}
+
+// SetDefaults registers a default value (see SetDefault) for each property in the supplied map of section names to
+// property names and values.
+func (ic *IniConfig) SetDefaults(values map[string]map[string]string) {
+
+	for section, properties := range values {
+		ic.SetSectionDefaults(section, properties)
+	}
+}
+
+// lookupDefault returns the default value registered for a property.
+func (ic *IniConfig) lookupDefault(sectionName, propertyName string) (propertyValue, bool) {
+
+	if ic.defaults == nil {
+		return propertyValue{}, false
+	}
+
+	return ic.lookupProperty(ic.lookupSectionIn(ic.defaults, sectionName), propertyName)
+}
+
+// Origin reports whether the effective value of a property is a default (see SetDefault), was parsed from a file or was
+// set at runtime with Add. Returns false if the property does not exist.
+func (ic *IniConfig) Origin(sectionName, propertyName string) (ValueOrigin, bool) {
+
+	if pv, found := ic.lookupProperty(ic.findSection(sectionName), propertyName); found {
+
+		if pv.line > 0 {
+			return OriginFile, true
+		}
+
+		return OriginOverride, true
+	}
+
+	if _, found := ic.lookupDefault(sectionName, propertyName); found {
+		return OriginDefault, true
+	}
+
+	return OriginDefault, false
+}
+
+// IsExplicitlySet returns true if the property was parsed from a file or added with Add, even if its value is empty
+// (key=). Returns false if the property does not exist, only has a default value (see SetDefault) or its value was one
+// of the NullLiterals in IniOptions. Note that properties with no value are discarded while parsing if
+// DiscardPropertiesWithNoValue is set in IniOptions.
+func (ic *IniConfig) IsExplicitlySet(sectionName, propertyName string) bool {
+
+	pv, found := ic.storedValue(sectionName, propertyName)
+
+	return found && pv.IsSet()
+}
@@ -0,0 +1,102 @@
+package inifile
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDefaults(t *testing.T) {
+
+	ic, err := NewIniConfigFromPath(typesPath())
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	ic.SetDefault("int", "positive", "100")
+	ic.SetDefault("int", "missing", "7")
+	ic.SetDefaults(map[string]map[string]string{"defaults-only": {"a": "1"}})
+
+	if v, _ := ic.ValueAsInt64("int", "positive"); v != 4 {
+		t.Errorf("Expected file value to take precedence over default, was %d", v)
+	}
+
+	if v, _ := ic.ValueAsInt64("int", "missing"); v != 7 {
+		t.Errorf("Expected default value, was %d", v)
+	}
+
+	if !ic.SectionExists("defaults-only") || !ic.PropertyExists("defaults-only", "a") {
+		t.Errorf("Expected defaults to be visible to SectionExists and PropertyExists")
+	}
+
+	is, err := ic.Section("defaults-only")
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if _, err := is.Value("b"); !errors.Is(err, ErrPropertyNotFound) {
+		t.Errorf("Expected ErrPropertyNotFound, was %v", err)
+	}
+
+	for property, expected := range map[string]ValueOrigin{"positive": OriginFile, "missing": OriginDefault} {
+
+		if o, found := ic.Origin("int", property); !found || o != expected {
+			t.Errorf("Expected origin of %s to be %s, was %s", property, expected, o)
+		}
+	}
+
+	ic.Add("int", "missing", "8")
+
+	if o, _ := ic.Origin("int", "missing"); o != OriginOverride || ic.ValueOrZeroAsInt64("int", "missing") != 8 {
+		t.Errorf("Expected added value to take precedence over default")
+	}
+
+	ic.Delete("int", "missing")
+
+	if v, _ := ic.ValueAsInt64("int", "missing"); v != 7 {
+		t.Errorf("Expected default to be used once override deleted, was %d", v)
+	}
+
+	if _, found := ic.Origin("int", "nothing"); found {
+		t.Errorf("Did not expect origin for missing property")
+	}
+
+	var b bytes.Buffer
+
+	ic.WriteTo(&b)
+
+	if bytes.Contains(b.Bytes(), []byte("defaults-only")) {
+		t.Errorf("Did not expect defaults to be written")
+	}
+}
+
+func TestIsExplicitlySet(t *testing.T) {
+
+	o := DefaultIniOptions()
+	o.DiscardPropertiesWithNoValue = false
+
+	ic, err := newIniConfigFromReader(strings.NewReader("[s]\nempty=\n"), "empty.ini", o)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	ic.SetDefault("s", "defaulted", "x")
+
+	if !ic.IsExplicitlySet("s", "empty") {
+		t.Errorf("Expected empty property to be explicitly set")
+	}
+
+	if ic.IsExplicitlySet("s", "defaulted") || ic.IsExplicitlySet("s", "missing") {
+		t.Errorf("Expected default and missing properties not to be explicitly set")
+	}
+
+	ic.Add("s", "defaulted", "")
+
+	if is, _ := ic.Section("s"); !is.IsExplicitlySet("defaulted") {
+		t.Errorf("Expected added property to be explicitly set")
+	}
+}
@@ -0,0 +1,91 @@
+package inifile
+
+// Detach returns a new IniConfig holding an independent copy of this section, with the same name, and nothing else, so
+// that the settings for (for example) a plugin can be handed to it without exposing the rest of the file. Defaults,
+// sensitive markings, declared types and inline comments for the section are copied, as is where each value was parsed
+// from. The new IniConfig uses the same IniOptions.
+//
+// Changes to either IniConfig do not affect the other. Values that refer to other sections (see Interpolate and
+// LayerReferences in IniOptions) cannot be resolved in the new IniConfig.
+func (is *IniSection) Detach() *IniConfig {
+
+	ic := is.ic
+
+	ic.loadSection(is.name)
+
+	section := ic.normalise(is.name)
+
+	detached := newIniConfigFromMap(nil, ic.options)
+	detached.source = ic.source
+
+	if stored := ic.sections[section]; len(stored) > 0 {
+
+		copied := make(map[string]propertyValue, len(stored))
+
+		for _, property := range ic.propertyOrder[section] {
+
+			pv := stored[property]
+
+			if pv.line > 0 {
+				pv.source = detached.sourceIndex(ic.sources[pv.source])
+			}
+
+			copied[property] = pv
+		}
+
+		detached.sections[section] = copied
+		detached.sectionOrder = []string{section}
+		detached.propertyOrder = map[string][]string{section: append([]string(nil), ic.propertyOrder[section]...)}
+	}
+
+	if defaults := ic.defaults[section]; defaults != nil {
+		detached.defaults = sectionPropertyMap{section: copyProperties(defaults)}
+	}
+
+	if sensitive := ic.sensitive[section]; sensitive != nil {
+		detached.sensitive = map[string]map[string]bool{section: copyFlags(sensitive)}
+	}
+
+	if types := ic.types[section]; types != nil {
+		detached.types = map[string]map[string]string{section: copyStrings(types)}
+	}
+
+	if comments := ic.inlineComments[section]; comments != nil {
+		detached.inlineComments = map[string]map[string]string{section: copyStrings(comments)}
+	}
+
+	return detached
+}
+
+func copyProperties(m map[string]propertyValue) map[string]propertyValue {
+
+	c := make(map[string]propertyValue, len(m))
+
+	for k, v := range m {
+		c[k] = v
+	}
+
+	return c
+}
+
+func copyFlags(m map[string]bool) map[string]bool {
+
+	c := make(map[string]bool, len(m))
+
+	for k, v := range m {
+		c[k] = v
+	}
+
+	return c
+}
+
+func copyStrings(m map[string]string) map[string]string {
+
+	c := make(map[string]string, len(m))
+
+	for k, v := range m {
+		c[k] = v
+	}
+
+	return c
+}
@@ -0,0 +1,57 @@
+package inifile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDetach(t *testing.T) {
+
+	ic, _ := newIniConfigFromReader(strings.NewReader("g=1\n[plugin-x]\nurl=http://x\ntoken=secret\n[other]\na=b\n"), "app.ini", DefaultIniOptions())
+
+	ic.MarkSensitive("plugin-x", "token")
+	ic.SetDefault("plugin-x", "timeout", "5s")
+
+	is, _ := ic.Section("plugin-x")
+
+	detached := is.Detach()
+
+	if names := strings.Join(detached.SectionNames(), ","); names != "plugin-x" {
+		t.Errorf("Unexpected sections %s", names)
+	}
+
+	checkValue(t, detached, "plugin-x", "url", "http://x")
+	checkValue(t, detached, "plugin-x", "timeout", "5s")
+
+	if !detached.IsSensitive("plugin-x", "token") {
+		t.Errorf("Expected sensitive marking to be copied")
+	}
+
+	if o, _ := detached.Origin("plugin-x", "url"); o != OriginFile {
+		t.Errorf("Expected origin to be preserved")
+	}
+
+	detached.Add("plugin-x", "url", "http://y")
+	ic.Delete("plugin-x", "token")
+
+	checkValue(t, ic, "plugin-x", "url", "http://x")
+	checkValue(t, detached, "plugin-x", "token", "secret")
+
+	var b bytes.Buffer
+	detached.WriteTo(&b)
+
+	if b.String() != "[plugin-x]\nurl=http://y\ntoken=secret\n" {
+		t.Errorf("Unexpected output %q", b.String())
+	}
+
+	global, _ := ic.Section(GLOBAL_SECTION)
+
+	detachedGlobal := global.Detach()
+
+	if names := detachedGlobal.SectionNames(); len(names) != 1 || names[0] != GLOBAL_SECTION {
+		t.Errorf("Unexpected sections %q", names)
+	}
+
+	checkValue(t, detachedGlobal, GLOBAL_SECTION, "g", "1")
+}
@@ -0,0 +1,116 @@
+package inifile
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// The number of lines examined by DetectOptions
+const detectSampleLines = 1000
+
+// DetectOptions examines the start of the supplied content and returns IniOptions that are likely to parse it, based on
+// DefaultIniOptions. The following are inferred:
+//
+//		CommentStart and ExtraCommentStarts	from the symbols (; or #) that start comment lines
+//		UseColonAssignment					if more properties are assigned with : than with =
+//		StripEnclosingQuotes				if any values are enclosed in quotes
+//		AllowInlineComments					if values contain a comment symbol after whitespace
+//		CaseSensitive						false if names are repeated with different case
+//
+// The result is a suggestion: the content is not parsed and may still fail to parse with the returned options.
+func DetectOptions(r io.Reader) (*IniOptions, error) {
+
+	options := DefaultIniOptions()
+
+	s := bufio.NewScanner(r)
+
+	comments := map[string]int{}
+	equals, colons := 0, 0
+	quoted, inline := false, false
+	names := map[string]string{}
+
+	for lines := 0; lines < detectSampleLines && s.Scan(); lines++ {
+
+		l := strings.TrimSpace(s.Text())
+
+		switch {
+		case l == "":
+			continue
+
+		case strings.HasPrefix(l, ";") || strings.HasPrefix(l, "#"):
+			comments[l[:1]]++
+			continue
+
+		case strings.HasPrefix(l, "[") && strings.HasSuffix(l, "]"):
+			if noteName(names, "["+l[1:len(l)-1]) {
+				options.CaseSensitive = false
+			}
+
+			continue
+		}
+
+		assign := strings.IndexAny(l, "=:")
+
+		if assign < 0 {
+			continue
+		}
+
+		if l[assign] == '=' {
+			equals++
+		} else {
+			colons++
+		}
+
+		name := strings.TrimSpace(l[:assign])
+		value := strings.TrimSpace(l[assign+1:])
+
+		if noteName(names, name) {
+			options.CaseSensitive = false
+		}
+
+		if i := strings.IndexAny(value, ";#"); i > 0 && (value[i-1] == ' ' || value[i-1] == '\t') {
+			inline = true
+			value = strings.TrimSpace(value[:i])
+		}
+
+		if len(value) > 1 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			quoted = true
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	if comments["#"] > comments[";"] {
+		options.CommentStart = "#"
+	}
+
+	for _, symbol := range []string{";", "#"} {
+		if symbol != options.CommentStart && comments[symbol] > 0 {
+			options.ExtraCommentStarts = append(options.ExtraCommentStarts, symbol)
+		}
+	}
+
+	options.UseColonAssignment = colons > equals
+	options.StripEnclosingQuotes = quoted
+	options.AllowInlineComments = inline
+
+	return options, nil
+}
+
+// noteName records a section or property name, returning true if the same name has already been seen with different
+// case.
+func noteName(names map[string]string, name string) bool {
+
+	lower := strings.ToLower(name)
+
+	if seen, found := names[lower]; found {
+		return seen != name
+	}
+
+	names[lower] = name
+
+	return false
+}
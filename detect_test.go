@@ -0,0 +1,35 @@
+package inifile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectOptions(t *testing.T) {
+
+	content := "# Comment\n# Another\n; Old style\n[mysqld]\nport = 3306\ndatadir = \"/var/lib/mysql\" # Quoted\n"
+
+	o, err := DetectOptions(strings.NewReader(content))
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if o.CommentStart != "#" || len(o.ExtraCommentStarts) != 1 || o.ExtraCommentStarts[0] != ";" {
+		t.Errorf("Expected # comments with ; as an extra comment start, got %s %v", o.CommentStart, o.ExtraCommentStarts)
+	}
+
+	if !o.StripEnclosingQuotes || !o.AllowInlineComments || o.UseColonAssignment || !o.CaseSensitive {
+		t.Errorf("Unexpected options detected %+v", o)
+	}
+
+	o, err = DetectOptions(strings.NewReader("[Server]\nHost: a\nhost: b\n[server]\n"))
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if !o.UseColonAssignment || o.CaseSensitive || o.CommentStart != ";" {
+		t.Errorf("Expected colon assignment and case insensitivity to be detected, got %+v", o)
+	}
+}
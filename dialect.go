@@ -0,0 +1,108 @@
+package inifile
+
+import (
+	"sort"
+	"sync"
+)
+
+// Dialect names a variant of the INI format, such as the one used by a particular program, for which a suitable set of
+// IniOptions is registered (see RegisterDialect and DialectOptions).
+type Dialect string
+
+// The built-in dialects
+const (
+	// The Windows profile API (see WindowsIniOptions)
+	DialectWindows Dialect = "windows"
+	// MySQL and MariaDB option files (see MySQLIniOptions)
+	DialectMySQL Dialect = "mysql"
+	// systemd unit files (see SystemdIniOptions)
+	DialectSystemd Dialect = "systemd"
+	// Git configuration files (see GitIniOptions)
+	DialectGit Dialect = "git"
+	// Python's configparser module (see PythonIniOptions)
+	DialectPython Dialect = "python"
+	// Samba's smb.conf (see SambaIniOptions)
+	DialectSamba Dialect = "samba"
+	// Files of key=value lines with no sections (see KeyValueIniOptions)
+	DialectKeyValue Dialect = "keyvalue"
+	// os-release files (see OSReleaseIniOptions)
+	DialectOSRelease Dialect = "os-release"
+	// sysctl.conf files (see SysctlIniOptions)
+	DialectSysctl Dialect = "sysctl"
+	// .editorconfig files (see EditorConfigIniOptions)
+	DialectEditorConfig Dialect = "editorconfig"
+)
+
+var (
+	dialectsMu sync.RWMutex
+	dialects   = map[Dialect]func() *IniOptions{
+		DialectWindows:      WindowsIniOptions,
+		DialectMySQL:        MySQLIniOptions,
+		DialectSystemd:      SystemdIniOptions,
+		DialectGit:          GitIniOptions,
+		DialectPython:       PythonIniOptions,
+		DialectSamba:        SambaIniOptions,
+		DialectKeyValue:     KeyValueIniOptions,
+		DialectOSRelease:    OSReleaseIniOptions,
+		DialectSysctl:       SysctlIniOptions,
+		DialectEditorConfig: EditorConfigIniOptions,
+	}
+)
+
+// RegisterDialect makes a dialect available to DialectOptions and NewIniConfigFromPathWithDialect, replacing any dialect
+// already registered with the same name. The supplied function is called each time the dialect's options are needed and
+// must return a new IniOptions each time, as callers may modify it.
+func RegisterDialect(d Dialect, options func() *IniOptions) {
+
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+
+	dialects[d] = options
+}
+
+// DialectOptions returns a new IniOptions suitable for the named dialect, or an error if no dialect with that name has
+// been registered.
+func DialectOptions(d Dialect) (*IniOptions, error) {
+
+	dialectsMu.RLock()
+	options, found := dialects[d]
+	dialectsMu.RUnlock()
+
+	if !found {
+		return nil, errorf("No dialect called %s has been registered", d)
+	}
+
+	return options(), nil
+}
+
+// Dialects returns the names of all registered dialects in alphabetical order.
+func Dialects() []Dialect {
+
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+
+	names := make([]Dialect, 0, len(dialects))
+
+	for d := range dialects {
+		names = append(names, d)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+
+	return names
+}
+
+// NewIniConfigFromPathWithDialect parses the file at the supplied path using the options registered for the named
+// dialect (see DialectOptions).
+func NewIniConfigFromPathWithDialect(path string, d Dialect) (*IniConfig, error) {
+
+	options, err := DialectOptions(d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIniConfigFromPathWithOptions(path, options)
+}
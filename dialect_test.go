@@ -0,0 +1,65 @@
+package inifile
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDialects(t *testing.T) {
+
+	ic, err := NewIniConfigFromPathWithDialect(filepath.Join(testfiles_base, "my.cnf"), DialectMySQL)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	checkValue(t, ic, "mysqld", "port", "3306")
+	checkValue(t, ic, "mysqld", "skip-name-resolve", "")
+	checkValue(t, ic, "mysqld", "datadir", "/var/lib/mysql")
+
+	ic, err = NewIniConfigFromPathWithDialect(filepath.Join(testfiles_base, "gitconfig"), DialectGit)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	checkValue(t, ic, "core", "filemode", "true")
+	checkValue(t, ic, "remote \"origin\"", "url", "https://example.com/repo.git")
+	checkValue(t, ic, "diff", "renames", "true")
+
+	ic, err = NewIniConfigFromPathWithDialect(filepath.Join(testfiles_base, "editorconfig", EditorConfigFileName), DialectEditorConfig)
+
+	if err != nil || !ic.isEditorConfigRoot() {
+		t.Errorf("Expected .editorconfig to be parsed with the EditorConfig dialect (%v)", err)
+	}
+
+	if _, err := NewIniConfigFromPathWithDialect(simplePath(), "unknown"); err == nil {
+		t.Errorf("Expected error for unregistered dialect")
+	}
+}
+
+func TestRegisterDialect(t *testing.T) {
+
+	RegisterDialect("hash-comments", func() *IniOptions {
+		io := DefaultIniOptions()
+		io.CommentStart = "#"
+
+		return io
+	})
+
+	o, err := DialectOptions("hash-comments")
+
+	if err != nil || o.CommentStart != "#" {
+		t.Errorf("Expected registered dialect options (%v)", err)
+	}
+
+	found := false
+
+	for _, d := range Dialects() {
+		found = found || d == "hash-comments"
+	}
+
+	if !found {
+		t.Errorf("Expected registered dialect to be listed")
+	}
+}
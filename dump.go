@@ -0,0 +1,126 @@
+package inifile
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// DumpOptions controls the detail included by Dump.
+type DumpOptions struct {
+	// Show the file and line each value was parsed from and the line of any earlier definition it overrode
+	ShowOrigin bool
+
+	// Show whether each property has been read (requires TrackReads in IniOptions)
+	ShowReads bool
+
+	// List sections and properties in alphabetical order rather than the order they were parsed or added
+	Sorted bool
+}
+
+// Dump writes an annotated, human-readable listing of every property in this IniConfig to the supplied writer, intended
+// to help diagnose why an application is using a particular value. The values of sensitive properties (see IsSensitive)
+// are redacted.
+//
+// The output is not intended to be parsed; use WriteTo to write INI output.
+func (ic *IniConfig) Dump(w io.Writer, opts DumpOptions) error {
+
+	if err := ic.loadAllSections(); err != nil {
+		return err
+	}
+
+	cw := &countingWriter{w: w}
+
+	sections := ic.writeOrder()
+
+	if opts.Sorted {
+		sort.Strings(sections)
+	}
+
+	for _, section := range sections {
+
+		if section == GLOBAL_SECTION {
+			cw.writeString("(global)\n")
+		} else {
+			cw.writeString("[" + section + "]\n")
+		}
+
+		properties := append([]string(nil), ic.propertyOrder[section]...)
+
+		if opts.Sorted {
+			sort.Strings(properties)
+		}
+
+		for _, property := range properties {
+
+			value := ic.redact(section, property, ic.sections[section][property].String())
+
+			cw.writeString(fmt.Sprintf("  %s = %q", property, value))
+
+			if opts.ShowOrigin {
+				cw.writeString("  " + ic.describeOrigin(section, property))
+			}
+
+			if opts.ShowReads {
+				if ic.wasRead(section, property) {
+					cw.writeString("  read")
+				} else {
+					cw.writeString("  unread")
+				}
+			}
+
+			cw.writeString("\n")
+		}
+	}
+
+	return cw.err
+}
+
+// describeOrigin summarises where the current value of a property came from.
+func (ic *IniConfig) describeOrigin(section, property string) string {
+
+	o := ic.sections[section][property]
+
+	var d string
+
+	if o.line == 0 {
+		d = "(added at runtime)"
+	} else {
+		d = fmt.Sprintf("(%s:%d)", ic.sources[o.source], o.line)
+	}
+
+	if o.overrides > 0 {
+		d += fmt.Sprintf(" overrides line %d", o.overrides)
+	}
+
+	return d
+}
+
+// markRead records that a property has been read.
+func (ic *IniConfig) markRead(section, property string) {
+
+	section = ic.normalise(section)
+	property = ic.normalise(property)
+
+	ic.readsMu.Lock()
+	defer ic.readsMu.Unlock()
+
+	if ic.reads == nil {
+		ic.reads = make(map[string]map[string]bool)
+	}
+
+	if ic.reads[section] == nil {
+		ic.reads[section] = make(map[string]bool)
+	}
+
+	ic.reads[section][property] = true
+}
+
+// wasRead returns true if the (normalised) property has been read since reads began to be tracked.
+func (ic *IniConfig) wasRead(section, property string) bool {
+
+	ic.readsMu.Lock()
+	defer ic.readsMu.Unlock()
+
+	return ic.reads[section][property]
+}
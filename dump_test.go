@@ -0,0 +1,47 @@
+package inifile
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "suspicious.ini")
+
+	options := DefaultIniOptions()
+	options.TrackReads = true
+	options.SensitiveProperties = []string{"path"}
+
+	ic, err := NewIniConfigFromPathWithOptions(path, options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	ic.Add("section", "added", "x")
+	ic.Value("section", "name")
+
+	var b bytes.Buffer
+
+	if err := ic.Dump(&b, DumpOptions{ShowOrigin: true, ShowReads: true}); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	expected := "[section]\n" +
+		"  name = \"second\"  (" + path + ":3) overrides line 2  read\n" +
+		"  path = \"****\"  (" + path + ":4)  unread\n" +
+		"  added = \"x\"  (added at runtime)  unread\n"
+
+	if b.String() != expected {
+		t.Errorf("Unexpected output:\n%s", b.String())
+	}
+
+	b.Reset()
+	ic.Dump(&b, DumpOptions{Sorted: true})
+
+	if b.String() != "[section]\n  added = \"x\"\n  name = \"second\"\n  path = \"****\"\n" {
+		t.Errorf("Unexpected output:\n%s", b.String())
+	}
+}
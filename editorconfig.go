@@ -0,0 +1,319 @@
+package inifile
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EditorConfigFileName is the name of the files read by EditorConfigFor
+const EditorConfigFileName = ".editorconfig"
+
+// EditorConfigIniOptions returns an IniOptions object suitable for .editorconfig files (see https://editorconfig.org).
+// It differs from DefaultIniOptions in that:
+//
+//		CommentStart			"#"
+//		ExtraCommentStarts		[]string{";"}
+//
+// Section names are glob patterns and are kept case-sensitive; use ResolveFor or EditorConfigFor to find the
+// properties that apply to a file.
+func EditorConfigIniOptions() *IniOptions {
+	io := DefaultIniOptions()
+
+	io.CommentStart = "#"
+	io.ExtraCommentStarts = []string{";"}
+
+	return io
+}
+
+// ResolveFor treats this IniConfig as a parsed .editorconfig file and returns a section containing the properties that
+// apply to the file at the supplied path. Properties from every section whose glob matches the path are merged, with
+// later sections taking precedence over earlier ones. Property names are converted to lower case.
+//
+// Globs are matched against the path relative to the directory containing the .editorconfig file (or against the path
+// as supplied if this IniConfig was not loaded from a file). Globs that do not contain a / match files of that name in
+// any directory.
+//
+// The returned section is not part of this IniConfig and is named after the path. Sections with invalid globs are ignored.
+func (ic *IniConfig) ResolveFor(path string) *IniSection {
+
+	resolved := newResolvedConfig(path)
+
+	ic.resolveEditorConfig(path, resolved)
+
+	return &IniSection{name: path, ic: resolved}
+}
+
+// newResolvedConfig creates an IniConfig with a single, empty section to hold resolved properties.
+func newResolvedConfig(section string) *IniConfig {
+
+	resolved := newIniConfigFromMap(nil, DefaultIniOptions())
+	resolved.sections[section] = make(map[string]propertyValue)
+	resolved.sectionOrder = append(resolved.sectionOrder, section)
+
+	return resolved
+}
+
+// resolveEditorConfig adds the properties from each section matching the supplied path to the single section of resolved.
+func (ic *IniConfig) resolveEditorConfig(path string, resolved *IniConfig) {
+
+	relative := path
+
+	if ic.source != "" {
+
+		dir, _ := filepath.Abs(filepath.Dir(ic.source))
+
+		if abs, err := filepath.Abs(path); err == nil {
+			if rel, err := filepath.Rel(dir, abs); err == nil {
+				relative = rel
+			}
+		}
+	}
+
+	relative = filepath.ToSlash(relative)
+
+	for _, section := range ic.writeOrder() {
+
+		if section == GLOBAL_SECTION {
+			continue
+		}
+
+		matcher, err := compileEditorConfigGlob(section)
+
+		if err != nil {
+			ic.debugf("Ignoring section [%s]: %s", section, err.Error())
+			continue
+		}
+
+		if !matcher.match(relative) {
+			continue
+		}
+
+		for _, property := range ic.propertyOrder[section] {
+			resolved.Add(path, strings.ToLower(property), ic.sections[section][property].String())
+		}
+	}
+}
+
+// isEditorConfigRoot returns true if the preamble of this .editorconfig file sets root=true.
+func (ic *IniConfig) isEditorConfigRoot() bool {
+
+	for _, property := range ic.propertyOrder[GLOBAL_SECTION] {
+
+		if strings.ToLower(property) == "root" {
+			return strings.ToLower(ic.sections[GLOBAL_SECTION][property].String()) == "true"
+		}
+	}
+
+	return false
+}
+
+// EditorConfigFor finds the .editorconfig files that apply to the file at the supplied path, starting in the file's
+// directory and moving up until a file with root=true in its preamble (or the root of the filesystem) is reached. The
+// properties that apply to the file are merged (see ResolveFor), with files closer to the path taking precedence.
+//
+// Returns an error if any .editorconfig file could not be parsed.
+func EditorConfigFor(path string) (*IniSection, error) {
+
+	abs, err := filepath.Abs(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []*IniConfig
+
+	for dir := filepath.Dir(abs); ; dir = filepath.Dir(dir) {
+
+		candidate := filepath.Join(dir, EditorConfigFileName)
+
+		if _, err := os.Stat(candidate); err == nil {
+
+			ic, err := NewIniConfigFromPathWithOptions(candidate, EditorConfigIniOptions())
+
+			if err != nil {
+				return nil, err
+			}
+
+			configs = append(configs, ic)
+
+			if ic.isEditorConfigRoot() {
+				break
+			}
+		}
+
+		if parent := filepath.Dir(dir); parent == dir {
+			break
+		}
+	}
+
+	resolved := newResolvedConfig(abs)
+
+	for i := len(configs) - 1; i >= 0; i-- {
+		configs[i].resolveEditorConfig(abs, resolved)
+	}
+
+	return &IniSection{name: abs, ic: resolved}, nil
+}
+
+// An EditorConfig glob converted to a regular expression, with the bounds of any {n1..n2} ranges it contained
+type editorConfigGlob struct {
+	rx     *regexp.Regexp
+	ranges [][2]int
+}
+
+// match returns true if the slash separated path matches the glob.
+func (g *editorConfigGlob) match(path string) bool {
+
+	matches := g.rx.FindStringSubmatch(path)
+
+	if matches == nil {
+		return false
+	}
+
+	for i, r := range g.ranges {
+
+		n, err := strconv.Atoi(matches[i+1])
+
+		if err != nil || n < r[0] || n > r[1] {
+			return false
+		}
+	}
+
+	return true
+}
+
+var editorConfigRange = regexp.MustCompile(`^\{([+-]?\d+)\.\.([+-]?\d+)\}`)
+
+// compileEditorConfigGlob converts a section name to a matcher following the EditorConfig glob rules:
+//
+//		*			any characters except /
+//		**			any characters
+//		?			any single character except /
+//		[abc]		any single character in the set ([!abc] for characters not in the set)
+//		{s1,s2}		any of the comma separated strings (which may themselves contain globs)
+//		{n1..n2}	any integer between n1 and n2
+func compileEditorConfigGlob(glob string) (*editorConfigGlob, error) {
+
+	g := new(editorConfigGlob)
+
+	var b strings.Builder
+
+	if strings.Contains(glob, "/") {
+		glob = strings.TrimPrefix(glob, "/")
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	braces := 0
+
+	for i := 0; i < len(glob); i++ {
+
+		c := glob[i]
+
+		switch {
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+
+		case c == '*' && strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+
+		case c == '*':
+			b.WriteString("[^/]*")
+
+		case c == '?':
+			b.WriteString("[^/]")
+
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+
+			if end < 0 || strings.Contains(glob[i+1:i+1+end], "/") {
+				b.WriteString(`\[`)
+				continue
+			}
+
+			set := glob[i+1 : i+1+end]
+
+			if strings.HasPrefix(set, "!") {
+				set = "^" + set[1:]
+			}
+
+			b.WriteString("[" + strings.Replace(set, `\`, `\\`, -1) + "]")
+			i += end + 1
+
+		case c == '{':
+			if r := editorConfigRange.FindStringSubmatch(glob[i:]); r != nil {
+				lo, _ := strconv.Atoi(r[1])
+				hi, _ := strconv.Atoi(r[2])
+
+				g.ranges = append(g.ranges, [2]int{lo, hi})
+				b.WriteString(`([+-]?\d+)`)
+				i += len(r[0]) - 1
+				continue
+			}
+
+			if !hasAlternatives(glob[i:]) {
+				b.WriteString(`\{`)
+				continue
+			}
+
+			braces++
+			b.WriteString("(?:")
+
+		case c == ',' && braces > 0:
+			b.WriteString("|")
+
+		case c == '}' && braces > 0:
+			braces--
+			b.WriteString(")")
+
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+
+	b.WriteString("$")
+
+	rx, err := regexp.Compile(b.String())
+
+	if err != nil {
+		return nil, errorf("Unable to interpret %s as a glob: %w", glob, err)
+	}
+
+	g.rx = rx
+
+	return g, nil
+}
+
+// hasAlternatives returns true if s starts with a brace that is closed and contains a comma at the same nesting level.
+func hasAlternatives(s string) bool {
+
+	depth := 0
+
+	for i := 0; i < len(s); i++ {
+
+		switch s[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+
+			if depth == 0 {
+				return false
+			}
+		case ',':
+			if depth == 1 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
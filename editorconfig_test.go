@@ -0,0 +1,94 @@
+package inifile
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveFor(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "editorconfig", EditorConfigFileName)
+
+	ic, err := NewIniConfigFromPathWithOptions(path, EditorConfigIniOptions())
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if !ic.isEditorConfigRoot() {
+		t.Errorf("Expected root=true to be found")
+	}
+
+	dir := filepath.Dir(path)
+
+	expected := map[string]map[string]string{
+		"main.go":             {"indent_style": "tab", "indent_size": "4"},
+		"go.mod":              {"indent_style": "tab"},
+		"sub/Makefile":        {"indent_style": "tab"},
+		"README.md":           {"indent_style": "space", "end_of_line": "lf"},
+		"src/lib/app.js":      {"indent_size": "2"},
+		"file2.txt":           {"charset": "utf-8"},
+		"file4.txt":           {"charset": ""},
+		"other/src/a/main.js": {"indent_size": "4"},
+	}
+
+	for file, properties := range expected {
+
+		is := ic.ResolveFor(filepath.Join(dir, file))
+
+		for property, value := range properties {
+
+			if v := is.ValueOrZero(property); v != value {
+				t.Errorf("Expected %s=%s for %s, was %s", property, value, file, v)
+			}
+		}
+	}
+}
+
+func TestEditorConfigFor(t *testing.T) {
+
+	is, err := EditorConfigFor(filepath.Join(testfiles_base, "editorconfig", "src", "lib", "app.js"))
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if v := is.ValueOrZero("indent_size"); v != "8" {
+		t.Errorf("Expected nearer .editorconfig to take precedence, was %s", v)
+	}
+
+	if v := is.ValueOrZero("indent_style"); v != "space" {
+		t.Errorf("Expected properties from root file, was %s", v)
+	}
+
+	if names := is.PropertyNames(); len(names) != 3 {
+		t.Errorf("Unexpected properties %v", names)
+	}
+}
+
+func TestEditorConfigGlobs(t *testing.T) {
+
+	for glob, paths := range map[string]map[string]bool{
+		"*.c":          {"a.c": true, "dir/a.c": true, "a.h": false},
+		"/top.c":       {"top.c": true, "dir/top.c": false},
+		"a?c":          {"abc": true, "a/c": false},
+		"[!x]y":        {"ay": true, "xy": false},
+		"{a,b{c,d}}.z": {"a.z": true, "bd.z": true, "b.z": false},
+		"{single}":     {"{single}": true, "single": false},
+		"v{-1..1}":     {"v0": true, "v-1": true, "v2": false},
+		"lib/**.js":    {"lib/a/b.js": true, "x/lib/a.js": false},
+	} {
+
+		g, err := compileEditorConfigGlob(glob)
+
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %s", glob, err.Error())
+		}
+
+		for path, expected := range paths {
+			if g.match(path) != expected {
+				t.Errorf("Expected match of %s against %s to be %v", path, glob, expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package inifile
+
+import (
+	"strings"
+)
+
+// ToEnvSlice returns the properties of this IniConfig as environment variables in NAME=value form, suitable for
+// exec.Cmd.Env. Each name is the supplied prefix followed by the section name, an underscore and the property name
+// (just the property name for the global section), in upper case with any character other than a letter, digit or
+// underscore replaced by an underscore. For example, with the prefix APP_, [database] host becomes APP_DATABASE_HOST.
+//
+// Values are retrieved with Value, so interpolation and decryption are applied. Properties whose values cannot be
+// retrieved are omitted. Variables are ordered as Walk visits the properties.
+func (ic *IniConfig) ToEnvSlice(prefix string) []string {
+
+	var env []string
+
+	for _, section := range ic.SectionNames() {
+
+		for _, property := range ic.propertyOrder[section] {
+
+			value, err := ic.Value(section, property)
+
+			if err != nil {
+				ic.debugf("Omitting [%s].%s from environment: %s", section, property, err.Error())
+				continue
+			}
+
+			name := property
+
+			if section != GLOBAL_SECTION {
+				name = section + "_" + property
+			}
+
+			env = append(env, prefix+envName(name)+"="+value)
+		}
+	}
+
+	return env
+}
+
+// FromEnv creates an IniConfig from the environment variables (in NAME=value form, as returned by os.Environ) whose
+// names start with the supplied prefix, reversing ToEnvSlice. After the prefix is removed, the part of the name before
+// the first underscore is the section and the rest is the property; names without an underscore are added to the global
+// section. Section and property names are converted to lower case.
+//
+// As the conversion to environment variable names loses information, section names containing underscores and
+// global properties whose names contain underscores are not restored as they were.
+func FromEnv(environ []string, prefix string, options *IniOptions) (*IniConfig, error) {
+
+	if options == nil {
+		return nil, errorf("Nil IniOptions provided")
+	}
+
+	ic := newIniConfigFromMap(nil, options)
+
+	for _, entry := range environ {
+
+		name, value, found := strings.Cut(entry, "=")
+
+		if !found || !strings.HasPrefix(name, prefix) || len(name) == len(prefix) {
+			continue
+		}
+
+		name = strings.ToLower(strings.TrimPrefix(name, prefix))
+
+		section, property, found := strings.Cut(name, "_")
+
+		if !found || section == "" || property == "" {
+			section, property = GLOBAL_SECTION, name
+		}
+
+		ic.Add(section, property, value)
+	}
+
+	return ic, nil
+}
+
+// envName converts a name to the form used for environment variables.
+func envName(name string) string {
+
+	return strings.Map(func(r rune) rune {
+
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_':
+			return r
+		}
+
+		return '_'
+
+	}, name)
+}
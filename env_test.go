@@ -0,0 +1,48 @@
+package inifile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToEnvSlice(t *testing.T) {
+
+	src := "debug=true\n[database]\nhost=db.example.com\nmax-connections=10\n[cache.redis]\nurl=redis://localhost\n"
+
+	ic, err := newIniConfigFromReader(strings.NewReader(src), "env.ini", DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	env := strings.Join(ic.ToEnvSlice("APP_"), "\n")
+
+	expected := "APP_DEBUG=true\nAPP_DATABASE_HOST=db.example.com\nAPP_DATABASE_MAX_CONNECTIONS=10\nAPP_CACHE_REDIS_URL=redis://localhost"
+
+	if env != expected {
+		t.Errorf("Unexpected environment:\n%s", env)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+
+	environ := []string{"PATH=/usr/bin", "APP_DEBUG=true", "APP_DATABASE_HOST=db.example.com", "APP_DATABASE_MAX_CONNECTIONS=10", "APP_=ignored"}
+
+	ic, err := FromEnv(environ, "APP_", DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, GLOBAL_SECTION, "debug", "true")
+	checkValue(t, ic, "database", "host", "db.example.com")
+	checkValue(t, ic, "database", "max_connections", "10")
+
+	if ic.PropertyExists(GLOBAL_SECTION, "path") {
+		t.Errorf("Expected variables without the prefix to be ignored")
+	}
+
+	if _, err := FromEnv(environ, "APP_", nil); err == nil {
+		t.Errorf("Expected error for nil options")
+	}
+}
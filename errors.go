@@ -0,0 +1,68 @@
+package inifile
+
+import (
+	"fmt"
+)
+
+// ErrSectionNotFound is wrapped by errors returned when a requested section does not exist.
+var ErrSectionNotFound = fmt.Errorf("section not found")
+
+// ErrPropertyNotFound is wrapped by errors returned when a requested property does not exist in a section that does.
+var ErrPropertyNotFound = fmt.Errorf("property not found")
+
+// ErrConversion is wrapped by errors returned when a value cannot be converted to the requested type. If the conversion
+// failed in a standard library function, that function's error (e.g. a *strconv.NumError) is also wrapped and can be
+// retrieved with errors.As.
+var ErrConversion = fmt.Errorf("value could not be converted")
+
+// wrappedError has a message of its own but can be matched with errors.Is and errors.As against the errors it wraps.
+type wrappedError struct {
+	message string
+	wrapped []error
+}
+
+func (we *wrappedError) Error() string {
+	return we.message
+}
+
+func (we *wrappedError) Unwrap() []error {
+	return we.wrapped
+}
+
+// wrapf creates an error with a formatted message that wraps the supplied sentinel and, if it is not nil, the underlying
+// cause of the error.
+func wrapf(sentinel, cause error, template string, args ...interface{}) error {
+
+	we := &wrappedError{message: fmt.Sprintf(template, args...), wrapped: []error{sentinel}}
+
+	if cause != nil {
+		we.wrapped = append(we.wrapped, cause)
+	}
+
+	return we
+}
+
+// ParseError describes a problem with a specific line of the content being parsed.
+type ParseError struct {
+	// The line the problem was found on
+	Line int
+
+	// A description of the problem
+	Message string
+
+	// The underlying cause of the problem, if any
+	Err error
+}
+
+func (pe *ParseError) Error() string {
+	return fmt.Sprintf("%s on line %d", pe.Message, pe.Line)
+}
+
+func (pe *ParseError) Unwrap() error {
+	return pe.Err
+}
+
+// parseErrorf creates a ParseError for the supplied line with a formatted message.
+func parseErrorf(line int, template string, args ...interface{}) error {
+	return &ParseError{Line: line, Message: fmt.Sprintf(template, args...)}
+}
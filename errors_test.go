@@ -0,0 +1,79 @@
+package inifile
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestErrorSentinels(t *testing.T) {
+
+	ic, err := NewIniConfigFromPath(typesPath())
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if _, err := ic.Value("missing", "positive"); !errors.Is(err, ErrSectionNotFound) {
+		t.Errorf("Expected ErrSectionNotFound, was %v", err)
+	}
+
+	if _, err := ic.Section("missing"); !errors.Is(err, ErrSectionNotFound) {
+		t.Errorf("Expected ErrSectionNotFound, was %v", err)
+	}
+
+	if _, err := ic.ValueAsInt64("int", "missing"); !errors.Is(err, ErrPropertyNotFound) || errors.Is(err, ErrSectionNotFound) {
+		t.Errorf("Expected ErrPropertyNotFound, was %v", err)
+	}
+
+	_, err = ic.ValueAsInt64("int", "string")
+
+	var ne *strconv.NumError
+
+	if !errors.Is(err, ErrConversion) || !errors.As(err, &ne) || ne.Err != strconv.ErrSyntax {
+		t.Errorf("Expected ErrConversion wrapping a strconv.NumError, was %v", err)
+	}
+
+	if err.Error() != "Unable to interpret [int].string (xxxx) as an int64." {
+		t.Errorf("Did not expect message to change, was %s", err.Error())
+	}
+
+	if _, err := ic.ValueAsInt32("narrow", "int32over"); !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("Expected range error to be wrapped, was %v", err)
+	}
+
+	for _, f := range []func() error{
+		func() error { _, err := ic.ValueAsBool("int", "string"); return err },
+		func() error { _, err := ic.ValueAsUint64("uint", "negative"); return err },
+		func() error { _, err := ic.ValueAsFloat64("float", "string"); return err },
+		func() error { _, err := ic.ValueAsPercent("int", "string"); return err },
+		func() error { _, err := ic.ValueAsBase64("binary", "badbase64"); return err },
+	} {
+		if err := f(); !errors.Is(err, ErrConversion) {
+			t.Errorf("Expected ErrConversion, was %v", err)
+		}
+	}
+}
+
+func TestEmptyPropertyName(t *testing.T) {
+
+	content := "[section]\nname=x\n=orphan\n"
+
+	_, err := newIniConfigFromReader(strings.NewReader(content), "empty-name.ini", DefaultIniOptions())
+
+	var pe *ParseError
+
+	if !errors.As(err, &pe) || pe.Line != 3 {
+		t.Fatalf("Expected a ParseError for line 3, got %v", err)
+	}
+
+	o := DefaultIniOptions()
+	o.EmptyPropertyName = "_"
+
+	ic, err := newIniConfigFromReader(strings.NewReader(content), "empty-name.ini", o)
+
+	if err != nil || ic.ValueOrZero("section", "_") != "orphan" {
+		t.Errorf("Expected property with no name to be stored as _ (%v)", err)
+	}
+}
@@ -0,0 +1,134 @@
+package inifile
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ExtractSection reads the supplied content until the named section has been read and returns it, without parsing
+// or storing the rest of the content. Reading stops at the first section header after the requested section that names a
+// different section, so the cost of finding a section near the start of a very large file is small.
+//
+// Only the first run of the section is returned: if the section's header appears again later in the content, the later
+// properties are not seen. Lines outside the section are not checked, so errors in them are not reported. Returns an error
+// wrapping ErrSectionNotFound if the section does not exist. LineClassifier, RecordPositions, SignatureKey and
+// VerifyChecksum are not supported.
+func ExtractSection(r io.Reader, section string, options *IniOptions) (*IniSection, error) {
+
+	if r == nil {
+		return nil, errors.New("Nil Reader provided")
+	}
+
+	if options == nil {
+		return nil, errors.New("Nil IniOptions provided")
+	}
+
+	if len(strings.TrimSpace(options.CommentStart)) == 0 {
+		return nil, errors.New("CommentStart field in IniOptions cannot be empty")
+	}
+
+	if options.LineClassifier != nil {
+		return nil, errorf("LineClassifier in IniOptions is not supported when extracting a section")
+	}
+
+	if options.RecordPositions {
+		return nil, errorf("RecordPositions in IniOptions is not supported when extracting a section")
+	}
+
+	if options.SignatureKey != nil {
+		return nil, errorf("SignatureKey in IniOptions is not supported when extracting a section")
+	}
+
+	if options.VerifyChecksum {
+		return nil, errorf("VerifyChecksum in IniOptions is not supported when extracting a section")
+	}
+
+	ic := new(IniConfig)
+	ic.options = options
+	ic.sections = make(sectionPropertyMap)
+
+	if f, ok := r.(interface{ Name() string }); ok {
+		ic.source = f.Name()
+	}
+
+	if options.Decompress {
+
+		decompressed, err := ic.decompressed(r)
+
+		if err != nil {
+			return nil, err
+		}
+
+		r = decompressed
+	}
+
+	content, firstLine, err := ic.scanSection(r, section)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ic.parseFromLine(content, firstLine); err != nil {
+		return nil, err
+	}
+
+	return ic.Section(section)
+}
+
+// scanSection reads lines from the supplied reader until the end of the first run of the named section, returning the
+// lines of the section (including its header) and the number of the line before the first line returned.
+func (ic *IniConfig) scanSection(r io.Reader, section string) (io.Reader, int, error) {
+
+	sectionRx := regexp.MustCompile(rx_section)
+	want := ic.normalise(section)
+
+	s := bufio.NewScanner(r)
+
+	if ic.options.NormalizeLineEndings {
+		s.Split(scanLinesNormalised)
+	}
+
+	var b bytes.Buffer
+
+	inSection := want == GLOBAL_SECTION
+	firstLine := 0
+	lineNumber := 0
+
+	for s.Scan() {
+
+		lineNumber++
+
+		l := strings.TrimSpace(s.Text())
+
+		if !ic.isComment(l) {
+
+			if matches := ic.matchSection(sectionRx, ic.stripInlineComments(l)); matches != nil {
+
+				name, _ := ic.resolveSectionTag(matches[1])
+
+				if ic.normalise(name) == want {
+
+					if !inSection {
+						inSection = true
+						firstLine = lineNumber - 1
+					}
+
+				} else if inSection {
+					//The section has been fully read
+					break
+				}
+			}
+		}
+
+		if inSection {
+			b.Write(s.Bytes())
+			b.WriteByte('\n')
+		}
+	}
+
+	return &b, firstLine, s.Err()
+}
@@ -0,0 +1,69 @@
+package inifile
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// A reader that fails if it is read beyond its content, to check that extraction stops early
+type failAfterReader struct {
+	r io.Reader
+}
+
+func (fr *failAfterReader) Read(p []byte) (int, error) {
+
+	n, err := fr.r.Read(p)
+
+	if err == io.EOF {
+		return n, errors.New("read beyond the end of the section")
+	}
+
+	return n, err
+}
+
+func TestExtractSection(t *testing.T) {
+
+	content := "global=G\n\n[first]\na=1\n[second]\nb=2\n\nc=3\n[third]\nd=4\n"
+
+	is, err := ExtractSection(strings.NewReader(content), "second", DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if is.Name() != "second" || strings.Join(is.PropertyNames(), ",") != "b,c" {
+		t.Errorf("Unexpected section %s with properties %v", is.Name(), is.PropertyNames())
+	}
+
+	if v, _ := is.Value("c"); v != "3" {
+		t.Errorf("Expected c=3, got %s", v)
+	}
+
+	if is, err := ExtractSection(strings.NewReader(content), GLOBAL_SECTION, DefaultIniOptions()); err != nil || is.ValueOrZero("global") != "G" {
+		t.Errorf("Expected global section to be extracted (%v)", err)
+	}
+
+	if _, err := ExtractSection(strings.NewReader(content), "missing", DefaultIniOptions()); !errors.Is(err, ErrSectionNotFound) {
+		t.Errorf("Expected ErrSectionNotFound, got %v", err)
+	}
+
+	//Content after the section would make the reader fail, so it must not be read
+	r := &failAfterReader{io.MultiReader(strings.NewReader("[wanted]\nx=1\n[next]\n"), strings.NewReader("y=2\n"))}
+
+	if is, err := ExtractSection(r, "wanted", DefaultIniOptions()); err != nil || is.ValueOrZero("x") != "1" {
+		t.Errorf("Expected section to be extracted without reading the rest of the content (%v)", err)
+	}
+}
+
+func TestExtractSectionReportsLineNumbers(t *testing.T) {
+
+	content := "[first]\na=1\n[second]\nb=2\nnot a property\n"
+
+	_, err := ExtractSection(strings.NewReader(content), "second", DefaultIniOptions())
+
+	if err == nil || !strings.Contains(err.Error(), "line 5") {
+		t.Errorf("Expected error reporting line 5, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package inifile
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SectionFamily returns the numbered sections formed from the supplied base name with the SectionFamilyFormat in the
+// IniOptions, e.g. [worker-1], [worker-2] and [worker-10] for the base name worker and the default format "%s-%d".
+// Sections are returned in numeric order, with sections with the same number (e.g. [worker-01] and [worker-1]) in the
+// order they were first parsed or added, so replicated blocks of configuration can be iterated deterministically.
+//
+// Returns nil if no sections match or if SectionFamilyFormat does not contain %s and %d exactly once.
+func (ic *IniConfig) SectionFamily(base string) []*IniSection {
+
+	format := ic.options.SectionFamilyFormat
+
+	if strings.Count(format, "%s") != 1 || strings.Count(format, "%d") != 1 {
+		return nil
+	}
+
+	pattern := regexp.QuoteMeta(format)
+	pattern = strings.Replace(pattern, "%s", regexp.QuoteMeta(ic.normalise(base)), 1)
+	pattern = strings.Replace(pattern, "%d", `(\d+)`, 1)
+
+	re := regexp.MustCompile("^" + pattern + "$")
+
+	type member struct {
+		number  uint64
+		section *IniSection
+	}
+
+	var members []member
+
+	for _, name := range ic.SectionNames() {
+
+		m := re.FindStringSubmatch(name)
+
+		if m == nil {
+			continue
+		}
+
+		n, err := strconv.ParseUint(m[1], 10, 64)
+
+		if err != nil {
+			continue
+		}
+
+		members = append(members, member{n, &IniSection{name: name, ic: ic}})
+	}
+
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].number < members[j].number
+	})
+
+	var family []*IniSection
+
+	for _, m := range members {
+		family = append(family, m.section)
+	}
+
+	return family
+}
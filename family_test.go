@@ -0,0 +1,52 @@
+package inifile
+
+import (
+	"strings"
+	"testing"
+)
+
+func familyNames(family []*IniSection) string {
+
+	var names []string
+
+	for _, is := range family {
+		names = append(names, is.Name())
+	}
+
+	return strings.Join(names, ",")
+}
+
+func TestSectionFamily(t *testing.T) {
+
+	ic, _ := newIniConfigFromReader(strings.NewReader("[worker-10]\na=1\n[worker-2]\na=2\n[worker-x]\na=3\n[workers-1]\na=4\n[worker-1]\na=5\n[other-1]\na=6\n"), "test", DefaultIniOptions())
+
+	if names := familyNames(ic.SectionFamily("worker")); names != "worker-1,worker-2,worker-10" {
+		t.Errorf("Unexpected family %s", names)
+	}
+
+	if family := ic.SectionFamily("missing"); family != nil {
+		t.Errorf("Expected no sections for an unknown base name")
+	}
+}
+
+func TestSectionFamilyFormat(t *testing.T) {
+
+	opts := DefaultIniOptions()
+	opts.SectionFamilyFormat = "node.%d.%s"
+
+	ic, _ := newIniConfigFromReader(strings.NewReader("[node.2.db]\na=1\n[node.1.db]\na=2\n[node-1-db]\na=3\n"), "test", opts)
+
+	if names := familyNames(ic.SectionFamily("db")); names != "node.1.db,node.2.db" {
+		t.Errorf("Unexpected family %s", names)
+	}
+
+	opts.SectionFamilyFormat = "%s"
+
+	if ic.SectionFamily("db") != nil {
+		t.Errorf("Expected format without %%d to return nil")
+	}
+
+	if err := ValidateOptions(opts); err == nil {
+		t.Errorf("Expected format without %%d to be invalid")
+	}
+}
@@ -0,0 +1,173 @@
+package inifile
+
+import (
+	"fmt"
+)
+
+// FeatureFlags interprets every property in a section as a boolean feature flag, using the bool rules in the IniOptions
+// (see IniConfig.ValueAsBool). Obtain one by calling IniConfig.Flags.
+type FeatureFlags struct {
+	ic      *IniConfig
+	section string
+}
+
+// FlagProblemKind identifies the type of problem found with a feature flag.
+type FlagProblemKind int
+
+const (
+	// The flag is not one of the flags the application knows about (e.g. a misspelling)
+	FlagUnknown FlagProblemKind = iota
+	// The flag's value cannot be interpreted as a bool
+	FlagInvalid
+)
+
+// FlagProblem describes a feature flag that is unknown or has an invalid value.
+type FlagProblem struct {
+	Kind FlagProblemKind
+
+	// The name of the flag as it appears in the file
+	Name string
+
+	// For FlagUnknown, the most similar known flag (if one is similar enough to be a likely misspelling)
+	Suggestion string
+
+	// For FlagInvalid, the error interpreting the value
+	Err error
+}
+
+// String describes the problem, e.g. "Unknown flag new_chekout (did you mean new_checkout?)"
+func (fp FlagProblem) String() string {
+
+	if fp.Kind == FlagInvalid {
+		return fmt.Sprintf("Invalid flag %s: %s", fp.Name, fp.Err.Error())
+	}
+
+	if fp.Suggestion != "" {
+		return fmt.Sprintf("Unknown flag %s (did you mean %s?)", fp.Name, fp.Suggestion)
+	}
+
+	return fmt.Sprintf("Unknown flag %s", fp.Name)
+}
+
+// Flags returns a FeatureFlags for the properties in the named section. The section does not need to exist, in which
+// case no flags are enabled. Flags reflect the current values in the IniConfig, including defaults (see SetDefault).
+func (ic *IniConfig) Flags(section string) *FeatureFlags {
+	return &FeatureFlags{ic: ic, section: section}
+}
+
+// Enabled returns true if the named flag exists and its value is true. Missing flags and flags whose values cannot be
+// interpreted as a bool are not enabled.
+func (ff *FeatureFlags) Enabled(name string) bool {
+	return ff.ic.ValueAsBoolDefault(ff.section, name, false)
+}
+
+// EnabledOr returns the value of the named flag, or the supplied default if the flag is missing or its value cannot
+// be interpreted as a bool.
+func (ff *FeatureFlags) EnabledOr(name string, def bool) bool {
+	return ff.ic.ValueAsBoolDefault(ff.section, name, def)
+}
+
+// Names returns the names of all flags in the section, in alphabetical order.
+func (ff *FeatureFlags) Names() []string {
+
+	if ff.ic.findSection(ff.section) == nil {
+		return nil
+	}
+
+	return append([]string(nil), ff.ic.sortedPropertyNames(ff.section)...)
+}
+
+// Report checks every flag in the section against the names of the flags the application knows about, returning a
+// problem for each flag that is not known (with a suggested known flag if it looks like a misspelling) and each flag
+// whose value cannot be interpreted as a bool. Problems are ordered by flag name.
+func (ff *FeatureFlags) Report(known ...string) []FlagProblem {
+
+	knownNames := make(map[string]string, len(known))
+
+	for _, k := range known {
+		knownNames[ff.ic.normalise(k)] = k
+	}
+
+	var problems []FlagProblem
+
+	for _, name := range ff.Names() {
+
+		if _, found := knownNames[ff.ic.normalise(name)]; !found {
+			problems = append(problems, FlagProblem{Kind: FlagUnknown, Name: name, Suggestion: closestName(name, known)})
+			continue
+		}
+
+		if _, err := ff.ic.ValueAsBool(ff.section, name); err != nil {
+			problems = append(problems, FlagProblem{Kind: FlagInvalid, Name: name, Err: err})
+		}
+	}
+
+	return problems
+}
+
+// closestName returns the candidate with the smallest edit distance from name, if that distance is small enough for the
+// name to be a likely misspelling of the candidate.
+func closestName(name string, candidates []string) string {
+
+	best := ""
+	bestDistance := 0
+
+	for _, c := range candidates {
+
+		d := editDistance(name, c)
+
+		//Allow one edit for short names and two for longer ones
+		limit := 1
+
+		if len(c) > 5 {
+			limit = 2
+		}
+
+		if d <= limit && (best == "" || d < bestDistance) {
+			best, bestDistance = c, d
+		}
+	}
+
+	return best
+}
+
+// editDistance returns the Levenshtein distance between the supplied strings.
+func editDistance(a, b string) int {
+
+	ar, br := []rune(a), []rune(b)
+
+	previous := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+
+		current[0] = i
+
+		for j := 1; j <= len(br); j++ {
+
+			cost := 1
+
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+
+			current[j] = previous[j-1] + cost
+
+			if d := previous[j] + 1; d < current[j] {
+				current[j] = d
+			}
+
+			if d := current[j-1] + 1; d < current[j] {
+				current[j] = d
+			}
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(br)]
+}
@@ -0,0 +1,66 @@
+package inifile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFeatureFlags(t *testing.T) {
+
+	src := "[features]\nnew_checkout=true\ndark_mode=false\nbeta_serch=true\nexperiments=sometimes\n"
+
+	ic, err := newIniConfigFromReader(strings.NewReader(src), "features.ini", DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	flags := ic.Flags("features")
+
+	if !flags.Enabled("new_checkout") || flags.Enabled("dark_mode") || flags.Enabled("missing") || flags.Enabled("experiments") {
+		t.Errorf("Unexpected enabled flags")
+	}
+
+	if !flags.EnabledOr("missing", true) || flags.EnabledOr("dark_mode", true) {
+		t.Errorf("Unexpected result from EnabledOr")
+	}
+
+	if names := strings.Join(flags.Names(), ","); names != "beta_serch,dark_mode,experiments,new_checkout" {
+		t.Errorf("Unexpected names %s", names)
+	}
+
+	problems := flags.Report("new_checkout", "dark_mode", "beta_search", "experiments")
+
+	if len(problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %v", problems)
+	}
+
+	if problems[0].Kind != FlagUnknown || problems[0].Suggestion != "beta_search" {
+		t.Errorf("Unexpected problem %s", problems[0].String())
+	}
+
+	if problems[1].Kind != FlagInvalid || problems[1].Name != "experiments" {
+		t.Errorf("Unexpected problem %s", problems[1].String())
+	}
+
+	if missing := ic.Flags("other"); missing.Enabled("new_checkout") || len(missing.Names()) != 0 {
+		t.Errorf("Expected no flags in a missing section")
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+
+	for _, c := range []struct {
+		a, b     string
+		distance int
+	}{
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"flag", "flag", 0},
+		{"serch", "search", 1},
+	} {
+		if d := editDistance(c.a, c.b); d != c.distance {
+			t.Errorf("Expected distance %d between %s and %s, got %d", c.distance, c.a, c.b, d)
+		}
+	}
+}
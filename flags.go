@@ -0,0 +1,78 @@
+package inifile
+
+import (
+	"flag"
+)
+
+// ApplyToFlagSet sets each flag in the supplied FlagSet that was not explicitly set on the command line to the value of
+// the property with the same name in the specified section. This gives command-line flags precedence over INI values,
+// which in turn take precedence over the defaults the flags were defined with.
+//
+// It should be called after fs.Parse. An error is returned if the section does not exist or a property's value is not
+// valid for its flag.
+func (ic *IniConfig) ApplyToFlagSet(fs *flag.FlagSet, section string) error {
+
+	is, err := ic.Section(section)
+
+	if err != nil {
+		return err
+	}
+
+	explicit := make(map[string]bool)
+
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	var setErr error
+
+	fs.VisitAll(func(f *flag.Flag) {
+
+		if setErr != nil || explicit[f.Name] || !is.PropertyExists(f.Name) {
+			return
+		}
+
+		v, err := is.Value(f.Name)
+
+		if err == nil {
+			err = fs.Set(f.Name, v)
+		}
+
+		if err != nil {
+			setErr = errorf("Unable to set flag %s from [%s].%s: %w", f.Name, section, f.Name, err)
+		}
+	})
+
+	return setErr
+}
+
+// RegisterFlags defines a string flag in the supplied FlagSet for each property in the specified section that does not
+// already have a flag with the same name. The property's value is used as the flag's default, so the INI file supplies
+// defaults that can be overridden on the command line.
+//
+// It must be called before fs.Parse. An error is returned if the section does not exist.
+func (ic *IniConfig) RegisterFlags(fs *flag.FlagSet, section string) error {
+
+	is, err := ic.Section(section)
+
+	if err != nil {
+		return err
+	}
+
+	for _, name := range is.PropertyNames() {
+
+		if fs.Lookup(name) != nil {
+			continue
+		}
+
+		v, err := is.Value(name)
+
+		if err != nil {
+			return err
+		}
+
+		fs.String(name, v, "Default from ["+section+"]."+name)
+	}
+
+	return nil
+}
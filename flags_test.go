@@ -0,0 +1,71 @@
+package inifile
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestApplyToFlagSet(t *testing.T) {
+
+	ic, _ := NewIniConfigFromPath(typesPath())
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	positive := fs.Int("positive", 0, "")
+	negative := fs.Int("negative", 0, "")
+	unrelated := fs.String("unrelated", "default", "")
+
+	fs.Parse([]string{"-negative", "-5"})
+
+	if err := ic.ApplyToFlagSet(fs, "int"); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if *positive != 4 {
+		t.Errorf("Expected flag to be set from INI, was %d", *positive)
+	}
+
+	if *negative != -5 {
+		t.Errorf("Expected command line to take precedence, was %d", *negative)
+	}
+
+	if *unrelated != "default" {
+		t.Errorf("Expected flag default to be kept, was %s", *unrelated)
+	}
+
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("string", 0, "")
+
+	if err := ic.ApplyToFlagSet(fs, "int"); err == nil {
+		t.Errorf("Expected invalid value to fail")
+	}
+
+	if err := ic.ApplyToFlagSet(fs, "missing"); err == nil {
+		t.Errorf("Expected missing section to fail")
+	}
+}
+
+func TestRegisterFlags(t *testing.T) {
+
+	ic, _ := NewIniConfigFromPath(typesPath())
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("positive", 10, "")
+
+	if err := ic.RegisterFlags(fs, "float"); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	fs.Parse([]string{"-string", "override"})
+
+	if v := fs.Lookup("negative").Value.String(); v != "-2.3333" {
+		t.Errorf("Expected INI value as default, was %s", v)
+	}
+
+	if v := fs.Lookup("string").Value.String(); v != "override" {
+		t.Errorf("Expected command line value, was %s", v)
+	}
+
+	if v := fs.Lookup("positive").Value.String(); v != "10" {
+		t.Errorf("Expected existing flag to be unchanged, was %s", v)
+	}
+}
@@ -0,0 +1,385 @@
+package inifile
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultFormatStyle returns a FormatStyle object populated with default values.
+//
+// Default values are:
+//		Options						nil (DefaultIniOptions)
+//		Indent						""
+//		ContinuationIndent			"    "
+//		SpaceAroundAssignment		false
+//		AlignAssignments			false
+//		AlignComments				true
+//		BlankLinesBetweenSections	1
+//		MaxBlankLines				1
+//		LineEnding					LF
+func DefaultFormatStyle() *FormatStyle {
+	fs := new(FormatStyle)
+
+	fs.Options = nil
+	fs.Indent = ""
+	fs.ContinuationIndent = "    "
+	fs.SpaceAroundAssignment = false
+	fs.AlignAssignments = false
+	fs.AlignComments = true
+	fs.BlankLinesBetweenSections = 1
+	fs.MaxBlankLines = 1
+	fs.LineEnding = LF
+
+	return fs
+}
+
+// FormatStyle controls the layout produced by Format.
+type FormatStyle struct {
+	//The options used to parse the content. If nil, DefaultIniOptions is used
+	Options *IniOptions
+
+	//Written before each property and comment in a named section. Ignored if IniOptions.AllowContinuationLines is set, as
+	//indented lines would be parsed as continuations
+	Indent string
+
+	//Written before each line that continues a value (see IniOptions.AllowContinuationLines). Must not be empty if the
+	//content contains continued values
+	ContinuationIndent string
+
+	//Write a space either side of the assignment symbol
+	SpaceAroundAssignment bool
+
+	//Pad property names so the assignment symbols in each section line up
+	AlignAssignments bool
+
+	//Start the inline comments in each section in the same column
+	AlignComments bool
+
+	//The number of blank lines before each section header (and any comments directly above it)
+	BlankLinesBetweenSections int
+
+	//The maximum number of consecutive blank lines kept elsewhere
+	MaxBlankLines int
+
+	//The line ending written after each line (LF or CRLF)
+	LineEnding string
+}
+
+// A line of content being formatted
+type formatLine struct {
+	kind    ElementKind
+	blank   bool
+	other   bool
+	key     string
+	value   string
+	text    string
+	comment string
+
+	//The text of a property line up to any inline comment, kept verbatim when IniOptions.TrimProperties is not set as
+	//the whitespace around the name and value is part of the property
+	verbatim string
+
+	//The number of section headers at or before this line
+	block int
+
+	//A comment directly above a section header
+	attached bool
+
+	//The first line of a section header and its attached comments
+	leads bool
+}
+
+// Format returns the supplied INI content re-laid out according to the supplied style: indentation, spacing around the
+// assignment symbol, the alignment of assignment symbols and inline comments and the number of blank lines are
+// standardised. The order of sections, properties and comments and the text of names, values and comments are kept, so
+// the formatted content parses to the same configuration.
+//
+// If TrimProperties is not set in the Options, whitespace around property names and values is significant, so property
+// lines are only indented: spacing around the assignment symbol, alignment and the spacing before inline comments on
+// those lines are left as they are.
+//
+// Lines the parser does not record positions for (see IniConfig.Elements) are kept with only their surrounding
+// whitespace removed. An error is returned if the content cannot be parsed with the Options in the style.
+func Format(src []byte, style *FormatStyle) ([]byte, error) {
+
+	if style == nil {
+		return nil, errorf("Nil FormatStyle provided")
+	}
+
+	options := DefaultIniOptions()
+
+	if style.Options != nil {
+		options = style.Options
+	}
+
+	//Positions are needed to find each part of a line
+	o := *options
+	o.RecordPositions = true
+	o.FailOnWarnings = false
+	o.PostParse = nil
+
+	ic, err := newIniConfigFromReader(bytes.NewReader(src), "", &o)
+
+	if err != nil {
+		return nil, err
+	}
+
+	elements := make(map[int][]Element)
+
+	for _, e := range ic.Elements() {
+		elements[e.Span.Start.Line] = append(elements[e.Span.Start.Line], e)
+	}
+
+	lines := formatLines(src, &o, elements)
+
+	fs := *style
+
+	if o.AllowContinuationLines {
+		fs.Indent = ""
+	}
+
+	return fs.write(lines, string(ic.assignmentSymbol())), nil
+}
+
+// formatLines splits the content into lines and identifies the parts of each line.
+func formatLines(src []byte, options *IniOptions, elements map[int][]Element) []formatLine {
+
+	s := bufio.NewScanner(bytes.NewReader(src))
+
+	if options.NormalizeLineEndings {
+		s.Split(scanLinesNormalised)
+	}
+
+	var lines []formatLine
+
+	block := 0
+	lineNumber := 0
+
+	for s.Scan() {
+
+		lineNumber++
+
+		raw := s.Text()
+		fl := formatLine{block: block}
+
+		if strings.TrimSpace(raw) == "" {
+			fl.blank = true
+			lines = append(lines, fl)
+			continue
+		}
+
+		found := elements[lineNumber]
+
+		if len(found) == 0 {
+			//Not recognised by the parser (e.g. discarded or defined by a LineClassifier)
+			fl.other = true
+			fl.text = strings.TrimSpace(raw)
+		}
+
+		for i, e := range found {
+
+			text := raw[e.Span.Start.Column-1 : e.Span.End.Column-1]
+
+			switch {
+			case e.Kind == ElementComment && i > 0:
+				fl.comment = text
+			case e.Kind == ElementKey:
+				fl.kind = ElementKey
+				fl.key = text
+			case e.Kind == ElementValue && fl.kind == ElementKey:
+				fl.value = text
+			default:
+				fl.kind = e.Kind
+				fl.text = text
+			}
+		}
+
+		if fl.kind == ElementKey && !options.TrimProperties {
+
+			end := len(strings.TrimRightFunc(raw, unicode.IsSpace))
+
+			if fl.comment != "" {
+				end = found[len(found)-1].Span.Start.Column - 1
+			}
+
+			fl.verbatim = raw[found[0].Span.Start.Column-1 : end]
+		}
+
+		if fl.isHeader() {
+			block++
+			fl.block = block
+			fl.leads = true
+		}
+
+		lines = append(lines, fl)
+	}
+
+	//Comments directly above a section header are kept with it
+	for i := range lines {
+
+		if !lines[i].isHeader() {
+			continue
+		}
+
+		for j := i - 1; j >= 0 && lines[j].kind == ElementComment && !lines[j].blank && !lines[j].other; j-- {
+			lines[j+1].leads = false
+			lines[j].attached = true
+			lines[j].leads = true
+		}
+	}
+
+	return lines
+}
+
+// isHeader returns true if the line is a section header.
+func (fl *formatLine) isHeader() bool {
+	return fl.kind == ElementSection && !fl.blank && !fl.other
+}
+
+// write lays out the identified lines.
+func (fs *FormatStyle) write(lines []formatLine, assign string) []byte {
+
+	if fs.SpaceAroundAssignment {
+		assign = " " + assign + " "
+	}
+
+	keyWidths := make(map[int]int)
+
+	if fs.AlignAssignments {
+		for _, fl := range lines {
+			if fl.kind == ElementKey && fl.verbatim == "" {
+				if l := utf8.RuneCountInString(fl.key); l > keyWidths[fl.block] {
+					keyWidths[fl.block] = l
+				}
+			}
+		}
+	}
+
+	codeWidths := make(map[int]int)
+
+	for i := range lines {
+
+		fl := &lines[i]
+
+		if fl.blank {
+			continue
+		}
+
+		fl.text = fs.code(fl, assign, keyWidths[fl.block])
+
+		if fl.comment != "" && fl.verbatim == "" {
+			if l := utf8.RuneCountInString(fl.text); l > codeWidths[fl.block] {
+				codeWidths[fl.block] = l
+			}
+		}
+	}
+
+	eol := fs.LineEnding
+
+	if eol == "" {
+		eol = LF
+	}
+
+	var b strings.Builder
+
+	for _, fl := range fs.spaceLines(lines) {
+
+		if fl == nil {
+			b.WriteString(eol)
+			continue
+		}
+
+		b.WriteString(fl.text)
+
+		if fl.comment != "" {
+
+			padding := 1
+
+			if fl.verbatim != "" {
+				padding = 0
+			} else if fs.AlignComments {
+				padding += codeWidths[fl.block] - utf8.RuneCountInString(fl.text)
+			}
+
+			b.WriteString(strings.Repeat(" ", padding) + fl.comment)
+		}
+
+		b.WriteString(eol)
+	}
+
+	return []byte(b.String())
+}
+
+// code returns the indented text of a line without its inline comment, padding property names to the supplied width.
+func (fs *FormatStyle) code(fl *formatLine, assign string, width int) string {
+
+	indent := ""
+
+	if fl.block > 0 && !fl.attached {
+		indent = fs.Indent
+	}
+
+	switch {
+	case fl.other || fl.kind == ElementComment:
+		return indent + fl.text
+
+	case fl.kind == ElementSection:
+		return fl.text
+
+	case fl.kind == ElementValue:
+		return fs.ContinuationIndent + fl.text
+	}
+
+	if fl.verbatim != "" {
+		return indent + fl.verbatim
+	}
+
+	key := fl.key
+
+	if padding := width - utf8.RuneCountInString(key); padding > 0 {
+		key += strings.Repeat(" ", padding)
+	}
+
+	return strings.TrimRight(indent+key+assign+fl.value, " ")
+}
+
+// spaceLines removes blank lines at the start and end of the content, places BlankLinesBetweenSections blank lines
+// before each section header (and its attached comments) and limits other runs of blank lines to MaxBlankLines. Blank
+// lines are returned as nil.
+func (fs *FormatStyle) spaceLines(lines []formatLine) []*formatLine {
+
+	var spaced []*formatLine
+
+	blanks := 0
+
+	for i := range lines {
+
+		fl := &lines[i]
+
+		if fl.blank {
+			blanks++
+			continue
+		}
+
+		if len(spaced) > 0 {
+
+			if fl.leads {
+				blanks = fs.BlankLinesBetweenSections
+			} else if blanks > fs.MaxBlankLines {
+				blanks = fs.MaxBlankLines
+			}
+
+			for ; blanks > 0; blanks-- {
+				spaced = append(spaced, nil)
+			}
+		}
+
+		blanks = 0
+		spaced = append(spaced, fl)
+	}
+
+	return spaced
+}
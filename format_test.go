@@ -0,0 +1,137 @@
+package inifile
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFormatDefaultStyle(t *testing.T) {
+
+	src := "\n\n;Global\nname =  test\n\n\n\nmode=fast\n[server]\nhost = localhost\n  port=80\n\n\n;Logging\n[log]\nlevel= debug\n\n"
+
+	expected := ";Global\nname=test\n\nmode=fast\n\n[server]\nhost=localhost\nport=80\n\n;Logging\n[log]\nlevel=debug\n"
+
+	b, err := Format([]byte(src), DefaultFormatStyle())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if string(b) != expected {
+		t.Errorf("Unexpected output:\n%q\nexpected:\n%q", string(b), expected)
+	}
+}
+
+func TestFormatAlignment(t *testing.T) {
+
+	opts := DefaultIniOptions()
+	opts.AllowInlineComments = true
+
+	fs := DefaultFormatStyle()
+	fs.Options = opts
+	fs.Indent = "  "
+	fs.SpaceAroundAssignment = true
+	fs.AlignAssignments = true
+	fs.BlankLinesBetweenSections = 2
+
+	src := "[server] ;Server\nhost=localhost ;Name\nport=80    ;Port\n;Timeouts\nreadTimeout=30\n[log]\nlevel=debug ;Level\n"
+
+	expected := "[server]                  ;Server\n" +
+		"  host        = localhost ;Name\n" +
+		"  port        = 80        ;Port\n" +
+		"  ;Timeouts\n" +
+		"  readTimeout = 30\n" +
+		"\n\n" +
+		"[log]\n" +
+		"  level = debug ;Level\n"
+
+	b, err := Format([]byte(src), fs)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if string(b) != expected {
+		t.Errorf("Unexpected output:\n%s\nexpected:\n%s", string(b), expected)
+	}
+
+	//The formatted content must parse to the same configuration
+	ic, err := newIniConfigFromReader(bytes.NewReader(b), "formatted", opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, "server", "port", "80")
+	checkValue(t, ic, "log", "level", "debug")
+}
+
+func TestFormatContinuationLines(t *testing.T) {
+
+	opts := DefaultIniOptions()
+	opts.AllowContinuationLines = true
+
+	fs := DefaultFormatStyle()
+	fs.Options = opts
+
+	fs.Indent = "  "
+
+	src := "[options]\ninstall_requires =\n\t\trequests\n  click>=7\n"
+
+	b, err := Format([]byte(src), fs)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if expected := "[options]\ninstall_requires=\n    requests\n    click>=7\n"; string(b) != expected {
+		t.Errorf("Unexpected output %q", string(b))
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+
+	if _, err := Format([]byte("[a]\nx=1\n"), nil); err == nil {
+		t.Errorf("Expected error with nil style")
+	}
+
+	if _, err := Format([]byte("[a\n"), DefaultFormatStyle()); err == nil {
+		t.Errorf("Expected error with unparseable content")
+	}
+}
+
+func TestFormatUntrimmedProperties(t *testing.T) {
+
+	opts := DefaultIniOptions()
+	opts.TrimProperties = false
+	opts.AllowInlineComments = true
+
+	fs := DefaultFormatStyle()
+	fs.Options = opts
+	fs.Indent = "  "
+	fs.SpaceAroundAssignment = true
+	fs.AlignAssignments = true
+
+	src := "[a]\n   y =  1 ;Comment\nlonger=2\n"
+
+	b, err := Format([]byte(src), fs)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	expected := "[a]\n  y =  1 ;Comment\n  longer=2\n"
+
+	if string(b) != expected {
+		t.Errorf("Unexpected output:\n%q\nexpected:\n%q", string(b), expected)
+	}
+
+	ic, err := newIniConfigFromReader(bytes.NewReader(b), "formatted.ini", opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, "a", "y ", "  1 ")
+	checkValue(t, ic, "a", "longer", "2")
+}
@@ -0,0 +1,51 @@
+package inifile
+
+import (
+	"strings"
+	"time"
+)
+
+// GeneratedTimeFormat is the layout (see time.Format) of the time of generation written in the comment at the start of
+// files written with IniWriteOptions.GeneratedBy set
+const GeneratedTimeFormat = time.RFC3339
+
+// The source of the time written in generated file headers, replaced in tests
+var generatedTime = time.Now
+
+// generatedHeader returns the comment lines written at the start of a generated file, each starting with the supplied
+// comment symbol and ending with eol, or an empty string if no header was requested.
+func (wo *IniWriteOptions) generatedHeader(commentStart, eol string) string {
+
+	var lines []string
+
+	if wo.GeneratedBy != "" {
+
+		line := "Generated by " + wo.GeneratedBy
+
+		if !wo.OmitTimestamp {
+			line += " at " + generatedTime().UTC().Format(GeneratedTimeFormat)
+		}
+
+		lines = append(lines, line)
+	}
+
+	if wo.GeneratedFrom != "" {
+		lines = append(lines, "Source: "+wo.GeneratedFrom)
+	}
+
+	if wo.DoNotEditWarning {
+		lines = append(lines, "DO NOT EDIT: this file is generated and changes made by hand will be overwritten")
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	for _, line := range lines {
+		b.WriteString(commentStart + " " + strings.NewReplacer("\r", " ", "\n", " ").Replace(line) + eol)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,50 @@
+package inifile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGeneratedHeader(t *testing.T) {
+
+	defer func() { generatedTime = time.Now }()
+	generatedTime = func() time.Time { return time.Date(2017, 3, 1, 12, 30, 0, 0, time.UTC) }
+
+	ic := NewIniConfigFromMap(map[string]map[string]string{GLOBAL_SECTION: {"a": "1"}, "db": {"host": "h"}})
+
+	wo := DefaultIniWriteOptions()
+	wo.GeneratedBy = "confgen"
+	wo.GeneratedFrom = "templates/app.ini.tmpl"
+	wo.DoNotEditWarning = true
+
+	var b bytes.Buffer
+	ic.WriteToWithOptions(&b, wo)
+
+	expected := "; Generated by confgen at 2017-03-01T12:30:00Z\n; Source: templates/app.ini.tmpl\n" +
+		"; DO NOT EDIT: this file is generated and changes made by hand will be overwritten\n\na=1\n\n[db]\nhost=h\n"
+
+	if b.String() != expected {
+		t.Errorf("Unexpected output %q", b.String())
+	}
+
+	reparsed, err := newIniConfigFromReader(&b, "test", DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, reparsed, GLOBAL_SECTION, "a", "1")
+
+	wo.OmitTimestamp = true
+	wo.GeneratedFrom = ""
+	wo.DoNotEditWarning = false
+
+	b.Reset()
+	ic.WriteToWithOptions(&b, wo)
+
+	if !strings.HasPrefix(b.String(), "; Generated by confgen\n\na=1\n") {
+		t.Errorf("Unexpected output without timestamp %q", b.String())
+	}
+}
@@ -0,0 +1,90 @@
+package inifile
+
+import (
+	"sort"
+)
+
+// ConfigSource is implemented by types that provide read-only access to INI-style configuration. Components that only
+// need to read configuration can accept a ConfigSource rather than an *IniConfig, so they can be tested with
+// configuration built in memory (see NewIniConfigFromMap).
+type ConfigSource interface {
+	SectionExists(sectionName string) bool
+	SectionNames() []string
+	PropertyExists(sectionName, propertyName string) bool
+
+	Value(sectionName, propertyName string) (string, error)
+	ValueAsFloat64(sectionName, propertyName string) (float64, error)
+	ValueAsInt64(sectionName, propertyName string) (int64, error)
+	ValueAsUint64(sectionName, propertyName string) (uint64, error)
+	ValueAsBool(sectionName, propertyName string) (bool, error)
+
+	ValueOrZero(sectionName, propertyName string) string
+	ValueOrZeroAsFloat64(sectionName, propertyName string) float64
+	ValueOrZeroAsInt64(sectionName, propertyName string) int64
+	ValueOrZeroAsUint64(sectionName, propertyName string) uint64
+	ValueOrZeroAsBool(sectionName, propertyName string) bool
+}
+
+// SectionSource is implemented by types that provide read-only access to the properties in a single section.
+type SectionSource interface {
+	Name() string
+	PropertyNames() []string
+	PropertyExists(propertyName string) bool
+
+	Value(propertyName string) (string, error)
+	ValueAsFloat64(propertyName string) (float64, error)
+	ValueAsInt64(propertyName string) (int64, error)
+	ValueAsUint64(propertyName string) (uint64, error)
+	ValueAsBool(propertyName string) (bool, error)
+
+	ValueOrZero(propertyName string) string
+	ValueOrZeroAsFloat64(propertyName string) float64
+	ValueOrZeroAsInt64(propertyName string) int64
+	ValueOrZeroAsUint64(propertyName string) uint64
+	ValueOrZeroAsBool(propertyName string) bool
+}
+
+var _ ConfigSource = (*IniConfig)(nil)
+var _ SectionSource = (*IniSection)(nil)
+
+// NewIniConfigFromMap creates an IniConfig holding the supplied sections and properties (use GLOBAL_SECTION as the key
+// for global properties), with the options returned by DefaultIniOptions(). Sections and properties are added in
+// alphabetical order.
+//
+// This is intended for building configuration in unit tests without needing an INI file.
+func NewIniConfigFromMap(values map[string]map[string]string) *IniConfig {
+	return newIniConfigFromMap(values, DefaultIniOptions())
+}
+
+func newIniConfigFromMap(values map[string]map[string]string, options *IniOptions) *IniConfig {
+
+	ic := new(IniConfig)
+	ic.options = options
+	ic.sections = make(sectionPropertyMap)
+
+	sections := make([]string, 0, len(values))
+
+	for section := range values {
+		sections = append(sections, section)
+	}
+
+	sort.Strings(sections)
+
+	for _, section := range sections {
+
+		properties := values[section]
+		names := make([]string, 0, len(properties))
+
+		for property := range properties {
+			names = append(names, property)
+		}
+
+		sort.Strings(names)
+
+		for _, property := range names {
+			ic.Add(section, property, properties[property])
+		}
+	}
+
+	return ic
+}
@@ -0,0 +1,21 @@
+package inifile
+
+import (
+	"testing"
+)
+
+func TestNewIniConfigFromMap(t *testing.T) {
+
+	var cs ConfigSource = NewIniConfigFromMap(map[string]map[string]string{
+		GLOBAL_SECTION: {"a": "1"},
+		"b":            {"c": "true", "d": "x"},
+	})
+
+	if !cs.ValueOrZeroAsBool("b", "c") || cs.ValueOrZeroAsInt64(GLOBAL_SECTION, "a") != 1 {
+		t.Errorf("Unexpected values")
+	}
+
+	if names := cs.SectionNames(); len(names) != 2 || names[0] != GLOBAL_SECTION || names[1] != "b" {
+		t.Errorf("Unexpected sections %v", names)
+	}
+}
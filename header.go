@@ -0,0 +1,108 @@
+package inifile
+
+import "strings"
+
+// matchEscapedSection parses a section header whose name may contain ] and \ escaped with a \ ([a\]b]) or be enclosed in
+// double quotes (["a]b"]), as allowed by EscapedSectionNames in IniOptions. Returns the whole line, the unescaped name and
+// any content after the closing bracket, or nil if the line is not a section header.
+func matchEscapedSection(line string) []string {
+
+	if !strings.HasPrefix(line, "[") {
+		return nil
+	}
+
+	var name strings.Builder
+
+	quoted := strings.HasPrefix(line[1:], "\"")
+	closing := byte(']')
+	i := 1
+
+	if quoted {
+		closing = '"'
+		i++
+	}
+
+	for ; i < len(line); i++ {
+
+		c := line[i]
+
+		if c == '\\' && i+1 < len(line) {
+			i++
+			name.WriteByte(line[i])
+			continue
+		}
+
+		if c == closing {
+			break
+		}
+
+		name.WriteByte(c)
+	}
+
+	if quoted {
+		//Skip the closing quote, which must be followed by the closing bracket
+		i++
+
+		if i >= len(line) || line[i] != ']' {
+			return nil
+		}
+	}
+
+	if i >= len(line) {
+		return nil
+	}
+
+	return []string{line, name.String(), line[i+1:]}
+}
+
+// escapeSectionName escapes any characters in a section name that would otherwise end the section header when it is
+// parsed with EscapedSectionNames set in IniOptions.
+func (ic *IniConfig) escapeSectionName(name string) string {
+
+	if !ic.options.EscapedSectionNames {
+		return name
+	}
+
+	return strings.NewReplacer("\\", "\\\\", "]", "\\]").Replace(name)
+}
+
+// quotedSectionEnd returns the index just after the closing bracket of a section header whose name is enclosed in double
+// quotes (["a;b"]), as allowed by QuotedSectionNames in IniOptions, or -1 if the line is not such a header. A backslash
+// escapes the character that follows it.
+func quotedSectionEnd(line string) int {
+
+	if !strings.HasPrefix(line, "[\"") {
+		return -1
+	}
+
+	for i := 2; i < len(line); i++ {
+
+		switch line[i] {
+		case '\\':
+			i++
+		case '"':
+
+			if i+1 < len(line) && line[i+1] == ']' {
+				return i + 2
+			}
+
+			return -1
+		}
+	}
+
+	return -1
+}
+
+// sectionHeader returns the header line (without a line ending) that starts the named section when it is written. If
+// QuotedSectionNames is set in IniOptions, names that could not otherwise be parsed back unchanged are enclosed in double
+// quotes.
+func (ic *IniConfig) sectionHeader(section string) string {
+
+	options := ic.options
+
+	if options.QuotedSectionNames && (strings.ContainsAny(section, "]\"") || options.AllowInlineComments && strings.Contains(section, options.CommentStart)) {
+		return "[\"" + strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(section) + "\"]"
+	}
+
+	return "[" + ic.escapeComments(ic.escapeSectionName(section)) + "]"
+}
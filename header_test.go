@@ -0,0 +1,144 @@
+package inifile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSectionHeaderTrailingContent(t *testing.T) {
+
+	_, err := newIniConfigFromReader(strings.NewReader("[a] b\nx=1\n"), "trailing.ini", DefaultIniOptions())
+
+	if err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("Expected content after the closing bracket to be rejected, got %v", err)
+	}
+
+	ic, err := newIniConfigFromReader(strings.NewReader("[a] ;Comment\nx=1\n"), "comment.ini", DefaultIniOptions())
+
+	if err != nil || ic.ValueOrZero("a", "x") != "1" {
+		t.Errorf("Expected a comment after the closing bracket to be allowed (%v)", err)
+	}
+}
+
+func TestEscapedSectionNames(t *testing.T) {
+
+	o := DefaultIniOptions()
+	o.EscapedSectionNames = true
+
+	ic, err := newIniConfigFromReader(strings.NewReader("[a\\]b]\nx=1\n[\"c]d\"]\ny=2\n[e\\\\]\nz=3\n"), "escaped.ini", o)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if ic.ValueOrZero("a]b", "x") != "1" || ic.ValueOrZero("c]d", "y") != "2" || ic.ValueOrZero("e\\", "z") != "3" {
+		t.Errorf("Unexpected sections %v", ic.SectionNames())
+	}
+
+	var b bytes.Buffer
+
+	if _, err := ic.WriteTo(&b); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	written, err := newIniConfigFromReader(&b, "written.ini", o)
+
+	if err != nil || strings.Join(written.SectionNames(), ",") != "a]b,c]d,e\\" {
+		t.Errorf("Expected escaped section names to survive writing (%v)", err)
+	}
+
+	if _, err := newIniConfigFromReader(strings.NewReader("[\"unterminated]\n"), "bad.ini", o); err == nil {
+		t.Errorf("Expected unterminated quoted section name to be rejected")
+	}
+}
+
+func TestQuotedSectionNames(t *testing.T) {
+
+	o := DefaultIniOptions()
+	o.AllowInlineComments = true
+	o.QuotedSectionNames = true
+
+	content := "[\"My Section; strange\"] ;Comment\nx=1 ;Comment\n[\"a]b \\\"c\\\"\"]\ny=2\n[plain]\nz=3\n"
+
+	ic, err := newIniConfigFromReader(strings.NewReader(content), "quoted.ini", o)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, "My Section; strange", "x", "1")
+	checkValue(t, ic, "a]b \"c\"", "y", "2")
+	checkValue(t, ic, "plain", "z", "3")
+
+	var b bytes.Buffer
+
+	if _, err := ic.WriteTo(&b); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if !strings.Contains(b.String(), "[\"My Section; strange\"]") || !strings.Contains(b.String(), "[plain]") {
+		t.Errorf("Expected only names that need it to be written in quotes, got:\n%s", b.String())
+	}
+
+	written, err := newIniConfigFromReader(&b, "written.ini", o)
+
+	if err != nil || strings.Join(written.SectionNames(), ",") != strings.Join(ic.SectionNames(), ",") {
+		t.Errorf("Expected quoted section names to survive writing (%v)", err)
+	}
+
+	o.QuotedSectionNames = false
+
+	if ic, err := newIniConfigFromReader(strings.NewReader("[\"My Section; strange\"]\nx=1\n"), "unquoted.ini", o); err == nil && ic.SectionExists("My Section; strange") {
+		t.Errorf("Expected quoted section names to only be taken verbatim with QuotedSectionNames")
+	}
+}
+
+func TestSectionHeaderAnchored(t *testing.T) {
+
+	ic, err := newIniConfigFromReader(strings.NewReader("[a]\nlist=[1,2]\nnote=see [b]\n"), "anchored.ini", DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if names := ic.SectionNames(); len(names) != 1 || names[0] != "a" {
+		t.Errorf("Expected values containing brackets not to be read as section headers, got sections %v", names)
+	}
+
+	checkValue(t, ic, "a", "list", "[1,2]")
+	checkValue(t, ic, "a", "note", "see [b]")
+}
+
+func TestSectionNameWithBrackets(t *testing.T) {
+
+	ic, err := newIniConfigFromReader(strings.NewReader("[*.[ch]] ;C sources\nindent_style=tab\n[a] ;see [b]\nx=1\n"), "brackets.ini", EditorConfigIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, "*.[ch]", "indent_style", "tab")
+	checkValue(t, ic, "a", "x", "1")
+
+	if v := ic.ResolveFor("main.c").ValueOrZero("indent_style"); v != "tab" {
+		t.Errorf("Expected [*.[ch]] to match main.c, got %q", v)
+	}
+
+	ic.Add("a]b", "y", "2")
+
+	var b bytes.Buffer
+
+	if _, err := ic.WriteTo(&b); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	written, err := newIniConfigFromReader(&b, "written.ini", EditorConfigIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unable to read back written file: %s", err.Error())
+	}
+
+	checkValue(t, written, "a]b", "y", "2")
+	checkValue(t, written, "*.[ch]", "indent_style", "tab")
+}
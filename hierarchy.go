@@ -0,0 +1,131 @@
+package inifile
+
+import (
+	"strings"
+)
+
+// childName returns the name of the child section of the named section (GLOBAL_SECTION's children are top-level sections).
+func (ic *IniConfig) childName(sectionName, child string) string {
+
+	if sectionName == GLOBAL_SECTION {
+		return child
+	}
+
+	return sectionName + ic.options.SectionSeparator + child
+}
+
+// Parent returns the section containing this section in the hierarchy formed by splitting section names around the
+// SectionSeparator in IniOptions (the parent of [database.replica] is [database]). The parent of a top-level section
+// is GLOBAL_SECTION. Returns nil for GLOBAL_SECTION or if SectionSeparator is empty.
+//
+// The returned section does not need to contain any properties, so that intermediate levels of the hierarchy can be
+// navigated.
+func (is *IniSection) Parent() *IniSection {
+
+	sep := is.ic.options.SectionSeparator
+
+	if is.name == GLOBAL_SECTION || sep == "" {
+		return nil
+	}
+
+	parent := GLOBAL_SECTION
+
+	if i := strings.LastIndex(is.name, sep); i >= 0 {
+		parent = is.name[:i]
+	}
+
+	return &IniSection{name: parent, ic: is.ic}
+}
+
+// Child returns the section below this one with the supplied name (see Parent), e.g. calling Child("replica") on
+// [database] returns [database.replica]. The returned section does not need to contain any properties.
+func (is *IniSection) Child(name string) *IniSection {
+	return &IniSection{name: is.ic.childName(is.name, name), ic: is.ic}
+}
+
+// Children returns the sections immediately below this one in the hierarchy (see Parent), in the order they were first
+// parsed or added. A child is included if it, or any section below it, contains properties.
+func (is *IniSection) Children() []*IniSection {
+
+	ic := is.ic
+	sep := ic.options.SectionSeparator
+
+	if sep == "" && is.name != GLOBAL_SECTION {
+		return nil
+	}
+
+	prefix := ic.normalise(ic.childName(is.name, ""))
+
+	var children []*IniSection
+	seen := make(map[string]bool)
+
+	for _, name := range ic.SectionNames() {
+
+		if name == GLOBAL_SECTION || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		child := name[len(prefix):]
+
+		if sep != "" {
+			if i := strings.Index(child, sep); i >= 0 {
+				child = child[:i]
+			}
+		}
+
+		if !seen[child] {
+			seen[child] = true
+			children = append(children, is.Child(child))
+		}
+	}
+
+	return children
+}
+
+// ValueAt returns the value of a property identified by a path relative to this section. The path is made up of zero or
+// more navigation steps separated by / followed by a property name, which may be qualified by the names of sections
+// below the current one. For example, from [service.api]:
+//
+//		timeout				[service.api] timeout
+//		retry.limit			[service.api.retry] limit
+//		../shared.timeout	[service.shared] timeout
+//		/database.host		[database] host
+//
+// A leading / starts from GLOBAL_SECTION and each .. step moves to the parent section.
+//
+// Returns an error if the path moves above GLOBAL_SECTION or the property does not exist.
+func (is *IniSection) ValueAt(path string) (string, error) {
+
+	current := is
+
+	if strings.HasPrefix(path, "/") {
+		current = &IniSection{name: GLOBAL_SECTION, ic: is.ic}
+		path = path[1:]
+	}
+
+	steps := strings.Split(path, "/")
+
+	for _, step := range steps[:len(steps)-1] {
+
+		switch step {
+		case "..":
+			if current = current.Parent(); current == nil {
+				return "", errorf("Path %s moves above the global section", path)
+			}
+		case ".", "":
+		default:
+			current = current.Child(step)
+		}
+	}
+
+	property := steps[len(steps)-1]
+
+	if sep := is.ic.options.SectionSeparator; sep != "" {
+		if i := strings.LastIndex(property, sep); i >= 0 {
+			current = current.Child(property[:i])
+			property = property[i+len(sep):]
+		}
+	}
+
+	return current.Value(property)
+}
@@ -0,0 +1,62 @@
+package inifile
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSectionHierarchy(t *testing.T) {
+
+	ic, err := NewIniConfigFromPath(filepath.Join(testfiles_base, "hierarchy.ini"))
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	api, _ := ic.Section("service.api")
+
+	if p := api.Parent(); p.Name() != "service" || p.Parent().Name() != GLOBAL_SECTION || p.Parent().Parent() != nil {
+		t.Errorf("Unexpected parents of %s", api.Name())
+	}
+
+	if v, _ := api.Child("retry").ValueAsInt64("limit"); v != 3 {
+		t.Errorf("Unexpected value %d", v)
+	}
+
+	service, _ := ic.Section("service")
+
+	var names []string
+
+	for _, child := range service.Children() {
+		names = append(names, child.Name())
+	}
+
+	if len(names) != 3 || names[0] != "service.api" || names[1] != "service.shared" || names[2] != "service.worker" {
+		t.Errorf("Unexpected children %v", names)
+	}
+
+	global, _ := ic.Section(GLOBAL_SECTION)
+
+	if c := global.Children(); len(c) != 2 || c[1].Name() != "database" {
+		t.Errorf("Unexpected top-level sections %v", c)
+	}
+
+	for path, expected := range map[string]string{
+		"port":                "8080",
+		"retry.limit":         "3",
+		"../shared.timeout":   "10",
+		"../timeout":          "30",
+		"/database.host":      "localhost",
+		"../../name":          "root",
+		"../worker.pool.size": "4",
+	} {
+
+		if v, err := api.ValueAt(path); err != nil || v != expected {
+			t.Errorf("Expected %s for %s, was %s (%v)", expected, path, v, err)
+		}
+	}
+
+	if _, err := api.ValueAt("../../../name"); err == nil {
+		t.Errorf("Expected path above global section to fail")
+	}
+}
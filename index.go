@@ -0,0 +1,155 @@
+package inifile
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxStackName is the length of the longest name that lookups will normalise without allocating.
+const maxStackName = 64
+
+// lowerASCII writes a lower case copy of s into buf, returning the copy and true. Returns false if s is too long for buf
+// or contains non-ASCII characters (which need the full Unicode rules in strings.ToLower).
+func lowerASCII(buf *[maxStackName]byte, s string) ([]byte, bool) {
+
+	if len(s) > maxStackName {
+		return nil, false
+	}
+
+	for i := 0; i < len(s); i++ {
+
+		c := s[i]
+
+		if c >= utf8.RuneSelf {
+			return nil, false
+		}
+
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+
+		buf[i] = c
+	}
+
+	return buf[:len(s)], true
+}
+
+// hasUpper returns true if s contains any characters that would be changed by normalisation.
+func hasUpper(s string) bool {
+
+	for i := 0; i < len(s); i++ {
+
+		c := s[i]
+
+		if c >= utf8.RuneSelf || ('A' <= c && c <= 'Z') {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lookupSection returns the properties in the named section (or nil if the section does not exist). Unlike normalise,
+// it does not allocate when a case-insensitive name contains upper case characters.
+func (ic *IniConfig) lookupSection(sectionName string) map[string]propertyValue {
+	return ic.lookupSectionIn(ic.sections, sectionName)
+}
+
+// lookupSectionIn finds a section in the supplied map without allocating (see lookupSection).
+func (ic *IniConfig) lookupSectionIn(sections sectionPropertyMap, sectionName string) map[string]propertyValue {
+
+	if ic.options.CaseSensitive || !hasUpper(sectionName) {
+		return sections[sectionName]
+	}
+
+	var buf [maxStackName]byte
+
+	if lower, ok := lowerASCII(&buf, sectionName); ok {
+		return sections[string(lower)]
+	}
+
+	return sections[strings.ToLower(sectionName)]
+}
+
+// lookupProperty returns the named property from a section returned by findSection. Unlike normalise, it does not
+// allocate when a case-insensitive name contains upper case characters.
+func (ic *IniConfig) lookupProperty(section map[string]propertyValue, propertyName string) (propertyValue, bool) {
+
+	var pv propertyValue
+	var found bool
+
+	if ic.options.CaseSensitive || !hasUpper(propertyName) {
+		pv, found = section[propertyName]
+		return pv, found
+	}
+
+	var buf [maxStackName]byte
+
+	if lower, ok := lowerASCII(&buf, propertyName); ok {
+		pv, found = section[string(lower)]
+	} else {
+		pv, found = section[strings.ToLower(propertyName)]
+	}
+
+	return pv, found
+}
+
+// sortedPropertyNames returns the (normalised) names of the properties in a section in alphabetical order. The sorted
+// slice is built the first time it is needed and reused until a property is added to or deleted from the section, so
+// callers must not modify it.
+func (ic *IniConfig) sortedPropertyNames(section string) []string {
+
+	section = ic.normalise(section)
+
+	ic.sortedMu.Lock()
+	defer ic.sortedMu.Unlock()
+
+	if names, found := ic.sorted[section]; found {
+		return names
+	}
+
+	names := append([]string(nil), ic.propertyOrder[section]...)
+	sort.Strings(names)
+
+	if ic.sorted == nil {
+		ic.sorted = make(map[string][]string)
+	}
+
+	ic.sorted[section] = names
+
+	return names
+}
+
+// invalidateSorted discards the sorted property names of a (normalised) section after a property has been added or removed.
+func (ic *IniConfig) invalidateSorted(section string) {
+
+	ic.sortedMu.Lock()
+	delete(ic.sorted, section)
+	ic.sortedMu.Unlock()
+}
+
+// PropertyNamesWithPrefix returns the names of the properties in the named section that start with the supplied prefix,
+// in alphabetical order. If CaseSensitive is false in the IniOptions, the names are returned in lower case and the
+// prefix is matched case-insensitively.
+//
+// The names are found with a binary search of a sorted index of the section, so this is efficient even for sections
+// with many thousands of properties.
+func (ic *IniConfig) PropertyNamesWithPrefix(sectionName, prefix string) []string {
+
+	if ic.findSection(sectionName) == nil {
+		return nil
+	}
+
+	names := ic.sortedPropertyNames(sectionName)
+	prefix = ic.normalise(prefix)
+
+	start := sort.SearchStrings(names, prefix)
+	end := start
+
+	for end < len(names) && strings.HasPrefix(names[end], prefix) {
+		end++
+	}
+
+	return append([]string(nil), names[start:end]...)
+}
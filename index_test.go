@@ -0,0 +1,53 @@
+package inifile
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCaseInsensitiveLookup(t *testing.T) {
+
+	options := DefaultIniOptions()
+	options.CaseSensitive = false
+
+	ic, _ := NewIniConfigFromJSON([]byte(`{"Mixed":{"Name":"a","ÄBC":"b"}}`), options)
+
+	long := strings.Repeat("X", maxStackName+1)
+	ic.Add("mixed", long, "c")
+
+	for name, expected := range map[string]string{"NAME": "a", "name": "a", "äbc": "b", "äBC": "b", strings.ToLower(long): "c"} {
+
+		if v, err := ic.Value("MIXED", name); err != nil || v != expected {
+			t.Errorf("Unexpected result for %s: %s %v", name, v, err)
+		}
+	}
+
+	if ic.PropertyExists("Mixed", "missing") {
+		t.Errorf("Did not expect missing property to exist")
+	}
+}
+
+func TestPropertyNamesWithPrefix(t *testing.T) {
+
+	ic := NewIniConfigFromMap(map[string]map[string]string{
+		"s": {"db.host": "h", "db.port": "1", "dc": "x", "a": "y"},
+	})
+
+	if names := ic.PropertyNamesWithPrefix("s", "db."); !reflect.DeepEqual(names, []string{"db.host", "db.port"}) {
+		t.Errorf("Unexpected names %v", names)
+	}
+
+	ic.Add("s", "db.name", "n")
+	ic.Delete("s", "db.port")
+
+	is, _ := ic.Section("s")
+
+	if names := is.PropertyNamesWithPrefix("db."); !reflect.DeepEqual(names, []string{"db.host", "db.name"}) {
+		t.Errorf("Expected index to reflect changes %v", names)
+	}
+
+	if names := ic.PropertyNamesWithPrefix("missing", ""); names != nil {
+		t.Errorf("Expected no names for missing section %v", names)
+	}
+}
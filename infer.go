@@ -0,0 +1,149 @@
+package inifile
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind is the most specific type a value can be converted to, as reported by InferTypes.
+type Kind int
+
+const (
+	// KindString values cannot be converted to any of the other kinds
+	KindString Kind = iota
+
+	// KindInt values can be read with ValueAsInt64 (and so also as unsigned integers if not negative, and as floats)
+	KindInt
+
+	// KindUint values are too large for ValueAsInt64 but can be read with ValueAsUint64
+	KindUint
+
+	// KindFloat values can be read with ValueAsFloat64 but are not integers
+	KindFloat
+
+	// KindBool values can be read with ValueAsBool but are not numbers
+	KindBool
+
+	// KindDuration values can be parsed with time.ParseDuration but are not numbers
+	KindDuration
+)
+
+// String returns the name of the kind: string, int, uint, float, bool or duration.
+func (k Kind) String() string {
+
+	switch k {
+	case KindInt:
+		return "int"
+	case KindUint:
+		return "uint"
+	case KindFloat:
+		return "float"
+	case KindBool:
+		return "bool"
+	case KindDuration:
+		return "duration"
+	}
+
+	return "string"
+}
+
+// InferTypes returns the Kind of the value of every property in this IniConfig, keyed by section and then property name,
+// so that migration tools can list the properties of an existing file and generate a schema or struct for it.
+//
+// Each value is retrieved with Value and checked against the kinds in the order int, uint, float, bool and duration,
+// and the first kind it can be converted to is reported. As a result a value like 1 is reported as KindInt even if
+// UseGoBoolRules means it could also be read as a bool. Floats and bools are checked with the rules set in this
+// IniConfig's IniOptions. Values that are unset or cannot be retrieved are reported as KindString.
+//
+// Inferring a type does not count as a failed conversion for the purposes of the Metrics set in IniOptions.
+func (ic *IniConfig) InferTypes() map[string]map[string]Kind {
+
+	kinds := make(map[string]map[string]Kind)
+
+	for _, section := range ic.SectionNames() {
+
+		inferred := make(map[string]Kind)
+
+		for _, property := range ic.propertyOrder[section] {
+
+			pv, found := ic.sections[section][property]
+
+			if !found {
+				continue
+			}
+
+			value, err := ic.Value(section, property)
+
+			if err != nil || !pv.IsSet() {
+				inferred[property] = KindString
+				continue
+			}
+
+			inferred[property] = ic.inferKind(value)
+		}
+
+		kinds[section] = inferred
+	}
+
+	return kinds
+}
+
+// inferKind returns the most specific Kind the supplied value can be converted to.
+func (ic *IniConfig) inferKind(value string) Kind {
+
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return KindInt
+	}
+
+	if _, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return KindUint
+	}
+
+	if ic.isFloat(value) {
+		return KindFloat
+	}
+
+	if ic.isBool(value) {
+		return KindBool
+	}
+
+	if _, err := time.ParseDuration(value); err == nil {
+		return KindDuration
+	}
+
+	return KindString
+}
+
+// isFloat returns true if the supplied value would be accepted by ValueAsFloat64.
+func (ic *IniConfig) isFloat(value string) bool {
+
+	f, err := strconv.ParseFloat(value, 64)
+
+	if err != nil {
+		return false
+	}
+
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return ic.options.AllowNonFiniteFloats
+	}
+
+	return ic.options.AllowFloatExponent || !strings.ContainsAny(value, "eEpP")
+}
+
+// isBool returns true if the supplied value would be accepted by ValueAsBool.
+func (ic *IniConfig) isBool(value string) bool {
+
+	options := ic.options
+
+	if options.UseGoBoolRules {
+		_, err := strconv.ParseBool(value)
+
+		return err == nil
+	}
+
+	trueValues, falseValues := options.strictBoolValues()
+
+	return options.matchesStrictBool(value, trueValues) || options.matchesStrictBool(value, falseValues)
+}
@@ -0,0 +1,49 @@
+package inifile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInferTypes(t *testing.T) {
+
+	content := "count=-3\nbig=18446744073709551615\nratio=0.5\nhuge=1e9\nenabled=true\ntimeout=1m30s\nname=server\n"
+
+	ic, err := newIniConfigFromReader(strings.NewReader(content), "infer.ini", DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	kinds := ic.InferTypes()
+
+	expected := map[string]Kind{
+		"count":   KindInt,
+		"big":     KindUint,
+		"ratio":   KindFloat,
+		"huge":    KindFloat,
+		"enabled": KindBool,
+		"timeout": KindDuration,
+		"name":    KindString,
+	}
+
+	for property, kind := range expected {
+		if got := kinds[GLOBAL_SECTION][property]; got != kind {
+			t.Errorf("Expected %s to be %s, got %s", property, kind, got)
+		}
+	}
+
+	options := DefaultIniOptions()
+	options.AllowFloatExponent = false
+	options.UseGoBoolRules = false
+	options.StrictBoolTrue = "yes"
+	options.StrictBoolFalse = "no"
+
+	ic, _ = newIniConfigFromReader(strings.NewReader("huge=1e9\nenabled=true\nflag=no\n"), "infer.ini", options)
+
+	kinds = ic.InferTypes()
+
+	if kinds[GLOBAL_SECTION]["huge"] != KindString || kinds[GLOBAL_SECTION]["enabled"] != KindString || kinds[GLOBAL_SECTION]["flag"] != KindBool {
+		t.Errorf("Expected inference to follow the float and bool rules in the IniOptions, got %v", kinds[GLOBAL_SECTION])
+	}
+}
@@ -24,6 +24,57 @@ For example:
 		ic, err := inifile.NewIniConfigFromPath("/path/to/file.ini")
 	}
 
+Loading many files
+
+Tools that need to parse a large number of files can call
+	inifile.LoadAll([]string, *IniOptions)
+which parses the files concurrently and returns a map of IniConfig objects keyed by path. If any files could not be parsed,
+the error returned is a LoadErrors recording the problem with each file.
+
+Applications that open the same files repeatedly (e.g. on every request) can use a ConfigCache, created with
+	inifile.NewConfigCache(capacity int, options *IniOptions)
+whose Get method only parses a file again if its modification time or size has changed.
+
+Services that pick up configuration changes while running can hold their configuration in an AtomicIniConfig. Request
+handlers call Load to obtain the latest IniConfig while
+	Watch(ctx context.Context, path string, options *IniOptions, interval time.Duration, onError func(error))
+reloads the file whenever it changes and stores the result, keeping the previous configuration if the new file cannot be
+parsed.
+
+To reject files that parse but contain settings your application cannot use, load them with
+	inifile.LoadAndValidate(path string, validate func(*IniConfig) error, options *IniOptions)
+which only returns the IniConfig if validate returns nil. AtomicIniConfig's ReloadAndValidate and WatchAndValidate apply
+the same check before storing a new configuration, so request handlers never see one that failed validation.
+
+Drop-in configuration directories
+
+Many Unix daemons read a base file followed by every file in a drop-in directory. To do the same, call
+	inifile.LoadDir(pattern string, options *IniOptions, strategy MergeStrategy)
+with a pattern like /etc/myapp/conf.d/*.ini (a ** segment matches any number of directories). Matching files are parsed in
+lexical order and merged into a single IniConfig; the MergeStrategy controls whether later files override, are overridden by,
+replace whole sections of or conflict with earlier files. Individual IniConfig objects can be combined in the same way with
+	Merge(other *IniConfig, strategy MergeStrategy)
+
+Drop-in files can also remove settings made by earlier files. Set TombstoneValue in your IniOptions (e.g. to "!unset")
+so that a property with that value removes the property, and TombstoneSectionPrefix (e.g. to "-") so that a header
+like [-database] removes every property set earlier in [database].
+
+Lazy parsing
+
+Applications that only need a few sections from a very large file can avoid parsing the whole file by calling
+	inifile.NewLazyIniConfig(io.ReaderAt, int64, *IniOptions)
+which only records where each section starts. Each section is parsed the first time it is accessed, so the io.ReaderAt
+(typically an *os.File) must remain open for as long as the IniConfig is in use. Syntax errors are reported when the affected
+section is accessed rather than when the IniConfig is created. As accessing a section can parse it, a lazily parsed
+IniConfig must not be used by more than one goroutine at a time.
+
+Custom sources
+
+INI content stored somewhere other than the local filesystem (object storage, a key/value store, a database) can be
+parsed by implementing the Source interface and calling one of:
+	inifile.NewIniConfigFromSource(Source)
+	inifile.NewIniConfigFromSourceWithOptions(Source, *IniOptions)
+
 
 Accessing properties
 
@@ -37,13 +88,44 @@ You can retrieve the value associated with a property by using one of:
 	ValueAsUint64(sectionName, propertyName string)
 	ValueAsBool(sectionName, propertyName string)
 
+Accessors for narrower types check that the value is within the range of the type and return an error if it is not:
+	ValueAsInt(sectionName, propertyName string)
+	ValueAsInt32(sectionName, propertyName string)
+	ValueAsUint32(sectionName, propertyName string)
+	ValueAsUint16(sectionName, propertyName string)
+	ValueAsFloat32(sectionName, propertyName string)
+
+Values holding a list of key/value pairs (e.g. labels=env=prod;team=core) can be retrieved as a map with:
+	ValueAsMap(sectionName, propertyName string, pairSep, kvSep string)
+
+Thresholds and sampling rates written as 75%, 0.75 or 75 can be retrieved as a fraction (0.75) with:
+	ValueAsPercent(sectionName, propertyName string)
+Set PercentWithoutSymbolIsFraction in your IniOptions to control whether values without a % symbol are fractions or percentages.
+
+Keys, tokens and other binary values stored as base64 or hexadecimal can be decoded into a []byte with:
+	ValueAsBase64(sectionName, propertyName string)
+	ValueAsHex(sectionName, propertyName string)
+
+To convert a value into a variable of any supported type (including time.Duration and types implementing
+encoding.TextUnmarshaler, such as net.IP), pass a pointer to the variable to:
+	ValueInto(sectionName, propertyName string, target interface{})
+
 These methods will return an error if the requested section or name does not exist or if the value associated with the
-requested property could not be converted to the request data type.
+requested property could not be converted to the request data type. These errors wrap ErrSectionNotFound,
+ErrPropertyNotFound or ErrConversion so that the type of failure can be checked with errors.Is.
 
 To check that a section of property exists before you call one of these functions use:
 	SectionExists(sectionName string)
 	PropertyExists(sectionName, propertyName string)
 
+To list the sections in the file, call SectionNames() on your IniConfig. To list the properties in a section, call
+PropertyNames() on an IniSection (see below).
+To hand the settings in one section to code that should not see the rest of the file (such as a plugin), call
+Detach() on the IniSection to obtain an independent IniConfig holding a copy of just that section.
+
+To select related properties in a section, such as handler.* or route_*, call Match(glob string) or
+MatchRegexp(re *regexp.Regexp) on an IniSection to obtain a map of the names and values of the matching properties.
+
 Methods exist to return the zero value for a type instead of an error if the section/property didn't exist or if there
 was a problem converting the value to the requested type:
 	ValueOrZero(sectionName, propertyName string)
@@ -52,6 +134,41 @@ was a problem converting the value to the requested type:
 	ValueOrZeroAsUint64(sectionName, propertyName string)
 	ValueOrZeroAsBool(sectionName, propertyName string)
 
+ValueOrZeroAsBool cannot distinguish a property that is false from one that is missing or invalid, which matters for
+feature flags. Use
+	ValueAsBoolDefault(sectionName, propertyName string, def bool)
+to supply the value to use when the property is missing or invalid, or
+	ValueAsOptionalBool(sectionName, propertyName string)
+which also returns whether the property is present and, if it is, any error interpreting its value.
+
+Many tools let a later file switch off a feature enabled by an earlier one with a property like no-debug=true. Set
+NegationPrefixes in your IniOptions (e.g. to []string{"no-", "disable-"}) to have the boolean accessors honour this
+convention: whichever of debug and no-debug was set last decides the value of debug.
+
+Connection strings
+
+Sections like
+	[database]
+	host=db.example.com
+	user=app
+	password=secret
+	database=orders
+can be turned into the connection strings expected by database drivers and network libraries by calling
+	MySQLDSN(sectionName string)
+	PostgresDSN(sectionName string)
+	URL(sectionName, scheme string)
+	SMTPAddress(sectionName string)
+on your IniConfig (or the same methods without a section name on an IniSection). An error wrapping ErrPropertyNotFound
+lists any required properties that are missing.
+
+Feature flags
+
+To treat every property in a section as a boolean feature flag, call
+	Flags(section string)
+on your IniConfig. The returned FeatureFlags reports whether a flag is Enabled (with EnabledOr supplying a default for
+missing flags) and can Report flags that are not in a list of known flags, suggesting the intended flag for likely
+misspellings, or whose values are not valid booleans.
+
 Accessing properties in the global section
 
 Use the constant inifile.GLOBAL_SECTION as the sectionName when calling any of the above functions to work with properties that are not
@@ -73,10 +190,151 @@ it is recommended that you use an IniSection object, which offers the same funct
 	c, err := is.Value("c")
 
 
-Adding new properties
+Decoupling and testing
 
-Properties can be added to an IniConfig at runtime by calling:
+IniConfig and IniSection implement the ConfigSource and SectionSource interfaces, which cover their read-only accessors.
+Components that accept these interfaces can be unit tested with configuration built in memory using
+	inifile.NewIniConfigFromMap(map[string]map[string]string)
+
+Adding and removing properties
+
+Properties can be added to or removed from an IniConfig at runtime by calling:
 	Add(section, propertyName string, value string)
+	Delete(section, propertyName string)
+
+Command-line flags
+
+Command-line flags defined with the standard flag package can be combined with the properties in a section by calling
+	ApplyToFlagSet(fs *flag.FlagSet, section string)
+after parsing the command line. Any flag not set on the command line is set to the value of the property with the same
+name. Alternatively, call
+	RegisterFlags(fs *flag.FlagSet, section string)
+before parsing the command line to define a flag for each property in the section, using the property's value as its default.
+
+Diagnostics
+
+To help diagnose why an application is using a particular value, call
+	Dump(w io.Writer, opts DumpOptions)
+on your IniConfig to write an annotated listing of every property, optionally showing the file and line each value came from,
+which earlier definition it overrode and (if TrackReads is set in your IniOptions) whether it has been read.
+
+Metrics
+
+To observe the health of configuration in a production service, set:
+	Metrics
+in your IniOptions to an implementation of the Metrics interface. It is told how long each file took to parse and
+whether parsing failed, whether a ConfigCache had to parse a file, which values could not be converted to the requested
+type and which look-ups were for sections or properties that do not exist. The interface is small so that it can be
+adapted to Prometheus, expvar or another metrics system.
+
+Statistics
+
+For monitoring and capacity planning, call
+	Stats()
+on your IniConfig to obtain counts of the sections, properties and empty values it contains along with information gathered
+while parsing (duplicated properties, the longest line and the number of bytes parsed).
+
+Tools migrating an existing file to typed access can call
+	InferTypes()
+to find out whether the value of each property can be read as an int, uint, float, bool or duration, or only as a string.
+
+Writing and converting
+
+An IniConfig can be written out in INI format, with sections and properties in the order they were first parsed or added, by calling:
+	WriteTo(w io.Writer)
+	Save(path string)
+
+Comments and blank lines in the original file are not preserved. The layout of the output can be controlled by passing
+an IniWriteOptions to
+	WriteToWithOptions(w io.Writer, wo *IniWriteOptions)
+	SaveWithOptions(path string, wo *IniWriteOptions)
+
+For example, setting Sorted = true writes sections and properties in alphabetical order so that generated files produce
+clean diffs in version control. Other fields control the alignment of and spacing around assignment symbols, the number of blank
+lines between sections, when and how values are quoted and whether lines end with LF or CRLF.
+
+To label machine-managed files, set GeneratedBy, GeneratedFrom and DoNotEditWarning in the IniWriteOptions to start
+the output with comments naming the tool and source template and warning against editing by hand. The time of
+generation is included unless OmitTimestamp is set, which keeps the output identical for reproducible builds.
+
+Add puts new properties at the end of their section and new sections at the end of the file. To put them where
+people reading the file would expect, call
+	AddAt(section, propertyName, value string, p Placement)
+	PlaceSection(section string, p Placement)
+with PlaceAfter(name), PlaceFirst(), PlaceLast() or PlaceSorted().
+
+Comments documenting units or allowed values can be attached to properties with
+	SetInlineComment(sectionName, propertyName, comment string)
+and are written after the property's value (or above the property if AllowInlineComments is false in your IniOptions).
+
+To show a change to a user for confirmation before saving it, call
+	Preview(path string)
+on your IniConfig to obtain the content Save would write and a unified diff against the current contents of the file.
+To avoid rewriting a file that would not change (and the reloads that can trigger), check
+	NeedsSave(path string)
+first.
+
+To recover from bad programmatic edits, set BackupVersions in the IniWriteOptions to keep the previous versions of a
+file (file.ini.1, file.ini.2 and so on) when it is saved, or BackupDirectory to copy each previous version to a
+directory with a timestamp appended to its name.
+
+To make sure a crash part-way through writing never leaves a corrupt file, use
+	SaveAtomic(path string, perm os.FileMode, keepBackup bool)
+which writes to a temporary file and renames it over the original, optionally keeping the previous version as a backup.
+
+When several processes may edit the same file, use
+	inifile.EditLocked(path string, options *IniOptions, edit func(*IniConfig) error)
+to read, modify and save the file while holding an advisory lock (Unix-like platforms only). Only the lines holding
+properties added, changed or deleted by the edit are rewritten, so comments and layout are kept.
+
+To change a few values in a file that is also edited by hand or by other tools, call
+	inifile.UpdateFile(path string, changes map[string]map[string]string, options *IniOptions)
+which edits only the lines holding the changed properties (adding lines for new properties and sections) and leaves the
+rest of the file exactly as it was.
+Properties can be removed in the same way with
+	inifile.RemoveFromFile(path string, removals map[string][]string, options *IniOptions)
+
+To tidy a file without losing its comments, blank lines or property order, call
+	inifile.Format(src []byte, style *FormatStyle)
+which standardises indentation, spacing around assignment symbols, the alignment of inline comments and the number of
+blank lines, in the same way gofmt does for Go source. DefaultFormatStyle returns a baseline style to modify.
+
+An IniConfig can also be converted to and from JSON (an
+object of sections, each an object of property names and string values) with:
+	json.Marshal(ic)
+	inifile.NewIniConfigFromJSON([]byte, *IniOptions)
+
+or to and from YAML (global properties as top-level keys and each section as a top-level map) with:
+	ToYAML()
+	inifile.NewIniConfigFromYAML([]byte, *IniOptions)
+
+The YAML conversion has no dependencies outside the standard library, so only the subset of YAML needed to represent
+an INI file can be read back. Raw values are converted, so a round trip does not lose anything.
+
+Simple TOML documents (keys and tables of strings, numbers and booleans) can be converted with:
+	ToTOML()
+	inifile.NewIniConfigFromTOML([]byte, *IniOptions)
+
+NewIniConfigFromTOML returns an error naming the line of any TOML feature that cannot be represented in an IniConfig,
+such as arrays, inline tables or nested tables.
+
+To mirror configuration into the Windows registry, call
+	WriteReg(w io.Writer, rootKey string, schema *IniSchema)
+on your IniConfig to write a .reg file with a subkey of rootKey for each section. Values are written as strings unless
+an IniSchema is supplied, in which case bool and integer properties are written as DWORD or QWORD values.
+
+Files derived from the configuration (e.g. nginx snippets or systemd drop-ins) can be generated with text/template by
+calling
+	RenderTemplate(tmpl *template.Template, w io.Writer)
+on your IniConfig. The template receives a TemplateData holding every section and property.
+
+To pass configuration to a child process, call
+	ToEnvSlice(prefix string)
+on your IniConfig to obtain environment variables like APP_DATABASE_HOST=db.example.com for exec.Cmd.Env. The child can
+load them back into an IniConfig with
+	inifile.FromEnv(os.Environ(), prefix, options)
+
+The iniq command (github.com/graniticio/inifile/cmd/iniq) makes these functions available from the command line.
 
 
 Customising parsing and configuration access
@@ -95,6 +353,21 @@ and then only modify those you need to change (see the documentation for Default
 
 will parse a file using # instead of ; to identify comment lines.
 
+To catch inconsistent options (for example an empty CommentStart, or UseGoBoolRules = false without StrictBoolTrue and
+StrictBoolFalse) when your application starts rather than when a file is parsed or a value accessed, call
+	inifile.ValidateOptions(*IniOptions)
+
+Options suitable for the files read by common programs are registered as dialects (DialectWindows, DialectMySQL,
+DialectSystemd, DialectGit, DialectPython and others). To parse a file in one of these dialects, call
+	inifile.NewIniConfigFromPathWithDialect(string, Dialect)
+or call DialectOptions to obtain the options and modify them further. Your own dialects can be added with RegisterDialect.
+
+IniOptions combines ParseOptions, which control how a file is parsed and written, with AccessOptions, which control how
+values are interpreted when they are accessed. As AccessOptions do not affect the stored properties, a view of an IniConfig
+with different AccessOptions can be created without parsing the file again:
+	lenient := ic.WithAccessOptions(ao)
+The view is a snapshot, so changes made to ic after the view was created are not visible through it.
+
 Case-sensitivity for section and property names
 
 By default look-ups of sections and properties are case sensitive - Value("mysection", "myproperty") would not match a property called myProperty in a section called [MYPROPERTY].
@@ -169,14 +442,279 @@ e.g:
 To support case-insensitve matching of the values in StrictBoolTrue and StrictBoolFalse set:
 	StrictBoolCaseSensitive = false
 
+Further values can be accepted by listing them in
+	StrictBoolTrueSynonyms = []string{"yes", "on"}
+	StrictBoolFalseSynonyms = []string{"no", "off"}
+
+If no true or no false values are set, ValueAsBool returns an error wrapping ErrInvalidOptions rather than matching
+empty values.
+
+
+Floating point values
+
+ValueAsFloat64 and the other floating point accessors accept any value Go's strconv.ParseFloat accepts, including
+exponent notation (1e6) and the non-finite values Inf and NaN. Consumers that must reject these can set:
+	AllowFloatExponent = false
+	AllowNonFiniteFloats = false
+in your IniOptions.
+
+Array properties
+
+Some INI files (notably those read by PHP) define lists by repeating a property name with an index suffix:
+	servers[]=alpha
+	servers[]=beta
+or
+	servers[0]=alpha
+	servers[1]=beta
+Explicitly indexed elements can always be retrieved in order with Values or ValueAsStringSlice. To number elements
+written with empty brackets automatically (in the order they appear), set:
+	AggregateArrayKeys = true
+in your IniOptions. Without this option, each servers[] line overrides the previous one.
+
+Files without sections
+
+Many files on Unix systems (/etc/os-release, sysctl.conf) contain only key=value lines. To stop a line starting with [
+being interpreted as a section header, set:
+	NoSections = true
+in your IniOptions. All properties are then stored in GLOBAL_SECTION. The KeyValueIniOptions function returns a set of
+options suitable for these files.
+
+Samba configuration
+
+Samba's smb.conf uses both # and ; for comments, case-insensitive names containing spaces and %-macros such as %U that
+Samba expands at runtime. SambaIniOptions returns suitable options. Macros are left in values unless you set:
+	MacroExpander
+in your IniOptions to a function that returns the expansion of each macro.
+
+EditorConfig
+
+In .editorconfig files (https://editorconfig.org) section names are glob patterns. Parse them with
+EditorConfigIniOptions and call
+	ResolveFor(path string)
+on the IniConfig to obtain an IniSection holding the properties that apply to a file. The EditorConfigFor function finds
+and merges every .editorconfig file that applies to a path, stopping at a file with root=true in its preamble.
+
+Layered configuration
+
+A value can refer to a property in a separate IniConfig (for example a secrets file with stricter permissions) without
+the two being merged. Register the other IniConfig with
+	AddLayer(name string, layer *IniConfig)
+and set:
+	LayerReferences = true
+in your IniOptions. References of the form ${name:section.property} (or ${name:property} for the global section) are
+then replaced with the referenced value when a value is accessed. Use $${ for a literal ${.
+
+Multi-line values
+
+Python's setup.cfg and tox.ini files continue values on the following indented lines, typically to define lists:
+	[options]
+	install_requires =
+		requests
+		click>=7
+To support this, set:
+	AllowContinuationLines = true
+in your IniOptions. The trimmed continuation lines are joined to the value with newlines and can be retrieved as a slice
+with ValueAsLines. A blank line ends the value.
+
+Character encoding
+
+Files are expected to be UTF-8. By default, bytes that are not valid UTF-8 are kept in section names, property names and
+values, which can cause problems when the configuration is later converted to JSON or compared. To replace invalid bytes
+with U+FFFD or fail parsing, set:
+	InvalidUTF8 = InvalidUTF8Replace
+or
+	InvalidUTF8 = InvalidUTF8Reject
+in your IniOptions.
+
+Line endings and control characters
+
+Lines ending in LF or CRLF are always accepted. Files edited on a mix of platforms sometimes contain lone carriage
+returns, which are kept as part of a value unless you set:
+	NormalizeLineEndings = true
+in your IniOptions. Control characters in values are recorded as warnings (see below). To fail parsing instead, set:
+	RejectControlCharacters = true
+
+Type annotations
+
+The type of a property can be declared in the file by appending a colon and a type to its name:
+	[server]
+	port:int=8080
+	timeout:float64=2.5
+	debug:bool=false
+To support this, set:
+	TypeAnnotations = true
+in your IniOptions. The property is stored under its name without the annotation (port) and parsing fails if its value
+cannot be converted to the declared type. The supported types are string, int, int32, int64, uint16, uint32, uint64,
+float32, float64 and bool. DeclaredType returns the type declared for a property and Validate re-checks every value.
+
+Hierarchical sections
+
+Section names can form a hierarchy by separating levels with the SectionSeparator in your IniOptions (. by default):
+	[service]
+	timeout=30
+
+	[service.api]
+	port=8080
+IniSection provides Parent, Child and Children to navigate the hierarchy, and ValueAt to look up properties with a
+relative path like ../shared.timeout.
+
+Namespaces
+
+A library can keep its configuration inside a host application's file, without colliding with the application's
+sections, by reading it through a Namespace:
+	ns := ic.Namespace("mylib")
+	host, err := ns.Value("db", "host")
+reads the property host from the section [mylib.db].
+
+Numbered sections
+
+Replicated blocks of configuration are often written as numbered sections:
+	[worker-1]
+	queue=high
+
+	[worker-2]
+	queue=low
+Call SectionFamily("worker") on your IniConfig to obtain these sections in numeric order (so [worker-10] follows
+[worker-9]). The way names are formed from the base name and number is set by SectionFamilyFormat in your IniOptions
+("%s-%d" by default; use "%s.%d" for [worker.1] or "%s%d" for [worker1]).
+
+Empty section names
+
+By default, the properties following an empty section header ([]) are added to the global section. To fail parsing,
+discard the properties or store them in a named section instead, set one of:
+	EmptySections = EmptySectionReject
+	EmptySections = EmptySectionIgnore
+	EmptySections = EmptySectionRename
+in your IniOptions. When renaming, the properties are stored in the section named by EmptySectionName.
+
+Section names containing brackets
+
+Section names end at the first closing bracket that is followed only by whitespace or a comment, so a name may contain
+brackets (e.g. the EditorConfig glob [*.[ch]]). Parsing fails if no closing bracket is followed only by whitespace or a
+comment. A name containing ] followed by the comment symbol (e.g. a];b) is cut short, so to allow any name containing ],
+set:
+	EscapedSectionNames = true
+in your IniOptions. The bracket can then be escaped with a backslash ([a\]b]) or the name enclosed in double quotes
+(["a]b"]). A backslash in a section name must also be escaped (\\).
+
+If AllowInlineComments is set, names enclosed in double quotes are still cut short at the comment symbol, as inline
+comments are removed before the header is parsed. To take everything between the quotes verbatim, set:
+	QuotedSectionNames = true
+in your IniOptions. A header like ["My Section; strange"] then defines the section My Section; strange, while unquoted
+headers are parsed as normal. Only a double quote or backslash in a quoted name needs to be escaped with a backslash,
+and names that need it are written in quotes by WriteTo.
+
+Properties with no name
+
+A line like =value defines a property with no name, which is almost always a mistake, so parsing fails with a ParseError
+identifying the line. To keep these values, set:
+	EmptyPropertyName
+in your IniOptions to the name they should be stored under.
+
+Property names containing the assignment symbol
+
+Property names end at the first = (or : if UseColonAssignment is set). Some names, like LDAP distinguished names,
+contain the assignment symbol. To support these, set:
+	EscapedPropertyNames = true
+in your IniOptions. The symbol can then be escaped with a backslash or the name enclosed in double quotes:
+	cn\=admin,dc\=example=read
+	"cn=guest,dc=example"=none
+A backslash in a property name must also be escaped (\\).
+
+Null values
+
+To distinguish a property that explicitly has no value from one with an empty value, set:
+	NullLiterals = []string{"null", "~"}
+in your IniOptions. Properties whose unquoted value is one of the literals are returned as nil by ValueOrNil and as an
+empty string by Value. Enclose the value in quotes to use a literal as a real value.
+
+Positions
+
+Editors, language servers and syntax highlighters need to know where each part of a file is. Set:
+	RecordPositions = true
+in your IniOptions to record the byte offsets and line and column ranges of every section header, property name, value
+and comment. They are returned in file order by Elements, and ElementAt finds the element at a line and column.
+
+Signed files
+
+Where a tampered file must be rejected when it is loaded, set:
+	SignatureKey
+in your IniOptions to an Ed25519 public key. Content is only parsed if its last line is a comment holding a signature
+made with the matching private key:
+	; signature: 6vF0jD...
+SignContent adds this comment to content. To keep the signature in a separate file, set DetachedSignature to a
+signature of the entire content. Errors returned for missing or incorrect signatures wrap ErrInvalidSignature.
+
+Checksums
+
+To detect corruption or manual edits of files that are managed by a program, set ChecksumFooter in the IniWriteOptions
+used to write them. A comment holding the SHA-256 checksum of the file is written as its last line:
+	; sha256: 9f86d081884c7d65...
+and setting:
+	VerifyChecksum = true
+in your IniOptions makes parsing fail (with an error wrapping ErrChecksumMismatch) if the file no longer matches it.
+AddChecksum adds the footer to existing content and UpdateFile keeps it up to date.
+
+File permissions
+
+Files holding secrets should not be readable or writable by other users. Setting:
+	ForbiddenPermissions = 0077
+in your IniOptions makes loading a file from a path, *os.File or file Source fail (with an error wrapping ErrInsecurePermissions
+that names the file and the permissions to remove) if its permissions include any of the supplied bits, in the same
+way OpenSSH refuses to use private keys that other users can read.
+
+Read errors
+
+If reading a file fails part way through, the constructor returns an error naming the file and the last line read,
+rather than an IniConfig holding only part of the file. Network filesystems can fail with errors that go away if the
+read is tried again; set ReadRetries (and optionally ReadRetryDelay) in your IniOptions to retry such reads.
+
+Compressed files
+
+Large machine-generated files are often stored compressed. Setting:
+	Decompress = true
+in your IniOptions transparently decompresses content compressed with gzip or bzip2 (recognised by the first bytes of
+the content), so that files like export.ini.gz can be loaded without piping them through another tool first.
+
+Derived properties
+
+To add properties computed from other properties, or to check rules that involve more than one property, set:
+	PostParse
+in your IniOptions to a function that is called with the IniConfig after the file has been parsed. If the function
+returns an error, the constructor returns it instead of the IniConfig.
+
+Custom line formats
+
+Files in a vendor dialect that the other options cannot describe can still be parsed by setting:
+	LineClassifier
+in your IniOptions. The function receives each line (before comments and whitespace are removed) and returns a
+ClassifiedLine that marks it as a section header, a property, a line to ignore or (with LineDefault) a line to be parsed
+normally. Properties returned by the classifier are treated as if they had been parsed from a name=value line.
 
 Unparseable lines
 
 By default, an INI file will not parse correctly if a line is encountered in the file that cannot be interpreted as a
-section, comment, property or a blank line. To ignore unparseable lines, set:
+section, comment, property or a blank line. Errors caused by the content of a particular line are a *ParseError, whose
+Line field identifies the line. To ignore unparseable lines, set:
 	IgnoreUnparseable = true
 in your IniOptions.
 
+To find out which lines were ignored, set
+	Logger
+in your IniOptions. The parser will send it debug messages describing each ignored line, discarded property and overridden
+property.
+
+
+Warnings
+
+Some input is accepted by the parser but is likely to be a mistake (a property defined twice in the same section, a value
+containing control characters or with an opening quote but no closing quote). These problems are recorded and available
+after parsing by calling
+	Warnings()
+on your IniConfig. To treat any warning as a parse error, set:
+	FailOnWarnings = true
+in your IniOptions.
+
 
 Inline comments
 
@@ -200,6 +738,14 @@ For example, with CommentStart = ";" and CommentEscapePrefix="\" your INI file m
 
 The value returned by Value("messages", "message") would be "Service is too busy;load is high"
 
+If inline comments are not allowed, a value like "localhost ;Default to localhost" usually means the author of the file
+expected them to be. To record a warning (see Warnings) or fail parsing when the comment symbol appears after whitespace
+in an unquoted value, set:
+	CommentInValue = CommentInValueWarn
+or
+	CommentInValue = CommentInValueError
+in your IniOptions.
+
 Quoted values
 
 Some INI files surround their values with quotes like:
@@ -214,21 +760,102 @@ The runes (characters) that will be recognised as enclosing quotes are defined i
 	EnclosingQuoteSymbols
 and default to the single (') and double (") quote symbols.
 
+Interpolation
+
+Values can refer to other properties in the same section or the global section using %(name)s syntax:
+	[paths]
+	base=/opt/app
+	logs=%(base)s/logs
+
+To enable this, set:
+	Interpolate = true
+in your IniOptions. References are resolved when a value is accessed and %% is used for a literal %. As well as properties,
+values can refer to the built-in variables listed in
+	InterpolationBuiltins
+which by default allows %(__name__)s (the current section's name) and %(here)s (the directory containing the file). The
+hostname and pid built-ins are also available if added to this list. Properties take precedence over built-in variables with
+the same name.
+
+Encrypted values
+
+Secrets can be stored encrypted in an INI file and decrypted when they are accessed by setting
+	ValueDecryptor
+in your IniOptions to a function that performs the decryption (e.g. by calling a KMS or Vault). The function is only called for
+values enclosed by
+	EncryptedValuePrefix
+	EncryptedValueSuffix
+which default to ENC[ and ] respectively:
+	[database]
+	password=ENC[AQICAHh4...]
+
+The function receives the text between the markers and its result is returned by Value and the other accessors. Values are
+decrypted each time they are accessed and the plaintext is never stored in the IniConfig.
+
+Sensitive properties
+
+Errors returned when a value cannot be converted to a requested type normally include the value. To prevent passwords and other
+secrets leaking into logs, properties can be marked as sensitive, either by listing patterns (see path.Match) for their names in
+	SensitiveProperties = []string{"*password*", "secret"}
+in your IniOptions or by calling
+	MarkSensitive(sectionName, propertyName string)
+The values of sensitive properties are replaced with **** in errors, in the output of Dump and in the JSON produced by
+MarshalJSON. Other output (WriteTo, Save, ToYAML, ToTOML, ToEnvSlice, RenderTemplate and WriteReg) contains the real
+values, as it is intended to be read back. As a result, converting an IniConfig to JSON and back with
+NewIniConfigFromJSON loses the values of sensitive properties.
+
+Conditional sections
+
+A single INI file can hold configuration for several platforms or environments by qualifying section names with a tag:
+	[server]
+	port=80
+
+	[server:linux]
+	user=www-data
+
+	[server:production]
+	port=8080
+
+Qualified sections are only used if their tag is listed in
+	ActiveTags
+in your IniOptions. The properties in active qualified sections are merged into the unqualified section (server in the
+example above) in the order the tags appear in ActiveTags, so a later tag's properties override an earlier tag's. Unqualified
+sections are always active and properties in qualified sections for inactive tags are discarded. If ActiveTags is empty,
+section names containing a colon are treated literally.
 
 */
 package inifile
 
 import (
+	"crypto/ed25519"
+	"io"
 	"os"
 	"bufio"
+	"path"
 	"regexp"
 	"strings"
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
 )
 
-type sectionPropertyMap map[string]map[string]*nilableString
+type sectionPropertyMap map[string]map[string]propertyValue
+
+// The stored value of a property and the lines of the source it was parsed from
+type propertyValue struct {
+	nilableString
+
+	// The line the value was parsed from (0 if added at runtime)
+	line int32
+
+	// The line of the definition this value replaced (0 if it did not replace a parsed value)
+	overrides int32
+
+	// The index in IniConfig.sources of the file or Source the value was parsed from
+	source uint16
+}
 
 // If your INI file contains properties outside of a named section, use this constant as the 'section name' when
 // looking up property values. For example:
@@ -249,6 +876,8 @@ const GLOBAL_SECTION = ""
 //		UseGoBoolRules					true
 //		StrictBoolTrue					""
 //		StrictBoolFalse					""
+//		StrictBoolTrueSynonyms			nil
+//		StrictBoolFalseSynonyms			nil
 //		StrictBoolCaseSensitive			true
 //		IgnoreUnparseable				false
 // 		AllowInlineComments				false
@@ -256,6 +885,53 @@ const GLOBAL_SECTION = ""
 //		StripEnclosingQuotes			false
 //		EnclosingQuoteSymbols			[]rune{'\'','"'}
 //      UseColonAssignment              false
+//		ActiveTags						nil
+//		ValueDecryptor					nil
+//		EncryptedValuePrefix			"ENC["
+//		EncryptedValueSuffix			"]"
+//		SensitiveProperties				nil
+//		Logger							nil
+//		Metrics							nil
+//		FailOnWarnings					false
+//		CommentInValue					CommentInValueAllow
+//		NoSections						false
+//		ExtraCommentStarts				nil
+//		MacroExpander					nil
+//		AllowContinuationLines			false
+//		InvalidUTF8						InvalidUTF8PassThrough
+//		NormalizeLineEndings			false
+//		RejectControlCharacters			false
+//		TypeAnnotations					false
+//		SectionSeparator				"."
+//		LayerReferences					false
+//		LineClassifier					nil
+//		EmptySections					EmptySectionGlobal
+//		EmptySectionName				"unnamed"
+//		EscapedSectionNames				false
+//		QuotedSectionNames				false
+//		EmptyPropertyName				""
+//		EscapedPropertyNames			false
+//		PostParse						nil
+//		NullLiterals					nil
+//		RecordPositions					false
+//		SignatureKey					nil
+//		DetachedSignature				nil
+//		VerifyChecksum					false
+//		ForbiddenPermissions			0
+//		ReadRetries						0
+//		ReadRetryDelay					100 * time.Millisecond
+//		Decompress						false
+//		TombstoneValue					""
+//		TombstoneSectionPrefix			""
+//		TrackReads						false
+//		Interpolate						false
+//		InterpolationBuiltins			[]string{"__name__", "here"}
+//		PercentWithoutSymbolIsFraction	true
+//		AllowFloatExponent				true
+//		AllowNonFiniteFloats			true
+//		AggregateArrayKeys				false
+//		SectionFamilyFormat				"%s-%d"
+//		NegationPrefixes				nil
 //
 func DefaultIniOptions() *IniOptions {
 	io := new(IniOptions)
@@ -267,6 +943,8 @@ func DefaultIniOptions() *IniOptions {
 	io.AllowGlobalSection = true
 	io.DiscardPropertiesWithNoValue = true
 	io.UseGoBoolRules = true
+	io.StrictBoolTrueSynonyms = nil
+	io.StrictBoolFalseSynonyms = nil
 	io.StrictBoolCaseSensitive = true
 	io.IgnoreUnparseable = false
 	io.AllowInlineComments = false
@@ -274,12 +952,66 @@ func DefaultIniOptions() *IniOptions {
 	io.StripEnclosingQuotes = false
 	io.EnclosingQuoteSymbols = []rune{'\'','"'}
     io.UseColonAssignment = false
+	io.ActiveTags = nil
+	io.ValueDecryptor = nil
+	io.EncryptedValuePrefix = "ENC["
+	io.EncryptedValueSuffix = "]"
+	io.SensitiveProperties = nil
+	io.Logger = nil
+	io.Metrics = nil
+	io.FailOnWarnings = false
+	io.CommentInValue = CommentInValueAllow
+	io.NoSections = false
+	io.ExtraCommentStarts = nil
+	io.MacroExpander = nil
+	io.AllowContinuationLines = false
+	io.InvalidUTF8 = InvalidUTF8PassThrough
+	io.NormalizeLineEndings = false
+	io.RejectControlCharacters = false
+	io.TypeAnnotations = false
+	io.SectionSeparator = "."
+	io.LayerReferences = false
+	io.LineClassifier = nil
+	io.EmptySections = EmptySectionGlobal
+	io.EmptySectionName = "unnamed"
+	io.EscapedSectionNames = false
+	io.QuotedSectionNames = false
+	io.EmptyPropertyName = ""
+	io.EscapedPropertyNames = false
+	io.PostParse = nil
+	io.NullLiterals = nil
+	io.RecordPositions = false
+	io.SignatureKey = nil
+	io.DetachedSignature = nil
+	io.VerifyChecksum = false
+	io.ForbiddenPermissions = 0
+	io.ReadRetries = 0
+	io.ReadRetryDelay = 100 * time.Millisecond
+	io.Decompress = false
+	io.TombstoneValue = ""
+	io.TombstoneSectionPrefix = ""
+	io.TrackReads = false
+	io.Interpolate = false
+	io.InterpolationBuiltins = []string{BuiltinSectionName, BuiltinHere}
+	io.PercentWithoutSymbolIsFraction = true
+	io.AllowFloatExponent = true
+	io.AllowNonFiniteFloats = true
+	io.AggregateArrayKeys = false
+	io.SectionFamilyFormat = "%s-%d"
+	io.NegationPrefixes = nil
 
 	return io
 }
 
-//IniOptions allows you to alter the behaviour of parsing and subsequent access to parsed configuration.
+//IniOptions allows you to alter the behaviour of parsing and subsequent access to parsed configuration. The fields of
+//ParseOptions and AccessOptions can be set directly on an IniOptions (e.g. io.CommentStart = "#").
 type IniOptions struct {
+	ParseOptions
+	AccessOptions
+}
+
+//ParseOptions control how the content of a file is interpreted when it is parsed and how it is written by WriteTo.
+type ParseOptions struct {
 	//Set to true if section and variable names should be treated as-case sensitive.
 	CaseSensitive bool
 
@@ -298,6 +1030,147 @@ type IniOptions struct {
 	//How to handle properties with a name but no value
 	DiscardPropertiesWithNoValue bool
 
+	//Ignore lines that cannot be parsed as a section, property, comment or blank
+	IgnoreUnparseable bool
+
+	//Allow comments on the same line as a section or property
+	AllowInlineComments bool
+
+	//The string which, when found before the comment symbol, escapes that symbol
+	//Only used when AllowInlineComments = true
+	CommentEscapePrefix string
+
+	//Remove leading and trailing quotes from property values before storing them
+	StripEnclosingQuotes bool
+
+	//The symbols that are used as enclosing quotes
+	EnclosingQuoteSymbols []rune
+
+    //Assignment uses colon not equals
+    UseColonAssignment bool
+
+	//The tags (e.g. linux, production) that select which qualified sections like [server:linux] are active.
+	//Qualified sections are merged into their unqualified section in the order the tags appear here.
+	//If empty, section names containing : are treated literally
+	ActiveTags []string
+
+	//Receives debug messages describing decisions made while parsing (e.g. lines that were ignored)
+	Logger Logger
+
+	//Receives measurements of parsing and configuration access (e.g. parse durations and look-ups of missing properties)
+	Metrics Metrics
+
+	//Return an error if parsing generates any warnings (see IniConfig.Warnings)
+	FailOnWarnings bool
+
+	//What to do when AllowInlineComments is false but a value contains the comment symbol after whitespace
+	CommentInValue CommentInValueMode
+
+	//Do not recognise section headers, so that lines starting with [ are parsed as properties in GLOBAL_SECTION
+	NoSections bool
+
+	//Other strings that mark a line as a comment if they start it (e.g. ";" for files that also use "#"). Inline comments
+	//are only recognised using CommentStart
+	ExtraCommentStarts []string
+
+	//Treat indented lines following a property as a continuation of its value, joined to it with a newline
+	AllowContinuationLines bool
+
+	//Store properties named like servers[] as servers[0], servers[1]... so they can be retrieved with Values
+	AggregateArrayKeys bool
+
+	//What to do when a line contains bytes that are not valid UTF-8
+	InvalidUTF8 InvalidUTF8Mode
+
+	//End lines at a lone carriage return as well as at LF and CRLF, so that stray CRs never end up in names or values. Not
+	//supported by NewLazyIniConfig
+	NormalizeLineEndings bool
+
+	//Fail parsing if a section, property or value contains a control character other than tab
+	RejectControlCharacters bool
+
+	//Treat property names like port:int as the property port with a declared type of int (see IniConfig.DeclaredType)
+	//and fail parsing if a value cannot be converted to its declared type. Cannot be used with UseColonAssignment
+	TypeAnnotations bool
+
+	//If set, called with each line of the file (with its line number) before it is parsed. The returned ClassifiedLine
+	//can define a section or property, ignore the line or leave it to the parser. Not supported by NewLazyIniConfig
+	LineClassifier func(line string, lineNumber int) (ClassifiedLine, error)
+
+	//What to do with a section header that has an empty name ([]). Functions like Add that take a section name always
+	//treat an empty name as GLOBAL_SECTION
+	EmptySections EmptySectionMode
+
+	//The section that properties following an empty section header are stored in if EmptySections is EmptySectionRename
+	EmptySectionName string
+
+	//Allow ] in section names by escaping it with a backslash ([a\]b]) or enclosing the name in double quotes (["a]b"])
+	EscapedSectionNames bool
+
+	//Allow section names enclosed in double quotes (["My Section; strange"]) to contain any characters, including ] and
+	//the comment symbol, without enabling EscapedSectionNames for every header
+	QuotedSectionNames bool
+
+	//The name to store properties defined without a name (=value) under. If empty, such properties are a parse error
+	EmptyPropertyName string
+
+	//Allow the assignment symbol in property names by escaping it with a backslash (cn\=admin=x) or enclosing the name
+	//in double quotes ("cn=admin"=x)
+	EscapedPropertyNames bool
+
+	//If set, called with the new IniConfig once a file has been parsed successfully, so that derived properties can be
+	//added and relationships between properties checked. An error returned by the function is returned by the constructor
+	PostParse func(ic *IniConfig) error
+
+	//Unquoted values (e.g. "null", "~" or "none") that mean the property explicitly has no value (see
+	//IniConfig.ValueOrNil). Matched case-sensitively
+	NullLiterals []string
+
+	//Record the location of every section header, property name, value and comment (see IniConfig.Elements). Not
+	//supported by NewLazyIniConfig or ExtractSection
+	RecordPositions bool
+
+	//If set, content is only parsed if it has been signed with the matching Ed25519 private key, either in a signature
+	//comment on its last line (see SignContent) or with DetachedSignature. Not supported by NewLazyIniConfig or
+	//ExtractSection
+	SignatureKey ed25519.PublicKey
+
+	//An Ed25519 signature of the entire content, checked against SignatureKey instead of an embedded signature comment
+	DetachedSignature []byte
+
+	//Only parse content whose last line (before any signature comment) is a checksum footer matching the rest of the
+	//content (see AddChecksum). Not supported by NewLazyIniConfig or ExtractSection
+	VerifyChecksum bool
+
+	//Refuse to load a file whose permissions include any of these bits, e.g. 0077 to require that only the owner can
+	//access it or 0022 to reject files that others can write to. Zero disables the check, which is not made on Windows.
+	//Loading from a Source that is not a local file fails if this is set
+	ForbiddenPermissions os.FileMode
+
+	//The number of times a read that fails with a transient error (a timeout, a temporary condition or EIO, as reported
+	//by network filesystems) is retried before parsing fails
+	ReadRetries int
+
+	//How long to wait before retrying a read that failed (see ReadRetries)
+	ReadRetryDelay time.Duration
+
+	//Decompress content that starts with the magic number of gzip or bzip2 compressed data. Content from a file with a
+	//.gz or .bz2 extension must be compressed in that format. Not supported by NewLazyIniConfig
+	Decompress bool
+
+	//An unquoted value (e.g. "!unset") that removes the property instead of setting it, both from the file being parsed
+	//and from any IniConfig the file is merged into with Merge or LoadDir. Empty disables tombstone values
+	TombstoneValue string
+
+	//A prefix for section names (e.g. "-" for [-database]) that removes all properties set earlier in the section, both
+	//in the file being parsed and in any IniConfig the file is merged into. Properties following the header are added
+	//to the (now empty) section. Empty disables tombstone sections. Not supported by NewLazyIniConfig
+	TombstoneSectionPrefix string
+}
+
+//AccessOptions control how values are interpreted when they are accessed, so can be changed without parsing the file
+//again (see IniConfig.WithAccessOptions).
+type AccessOptions struct {
 	//Use Go's standard string-to-bool rules https://golang.org/pkg/strconv/#ParseBool
 	//If set to false, StrictBoolTrue and StrictBoolFalse must be set.
 	UseGoBoolRules bool
@@ -310,28 +1183,102 @@ type IniOptions struct {
 	//Only used if UseGoBoolRules = false
 	StrictBoolFalse string
 
+	//Other strings that are considered 'true' booleans (e.g. "yes", "on"), in addition to StrictBoolTrue.
+	//Only used if UseGoBoolRules = false
+	StrictBoolTrueSynonyms []string
+
+	//Other strings that are considered 'false' booleans (e.g. "no", "off"), in addition to StrictBoolFalse.
+	//Only used if UseGoBoolRules = false
+	StrictBoolFalseSynonyms []string
+
 	//Use case sensitive matching when in StrictBool mode.
 	//Only used if UseGoBoolRules = false
 	StrictBoolCaseSensitive bool
 
-	//Ignore lines that cannot be parsed as a section, property, comment or blank
-	IgnoreUnparseable bool
+	//Called when a value enclosed by EncryptedValuePrefix and EncryptedValueSuffix is accessed. Receives the text
+	//between the markers and returns the plaintext
+	ValueDecryptor func(section, property, raw string) (string, error)
 
-	//Allow comments on the same line as a section or property
-	AllowInlineComments bool
+	//Marks the start of an encrypted value. Only used if ValueDecryptor is set
+	EncryptedValuePrefix string
 
-	//The string which, when found before the comment symbol, escapes that symbol
-	//Only used when AllowInlineComments = true
-	CommentEscapePrefix string
+	//Marks the end of an encrypted value. Only used if ValueDecryptor is set
+	EncryptedValueSuffix string
 
-	//Remove leading and trailing quotes from property values before storing them
-	StripEnclosingQuotes bool
+	//Patterns (see path.Match) for the names of properties whose values should never appear in error messages or output
+	SensitiveProperties []string
 
-	//The symbols that are used as enclosing quotes
-	EnclosingQuoteSymbols []rune
+	//If set, called when a value is accessed to expand each %-macro (e.g. %U or %$(HOME)) it contains. The macro is passed
+	//without its % (U or $(HOME)). %% is replaced with a literal %
+	MacroExpander func(section, property, macro string) (string, error)
 
-    //Assignment uses colon not equals
-    UseColonAssignment bool
+	//Record which properties have been read, so Dump can report them. Reading becomes slightly slower.
+	TrackReads bool
+
+	//Replace %(name)s references in values with the value of the named property when the value is accessed
+	Interpolate bool
+
+	//The built-in variables (BuiltinSectionName, BuiltinHere, BuiltinHostname, BuiltinPID) that may be referenced
+	//when Interpolate is true
+	InterpolationBuiltins []string
+
+	//Used by ValueAsPercent. If true, values without a % symbol are fractions (0.75), otherwise they are percentages (75)
+	PercentWithoutSymbolIsFraction bool
+
+	//Accept floats written in exponent notation (e.g. 1e6)
+	AllowFloatExponent bool
+
+	//Accept the non-finite floats Inf, -Inf and NaN
+	AllowNonFiniteFloats bool
+
+	//Separates the levels of a section name (e.g. [database.replica]) when navigating between sections with
+	//IniSection.Parent, Child, Children and ValueAt. An empty string disables navigation
+	SectionSeparator string
+
+	//Replace ${layer:section.property} references in values with the value of the property in the IniConfig registered
+	//with AddLayer under that name when the value is accessed
+	LayerReferences bool
+
+	//How the names of numbered sections are formed from a base name (%s) and a number (%d) when they are gathered with
+	//SectionFamily, e.g. "%s-%d" for [worker-1], [worker-2]
+	SectionFamilyFormat string
+
+	//Prefixes (e.g. "no-" and "disable-") that form the name of a property negating a boolean property. If no-debug=true
+	//is set after debug=true (e.g. in a later drop-in file), ValueAsBool("section", "debug") returns false
+	NegationPrefixes []string
+}
+
+// InvalidUTF8Mode controls what happens when the parser encounters a line that is not valid UTF-8
+type InvalidUTF8Mode int
+
+const (
+	// Invalid bytes are kept in names and values unchanged
+	InvalidUTF8PassThrough InvalidUTF8Mode = iota
+	// Each run of invalid bytes is replaced with the Unicode replacement character U+FFFD
+	InvalidUTF8Replace
+	// Parsing fails with an error identifying the line
+	InvalidUTF8Reject
+)
+
+// EmptySectionMode controls what happens when the parser encounters a section header with an empty name ([])
+type EmptySectionMode int
+
+const (
+	// The properties following the header are added to the global section
+	EmptySectionGlobal EmptySectionMode = iota
+	// Parsing fails with an error identifying the line
+	EmptySectionReject
+	// The properties following the header are discarded
+	EmptySectionIgnore
+	// The properties following the header are added to the section named by EmptySectionName in IniOptions
+	EmptySectionRename
+)
+
+// Logger is implemented by types that can receive debug messages from the parser. It is intended to be easily adapted
+// to whichever logging framework your application uses.
+type Logger interface {
+	// Debugf records a message using the fmt.Sprintf style of template and arguments.
+	Debugf(template string, args ...interface{})
 }
 
 // NewIniConfigFromPath loads the INI file at the supplied path into a new IniConfig object.
@@ -375,6 +1322,19 @@ func NewIniConfigFromFileWithOptions(file *os.File, options *IniOptions) (*IniCo
 		return nil, errors.New("Nil file provided")
 	}
 
+	if options != nil {
+		if err := checkPermissions(file, options); err != nil {
+			return nil, err
+		}
+	}
+
+	return newIniConfigFromReader(file, file.Name(), options)
+}
+
+// newIniConfigFromReader validates the supplied options and parses the contents of the supplied reader
+// into a new IniConfig. The name identifies the source of the content (e.g. a path) in diagnostic output.
+func newIniConfigFromReader(r io.Reader, name string, options *IniOptions) (*IniConfig, error) {
+
 	if options == nil {
 		return nil, errors.New("Nil IniOptions provided")
 	}
@@ -386,18 +1346,31 @@ func NewIniConfigFromFileWithOptions(file *os.File, options *IniOptions) (*IniCo
 	ic := new(IniConfig)
 	ic.options = options
 	ic.sections = make(sectionPropertyMap)
+	ic.source = name
+
+	start := time.Now()
 
-	if err := ic.parse(file); err != nil {
+	err := ic.parse(r)
+
+	if err == nil {
+		err = ic.postParse()
+	}
+
+	if m := options.Metrics; m != nil {
+		m.ParseCompleted(name, time.Since(start), err)
+	}
+
+	if err != nil {
 		return nil, err
-	} else {
-		return ic, nil
 	}
 
+	return ic, nil
+
 }
 
-const rx_section = "\\[(.*)\\]"
-const rx_property = "([^=]*)=(.*)"
-const rx_colon_property = "([^=]*):(.*)"
+const rx_section = "^\\[([^\\]]*)\\](.*)$"
+const rx_property = "^([^=]*)=(.*)$"
+const rx_colon_property = "^([^=]*):(.*)$"
 
 // IniConfig provides access to configuration loaded in from an INI file. Functions exist to
 // check whether a section or property exists; to recover the raw string value of a property or
@@ -405,14 +1378,54 @@ const rx_colon_property = "([^=]*):(.*)"
 //
 // The various PropertyValueAsXXX methods are generally convenience functions over the builtin strconv.Parse functions.
 type IniConfig struct {
-	sections sectionPropertyMap
-	options  *IniOptions
+	sections  sectionPropertyMap
+	options   *IniOptions
+	sensitive map[string]map[string]bool
+	elements  []Element
+	warnings  []Warning
+	shadowed  []Shadowing
+	lazy      *sectionIndex
+
+	//Statistics gathered while parsing
+	parseStats Stats
+
+	//The name of the source currently or most recently parsed, the names of all sources values were parsed from
+	//and which properties have been read
+	source  string
+	sources []string
+	readsMu sync.Mutex
+	reads   map[string]map[string]bool
+
+	//Other configurations that values can refer to (see AddLayer)
+	layers map[string]*IniConfig
+
+	//Values used when a property has not been parsed or added (see SetDefault)
+	defaults sectionPropertyMap
+
+	//The types declared for properties with type annotations
+	types map[string]map[string]string
+
+	//Comments written after the values of properties (see SetInlineComment)
+	inlineComments map[string]map[string]string
+
+	//Properties and sections removed by tombstones, applied again when this IniConfig is merged into another
+	tombstones []tombstone
+
+	//The order in which sections and properties were first added and the sorted names of the properties in each section
+	sectionOrder  []string
+	propertyOrder map[string][]string
+	sortedMu      sync.Mutex
+	sorted        map[string][]string
+
+	//The index to start from when looking for the next unused element of each array property (see AggregateArrayKeys)
+	//in each section, so appending elements does not rescan the earlier ones
+	arrayNext map[string]map[string]int
 }
 
-//SectionExists returns true if a section with the supplied name was found and parsed.
+//SectionExists returns true if a section with the supplied name was found and parsed (or has default values, see SetDefault).
 func (ic *IniConfig) SectionExists(sectionName string) bool {
 
-	return ic.findSection(sectionName) != nil
+	return ic.findSection(sectionName) != nil || ic.lookupSectionIn(ic.defaults, sectionName) != nil
 }
 
 //Section returns a view on the IniConfig with the same methods but constrained to a single section
@@ -426,20 +1439,52 @@ func (ic *IniConfig) Section(sectionName string) (*IniSection, error) {
 		return is, nil
 	} else {
 
-		return nil, errorf("Section %s does not exist", sectionName)
+		ic.lookupMissed(sectionName, "")
+		return nil, wrapf(ErrSectionNotFound, nil, "Section %s does not exist", sectionName)
+
+	}
+
+}
+
+//SectionNames returns the names of all sections containing at least one property, with GLOBAL_SECTION first (if it contains
+//any properties) followed by the named sections in the order they were first parsed or added.
+func (ic *IniConfig) SectionNames() []string {
 
+	if err := ic.loadAllSections(); err != nil {
+		ic.debugf("Unable to load all sections: %s", err.Error())
 	}
 
+	return ic.writeOrder()
 }
 
-//PropertyExists returns true if the section exists and it contains a property with the requested name
+//PropertyExists returns true if the section exists and it contains a property with the requested name (or the property
+//has a default value, see SetDefault)
 func (ic *IniConfig) PropertyExists(sectionName, propertyName string) bool {
-	propertyName = ic.normalise(propertyName)
+
+	if ic.stored(sectionName, propertyName) {
+		return true
+	}
+
+	_, found := ic.lookupDefault(sectionName, propertyName)
+
+	return found
+}
+
+// stored returns true if the property was parsed or added (ignoring default values).
+func (ic *IniConfig) stored(sectionName, propertyName string) bool {
+
+	_, found := ic.storedValue(sectionName, propertyName)
+
+	return found
+}
+
+// storedValue returns the parsed or added value of a property (ignoring default values).
+func (ic *IniConfig) storedValue(sectionName, propertyName string) (propertyValue, bool) {
 
 	if foundSection := ic.findSection(sectionName); foundSection == nil {
-		return false
+		return propertyValue{}, false
 	} else {
-		return foundSection[propertyName] != nil
+		return ic.lookupProperty(foundSection, propertyName)
 	}
 
 }
@@ -449,17 +1494,67 @@ func (ic *IniConfig) PropertyExists(sectionName, propertyName string) bool {
 // Returns an error if the section or property does not exist.
 func (ic *IniConfig) Value(sectionName, propertyName string) (string, error) {
 
+	if err := ic.loadSection(sectionName); err != nil {
+		return "", err
+	}
+
 	section := ic.findSection(sectionName)
-	propertyName = ic.normalise(propertyName)
 
-	if section == nil {
-		return "", errorf("No such section %s", sectionName)
+	value, found := ic.lookupProperty(section, propertyName)
+
+	if !found {
+		value, found = ic.lookupDefault(sectionName, propertyName)
+	}
+
+	if !found {
+		ic.lookupMissed(sectionName, propertyName)
 	}
 
-	if value := section[propertyName]; value == nil {
-		return "",  errorf("No such property [%s].%s", sectionName, propertyName)
+	if !found && !ic.SectionExists(sectionName) {
+		return "", wrapf(ErrSectionNotFound, nil, "No such section %s", sectionName)
+	}
+
+	if !found {
+		return "",  wrapf(ErrPropertyNotFound, nil, "No such property [%s].%s", sectionName, ic.normalise(propertyName))
 	} else {
-		return value.String(), nil
+		if ic.options.TrackReads || ic.options.ValueDecryptor != nil || ic.options.MacroExpander != nil {
+			propertyName = ic.normalise(propertyName)
+		}
+
+		if ic.options.TrackReads {
+			ic.markRead(sectionName, propertyName)
+		}
+
+		v := value.String()
+
+		if ic.options.Interpolate {
+
+			var err error
+
+			if v, err = ic.interpolate(sectionName, v, 0); err != nil {
+				return "", err
+			}
+		}
+
+		if ic.options.LayerReferences {
+
+			var err error
+
+			if v, err = ic.resolveLayerReferences(v); err != nil {
+				return "", err
+			}
+		}
+
+		if ic.options.MacroExpander != nil {
+
+			var err error
+
+			if v, err = ic.expandMacros(sectionName, propertyName, v); err != nil {
+				return "", err
+			}
+		}
+
+		return ic.decrypt(sectionName, propertyName, v)
 	}
 
 }
@@ -476,7 +1571,8 @@ func (ic *IniConfig) ValueOrZero(sectionName, propertyName string) (string) {
 
 }
 
-// ValueAsFloat64 attempts to convert the specified property to a float64.
+// ValueAsFloat64 attempts to convert the specified property to a float64. Whether values in exponent notation (1e6)
+// or non-finite values (Inf, NaN) are accepted is controlled by AllowFloatExponent and AllowNonFiniteFloats in the IniOptions.
 //
 // Returns an error if the section or property does not exist or if the value could not be converted to a float64
 func (ic *IniConfig) ValueAsFloat64(sectionName, propertyName string) (float64, error) {
@@ -492,11 +1588,11 @@ func (ic *IniConfig) ValueAsFloat64(sectionName, propertyName string) (float64,
 		return 0, err
 	}
 
-	if v, err := strconv.ParseFloat(sv, 64); err == nil {
+	if v, err := ic.parseFloat(origSectionName, origPropName, sv, 64); err == nil {
 		return v, nil
 	} else {
 
-		return 0, errorf("Unable to interpret [%s].%s (%s) as a float64.", origSectionName, origPropName, sv)
+		return 0, err
 
 	}
 
@@ -535,7 +1631,7 @@ func (ic *IniConfig) ValueAsInt64(sectionName, propertyName string) (int64, erro
 		return v, nil
 	} else {
 
-		return 0, errorf("Unable to interpret [%s].%s (%s) as an int64.", origSectionName, origPropName, sv)
+		return 0, ic.conversionFailed(origSectionName, origPropName, wrapf(ErrConversion, ic.conversionCause(origSectionName, origPropName, err), "Unable to interpret [%s].%s (%s) as an int64.", origSectionName, origPropName, ic.redact(origSectionName, origPropName, sv)))
 
 	}
 
@@ -573,7 +1669,7 @@ func (ic *IniConfig) ValueAsUint64(sectionName, propertyName string) (uint64, er
 		return v, nil
 	} else {
 
-		return 0, errorf("Unable to interpret [%s].%s (%s) as a uint64.", origSectionName, origPropName, sv)
+		return 0, ic.conversionFailed(origSectionName, origPropName, wrapf(ErrConversion, ic.conversionCause(origSectionName, origPropName, err), "Unable to interpret [%s].%s (%s) as a uint64.", origSectionName, origPropName, ic.redact(origSectionName, origPropName, sv)))
 
 	}
 
@@ -597,10 +1693,33 @@ func (ic *IniConfig) ValueOrZeroAsUint64(sectionName, propertyName string) (uint
 // Behaviour is affected by the IniOptions  supplied when creating this IniConfig. If the UseGoBoolRules field is set
 // to true, conversion behaviour is as defined by strconv.ParseBool
 //
-// If UseGoBoolRules is set to false, the property value must be equal to the StrictBoolTrue field to be considered 'true' or
-// match StrictBoolFalse to be considered 'false'. This matching can be made case insensitive by setting StrictBoolCaseSensitive to false
+// If UseGoBoolRules is set to false, the property value must be equal to the StrictBoolTrue field (or one of
+// StrictBoolTrueSynonyms) to be considered 'true' or match StrictBoolFalse (or one of StrictBoolFalseSynonyms) to be
+// considered 'false'. This matching can be made case insensitive by setting StrictBoolCaseSensitive to false. An error
+// wrapping ErrInvalidOptions is returned if there are no values for true or for false.
+//
+// If NegationPrefixes is set in the IniOptions, a property negating this one (e.g. no-debug for debug) that was set
+// after it, or that is set when this property is not, decides the value instead: no-debug=true makes debug false.
 func (ic *IniConfig) ValueAsBool(sectionName, propertyName string) (bool, error) {
 
+	if negation, found := ic.negation(sectionName, propertyName); found {
+
+		v, err := ic.valueAsBool(sectionName, negation)
+
+		if err != nil {
+			return false, err
+		}
+
+		return !v, nil
+	}
+
+	return ic.valueAsBool(sectionName, propertyName)
+}
+
+// valueAsBool converts the specified property to a bool, ignoring any property negating it (see NegationPrefixes in
+// IniOptions).
+func (ic *IniConfig) valueAsBool(sectionName, propertyName string) (bool, error) {
+
 	sv, err := ic.Value(sectionName, propertyName)
 
 	origSv := sv
@@ -618,35 +1737,58 @@ func (ic *IniConfig) ValueAsBool(sectionName, propertyName string) (bool, error)
 		if bv, err := strconv.ParseBool(sv); err == nil {
 			return bv, nil
 		} else {
-			return false, errorf("Unable to interpret [%s].%s as a Go bool.", sectionName, propertyName)
+			return false, ic.conversionFailed(sectionName, propertyName, wrapf(ErrConversion, ic.conversionCause(sectionName, propertyName, err), "Unable to interpret [%s].%s as a Go bool.", sectionName, propertyName))
 		}
 
 	}
 
 	//Require that specific values for true or false be matched
-	strictTrue := options.StrictBoolTrue
-	strictFalse := options.StrictBoolFalse
+	trueValues, falseValues := options.strictBoolValues()
 
-	if !options.StrictBoolCaseSensitive {
-		strictTrue = strings.ToUpper(strictTrue)
-		strictFalse = strings.ToUpper(strictFalse)
-		sv = strings.ToUpper(sv)
+	if len(trueValues) == 0 || len(falseValues) == 0 {
+		return false, wrapf(ErrInvalidOptions, nil, "Unable to interpret [%s].%s as a bool: UseGoBoolRules is false but no StrictBoolTrue or StrictBoolFalse values are set", sectionName, propertyName)
 	}
 
-
-	if sv == strictTrue {
+	if options.matchesStrictBool(sv, trueValues) {
 		return true, nil
-	} else if sv == strictFalse {
+	} else if options.matchesStrictBool(sv, falseValues) {
 		return false, nil
 	} else {
 
-		return false, errorf("Value of [%s].%s (%s) could not be matched to %s or %s", sectionName, propertyName, origSv, options.StrictBoolTrue, options.StrictBoolFalse)
+		return false, ic.conversionFailed(sectionName, propertyName, wrapf(ErrConversion, nil, "Value of [%s].%s (%s) could not be matched to %s or %s", sectionName, propertyName, ic.redact(sectionName, propertyName, origSv), strings.Join(trueValues, "/"), strings.Join(falseValues, "/")))
+
+	}
+}
+
+// strictBoolValues returns the non-empty strings that represent true and false when UseGoBoolRules is false.
+func (ao *AccessOptions) strictBoolValues() ([]string, []string) {
 
+	nonEmpty := func(first string, others []string) []string {
+
+		var values []string
+
+		for _, v := range append([]string{first}, others...) {
+			if v != "" {
+				values = append(values, v)
+			}
+		}
+
+		return values
 	}
 
+	return nonEmpty(ao.StrictBoolTrue, ao.StrictBoolTrueSynonyms), nonEmpty(ao.StrictBoolFalse, ao.StrictBoolFalseSynonyms)
+}
+
+// matchesStrictBool returns true if the value matches one of the supplied strict bool values.
+func (ao *AccessOptions) matchesStrictBool(value string, values []string) bool {
 
+	for _, v := range values {
+		if value == v || (!ao.StrictBoolCaseSensitive && strings.EqualFold(value, v)) {
+			return true
+		}
+	}
 
-	return false, nil
+	return false
 }
 
 // ValueOrZeroAsBool returns the value of the specified property in the specified section as a bool or
@@ -661,8 +1803,22 @@ func (ic *IniConfig) ValueOrZeroAsBool(sectionName, propertyName string) (bool)
 
 }
 
-// Add stores a property in the named section. If the property already exists, its value is overwritten.
+// Add stores a property in the named section. If the property already exists, its value is overwritten. Values added
+// take precedence over values parsed from a file, which take precedence over default values (see SetDefault).
 func (ic *IniConfig) Add(section, propertyName string, value string) {
+	ic.addFromLine(section, propertyName, value, 0)
+}
+
+// addFromLine stores a property, recording the line of the source it was parsed from (or 0 if it was added at runtime).
+func (ic *IniConfig) addFromLine(section, propertyName string, value string, line int) {
+	ic.storeFromLine(section, propertyName, newNilableString(value), line)
+}
+
+// storeFromLine stores a property whose value may be unset (see NullLiterals in IniOptions), recording the line of the
+// source it was parsed from (or 0 if it was added at runtime).
+func (ic *IniConfig) storeFromLine(section, propertyName string, value nilableString, line int) {
+
+	ic.loadSection(section)
 
 	section = ic.normalise(section)
 	propertyName = ic.normalise(propertyName)
@@ -670,17 +1826,160 @@ func (ic *IniConfig) Add(section, propertyName string, value string) {
 	storedSection := ic.sections[section]
 
 	if storedSection == nil {
-		storedSection = make(map[string]*nilableString)
+		storedSection = make(map[string]propertyValue)
 		ic.sections[section] = storedSection
+		ic.sectionOrder = append(ic.sectionOrder, section)
 	}
 
-	storedSection[propertyName] = newNilableString(value)
+	propertyName = ic.arrayElementName(section, storedSection, propertyName)
 
+	pv := propertyValue{nilableString: value, line: int32(line)}
+
+	if line > 0 {
+		pv.source = ic.sourceIndex(ic.source)
+	}
+
+	if existing, found := storedSection[propertyName]; found {
+		pv.overrides = existing.line
+	} else {
+
+		if ic.propertyOrder == nil {
+			ic.propertyOrder = make(map[string][]string)
+		}
+
+		ic.propertyOrder[section] = append(ic.propertyOrder[section], propertyName)
+		ic.invalidateSorted(section)
+	}
+
+	storedSection[propertyName] = pv
 }
 
-//parse scans the supplied file line by line according to the rules defined in the IniOptions
-func (ic *IniConfig) parse(cf *os.File) error {
-	s := bufio.NewScanner(cf)
+// sourceIndex returns the position of the named source in the list of sources values have been parsed from, adding it
+// if necessary.
+func (ic *IniConfig) sourceIndex(name string) uint16 {
+
+	for i := len(ic.sources) - 1; i >= 0; i-- {
+		if ic.sources[i] == name {
+			return uint16(i)
+		}
+	}
+
+	ic.sources = append(ic.sources, name)
+
+	return uint16(len(ic.sources) - 1)
+}
+
+// Delete removes a property from the named section, returning true if the property existed. If the section contains no
+// more properties, the section is also removed.
+func (ic *IniConfig) Delete(section, propertyName string) bool {
+
+	ic.loadSection(section)
+
+	section = ic.normalise(section)
+	propertyName = ic.normalise(propertyName)
+
+	storedSection := ic.sections[section]
+
+	if _, found := storedSection[propertyName]; !found {
+		return false
+	}
+
+	delete(storedSection, propertyName)
+	delete(ic.inlineComments[section], propertyName)
+	ic.propertyOrder[section] = removeString(ic.propertyOrder[section], propertyName)
+	ic.invalidateSorted(section)
+
+	//An earlier array element may now be unused
+	delete(ic.arrayNext, section)
+
+	if len(storedSection) == 0 {
+		delete(ic.sections, section)
+		delete(ic.propertyOrder, section)
+		ic.sectionOrder = removeString(ic.sectionOrder, section)
+	}
+
+	return true
+}
+
+// removeString returns the supplied slice without the first instance of s
+func removeString(ss []string, s string) []string {
+
+	for i, candidate := range ss {
+		if candidate == s {
+			return append(ss[:i], ss[i+1:]...)
+		}
+	}
+
+	return ss
+}
+
+//parse scans the supplied reader line by line according to the rules defined in the IniOptions
+func (ic *IniConfig) parse(r io.Reader) error {
+
+	if ic.options.ReadRetries > 0 {
+		r = &retryingReader{r: r, retries: ic.options.ReadRetries, delay: ic.options.ReadRetryDelay, debugf: ic.debugf}
+	}
+
+	if ic.options.Decompress {
+
+		decompressed, err := ic.decompressed(r)
+
+		if err != nil {
+			return err
+		}
+
+		r = decompressed
+	}
+
+	if ic.options.SignatureKey != nil {
+
+		verified, err := ic.verifiedContent(r)
+
+		if err != nil {
+			return err
+		}
+
+		r = verified
+	}
+
+	if ic.options.VerifyChecksum {
+
+		checked, err := ic.checkedContent(r)
+
+		if err != nil {
+			return err
+		}
+
+		r = checked
+	}
+
+	return ic.parseFromLine(r, 0)
+}
+
+//parseFromLine parses the supplied reader, numbering lines as if the first line read follows line firstLine
+func (ic *IniConfig) parseFromLine(r io.Reader, firstLine int) error {
+	cr := &countingReader{r: r}
+	s := bufio.NewScanner(cr)
+
+	split := bufio.ScanLines
+
+	if ic.options.NormalizeLineEndings {
+		split = scanLinesNormalised
+	}
+
+	//The offset of each line is needed to record the positions of elements
+	offsets := new(lineOffsets)
+
+	if ic.options.RecordPositions {
+		split = offsets.track(split)
+	}
+
+	s.Split(split)
+
+	defer func() {
+		ic.parseStats.BytesParsed += cr.n
+	}()
+
 	section := GLOBAL_SECTION
 
 	options := ic.options
@@ -693,44 +1992,139 @@ func (ic *IniConfig) parse(cf *os.File) error {
 	    propRx = regexp.MustCompile(rx_property)
     }
 
-	lineNumber := 0
+	lineNumber := firstLine
+
+	//Properties from qualified sections, grouped by the index of their tag in ActiveTags
+	tagged := make([][]taggedProperty, len(options.ActiveTags))
+	tagIndex := untaggedSection
+
+	//Generated files often repeat the same values many times, so share a single copy of each
+	interned := make(internTable)
+
+	//The property that indented lines following it continue, if AllowContinuationLines is set
+	var continuing *continuation
+
+	//Properties with type annotations, checked once parsing is complete
+	var annotated [][2]string
 
 	for s.Scan() {
 
 		lineNumber++
 
-		l := strings.TrimSpace(s.Text())
+		if raw := len(s.Bytes()); raw > ic.parseStats.LongestLine {
+			ic.parseStats.LongestLine = raw
+		}
+
+		raw := s.Text()
+
+		var lr *lineRecorder
+
+		if options.RecordPositions {
+			lr = &lineRecorder{ic, raw, offsets.current, lineNumber}
+		}
+
+		if options.InvalidUTF8 != InvalidUTF8PassThrough && !utf8.ValidString(raw) {
+
+			if options.InvalidUTF8 == InvalidUTF8Reject {
+				return parseErrorf(lineNumber, "Invalid UTF-8 (forbidden in IniOptions)")
+			}
+
+			ic.debugf("Replacing invalid UTF-8 on line %d", lineNumber)
+			raw = strings.ToValidUTF8(raw, string(utf8.RuneError))
+		}
+
+		classified, err := ic.classifyLine(raw, lineNumber)
+
+		if err != nil {
+			return err
+		}
+
+		switch classified.Kind {
+		case LineIgnored:
+			continue
+		case LineSection:
+			if err := ic.checkEmptySection(classified.Name, lineNumber); err != nil {
+				return err
+			}
+
+			section, tagIndex = ic.resolveSectionTag(ic.tombstoneSection(classified.Name, lineNumber))
+			continuing = nil
+			continue
+		}
+
+		//Properties defined by the LineClassifier skip comment, continuation and section detection
+		claimed := classified.Kind == LineProperty
+
+		l := strings.TrimSpace(raw)
 		lineLength := len(l)
 
-		if lineLength == 0 && !options.TolerateBlankLines {
-			return errorf("Blank line on line %d (forbidden in IniOptions)", lineNumber)
-		} else if lineLength == 0 || strings.HasPrefix(l, options.CommentStart) {
+		if !claimed && lineLength == 0 && !options.TolerateBlankLines {
+			return parseErrorf(lineNumber, "Blank line (forbidden in IniOptions)")
+		} else if !claimed && (lineLength == 0 || ic.isComment(l)) {
 			//Blank line or comment - ignore
+			if lineLength == 0 {
+				continuing = nil
+			} else {
+				lr.comment(section)
+			}
+
 			continue
 		}
 
-		l = ic.stripInlineComments(l)
+		if options.RejectControlCharacters {
+			if err := checkControlCharacters(l, lineNumber); err != nil {
+				return err
+			}
+		}
+
+		if !claimed {
+			l = ic.stripInlineComments(l)
+		}
 
-		if sectionRx.MatchString(l) {
-			matches := sectionRx.FindStringSubmatch(l)
+		if !claimed && continuing != nil && options.AllowContinuationLines && isIndented(raw) {
+			ic.continueValue(continuing, tagged, l)
 
-			if len(matches) != 2 {
-				return errorf("Unparseable section line in file at line %d", lineNumber)
+			if continuing.tagIndex != inactiveSection {
+				lr.continuation(continuing.section, continuing.name)
 			}
 
-			section = matches[1]
+			continue
+		}
 
-		} else if propRx.MatchString(l) {
+		continuing = nil
 
-			if section == GLOBAL_SECTION && !options.AllowGlobalSection {
-				return errorf("Property on line %d is outside of a named section (forbidden in IniOptions)", lineNumber)
+		if matches := ic.matchSection(sectionRx, l); !claimed && matches != nil {
+
+			if len(matches) != 3 {
+				return parseErrorf(lineNumber, "Unparseable section line in file")
+			}
+
+			if trailing := strings.TrimSpace(matches[2]); trailing != "" && !ic.isComment(trailing) {
+				return parseErrorf(lineNumber, "Unexpected content after section header (%s)", trailing)
 			}
 
+			if err := ic.checkEmptySection(matches[1], lineNumber); err != nil {
+				return err
+			}
+
+			section, tagIndex = ic.resolveSectionTag(ic.tombstoneSection(matches[1], lineNumber))
+			lr.section(section)
+
+		} else if matches := ic.matchProperty(propRx, l, classified); matches != nil {
 
-			matches := propRx.FindStringSubmatch(l)
+			if tagIndex == inactiveSection {
+				//Property belongs to a section qualified with a tag that is not active
+				ic.debugf("Discarding property on line %d (section is qualified with an inactive tag)", lineNumber)
+				continuing = &continuation{tagIndex: inactiveSection}
+				continue
+			}
+
+			if section == GLOBAL_SECTION && !options.AllowGlobalSection {
+				return parseErrorf(lineNumber, "Property is outside of a named section (forbidden in IniOptions)")
+			}
 
 			if len(matches) != 3{
-				return errorf("Unparseable property line in file at line %d", lineNumber)
+				return parseErrorf(lineNumber, "Unparseable property line in file")
 			}
 
 			key := matches[1]
@@ -741,23 +2135,222 @@ func (ic *IniConfig) parse(cf *os.File) error {
 				value = strings.TrimSpace(value)
 			}
 
-			value = ic.stripQuotes(value)
+			declared := ""
+
+			if options.TypeAnnotations {
+				key, declared = splitTypeAnnotation(key)
+			}
+
+			if strings.TrimSpace(key) == "" {
+
+				if options.EmptyPropertyName == "" {
+					return &ParseError{Line: lineNumber, Message: "Property has no name"}
+				}
+
+				ic.debugf("Storing property with no name on line %d as %s", lineNumber, options.EmptyPropertyName)
+				key = options.EmptyPropertyName
+			}
+
+			if !claimed {
+				lr.property(section, key)
+			}
+
+			quoted := ic.stripQuotes(value) != value
+
+			if !quoted {
+				if err := ic.checkCommentInValue(lineNumber, section, key, value); err != nil {
+					return err
+				}
+			}
+
+			null := !quoted && ic.isNullLiteral(value)
+
+			key = interned.intern(key)
+			value = interned.intern(ic.stripQuotes(value))
+
+			if !quoted && ic.isTombstone(value) {
+				ic.unsetProperty(section, key, lineNumber)
+				continue
+			}
 
 			if len(value) > 0 || !options.DiscardPropertiesWithNoValue {
-				ic.Add(section, key, value)
+
+				if previous, found := ic.storedValue(section, key); found {
+					ic.recordShadowing(section, key, value, lineNumber, previous)
+					ic.debugf("Property [%s].%s on line %d overrides an earlier definition", section, key, lineNumber)
+					ic.warn(ShadowedProperty, lineNumber, section, key, "Property [%s].%s overrides an earlier definition", section, key)
+					ic.parseStats.DuplicateProperties++
+				}
+
+				ic.checkSuspiciousValue(lineNumber, section, key, value)
+
+				if tagIndex >= 0 {
+					tagged[tagIndex] = append(tagged[tagIndex], taggedProperty{section, key, value, lineNumber, null})
+				} else {
+					ic.storeFromLine(section, key, parsedValue(value, null), lineNumber)
+				}
+
+				if declared != "" {
+					ic.declareType(section, key, declared)
+					annotated = append(annotated, [2]string{section, key})
+				}
+			} else {
+				ic.debugf("Discarding property [%s].%s on line %d (no value)", section, key, lineNumber)
 			}
 
+			continuing = &continuation{section, key, tagIndex, lineNumber}
+
 		} else {
 
 			if !options.IgnoreUnparseable {
-				return errorf("Unparseable line in file at line %d", lineNumber)
+				return parseErrorf(lineNumber, "Unparseable line in file")
 			}
+
+			ic.debugf("Ignoring unparseable line %d", lineNumber)
 		}
 	}
 
+	if err := s.Err(); err != nil {
+		return errorf("Unable to read %s after line %d: %w", ic.sourceName(), lineNumber, err)
+	}
+
+	if options.FailOnWarnings && len(ic.warnings) > 0 {
+		return parseErrorf(ic.warnings[0].Line, "Warning treated as error (FailOnWarnings set in IniOptions): %s", ic.warnings[0].Message)
+	}
+
+	//Merge properties from active qualified sections in tag order
+	for _, properties := range tagged {
+		for _, p := range properties {
+			ic.storeFromLine(p.section, p.name, parsedValue(p.value, p.null), p.line)
+		}
+	}
+
+	return ic.checkDeclaredTypes(annotated)
+}
+
+// postParse calls the PostParse function in the IniOptions (if set).
+func (ic *IniConfig) postParse() error {
+
+	if ic.options.PostParse == nil {
+		return nil
+	}
+
+	if err := ic.options.PostParse(ic); err != nil {
+		return errorf("PostParse function failed: %w", err)
+	}
+
+	return nil
+}
+
+// matchSection returns the whole line, the section name and any content after the closing bracket if the line is a
+// section header, or nil if the line is not a section header or sections are disabled by NoSections in the IniOptions.
+func (ic *IniConfig) matchSection(sectionRx *regexp.Regexp, line string) []string {
+
+	if ic.options.NoSections {
+		return nil
+	}
+
+	if ic.options.EscapedSectionNames || ic.options.QuotedSectionNames && quotedSectionEnd(line) >= 0 {
+		return matchEscapedSection(line)
+	}
+
+	matches := sectionRx.FindStringSubmatch(line)
+
+	if matches == nil {
+		return nil
+	}
+
+	//Names may contain ] (e.g. EditorConfig globs like [*.[ch]]), so the header ends at the first ] that is followed
+	//only by whitespace or a comment. If there is no such ], the trailing content is reported by the caller.
+	for i := len(matches[1]) + 1; i < len(line); i++ {
+
+		if line[i] != ']' {
+			continue
+		}
+
+		if trailing := strings.TrimSpace(line[i+1:]); trailing == "" || ic.isComment(trailing) {
+			return []string{line, line[1:i], line[i+1:]}
+		}
+	}
+
+	return matches
+}
+
+const untaggedSection = -1
+const inactiveSection = -2
+
+// A property found in a section qualified with an active tag, held until parsing is complete so
+// it can be merged in tag order.
+type taggedProperty struct {
+	section string
+	name    string
+	value   string
+	line    int
+	null    bool
+}
+
+// resolveSectionTag splits a qualified section name like server:linux into its base name and the
+// index of its tag in ActiveTags. Returns untaggedSection if the name is not qualified (or ActiveTags is empty)
+// and inactiveSection if the tag is not active or the name is empty and EmptySections is EmptySectionIgnore.
+func (ic *IniConfig) resolveSectionTag(name string) (string, int) {
+
+	base, tagIndex := ic.splitSectionTag(name)
+
+	if strings.TrimSpace(base) == "" {
+
+		switch ic.options.EmptySections {
+		case EmptySectionIgnore:
+			return base, inactiveSection
+		case EmptySectionRename:
+			return ic.options.EmptySectionName, tagIndex
+		}
+	}
+
+	return base, tagIndex
+}
+
+// checkEmptySection returns an error if the section header on the specified line has an empty name and EmptySections
+// in the IniOptions is EmptySectionReject.
+func (ic *IniConfig) checkEmptySection(name string, lineNumber int) error {
+
+	if ic.options.EmptySections != EmptySectionReject {
+		return nil
+	}
+
+	if base, _ := ic.splitSectionTag(name); strings.TrimSpace(base) == "" {
+		return parseErrorf(lineNumber, "Empty section name (forbidden in IniOptions)")
+	}
+
 	return nil
 }
 
+// splitSectionTag separates the base name of a qualified section from its tag (see resolveSectionTag).
+func (ic *IniConfig) splitSectionTag(name string) (string, int) {
+
+	tags := ic.options.ActiveTags
+
+	if len(tags) == 0 {
+		return name, untaggedSection
+	}
+
+	sep := strings.LastIndex(name, ":")
+
+	if sep < 0 {
+		return name, untaggedSection
+	}
+
+	base := name[:sep]
+	tag := name[sep+1:]
+
+	for i, t := range tags {
+		if t == tag {
+			return base, i
+		}
+	}
+
+	return base, inactiveSection
+}
+
 func (ic *IniConfig) stripQuotes(value string) string {
 
 	options := ic.options
@@ -782,6 +2375,24 @@ func (ic *IniConfig) stripQuotes(value string) string {
 	return value
 }
 
+// isComment returns true if the (trimmed) line starts with CommentStart or one of the ExtraCommentStarts.
+func (ic *IniConfig) isComment(line string) bool {
+
+	options := ic.options
+
+	if strings.HasPrefix(line, options.CommentStart) {
+		return true
+	}
+
+	for _, cs := range options.ExtraCommentStarts {
+		if cs != "" && strings.HasPrefix(line, cs) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (ic *IniConfig) stripInlineComments(line string) string {
 
 	options := ic.options
@@ -790,6 +2401,14 @@ func (ic *IniConfig) stripInlineComments(line string) string {
 		return line
 	}
 
+	if options.QuotedSectionNames {
+
+		//The quoted name of a section header is taken verbatim, so only look for a comment after it
+		if end := quotedSectionEnd(line); end >= 0 {
+			return line[:end] + ic.stripInlineComments(line[end:])
+		}
+	}
+
 	ph := "[ESC_PH?]"
 	escapeSeq := options.CommentEscapePrefix + options.CommentStart
 
@@ -804,10 +2423,108 @@ func (ic *IniConfig) stripInlineComments(line string) string {
 }
 
 
-func (ic *IniConfig) findSection(sectionName string) map[string]*nilableString {
+// MarkSensitive records that the value of the specified property must be redacted in errors and output,
+// regardless of whether its name matches one of the SensitiveProperties patterns.
+func (ic *IniConfig) MarkSensitive(sectionName, propertyName string) {
+
 	sectionName = ic.normalise(sectionName)
+	propertyName = ic.normalise(propertyName)
+
+	if ic.sensitive == nil {
+		ic.sensitive = make(map[string]map[string]bool)
+	}
+
+	if ic.sensitive[sectionName] == nil {
+		ic.sensitive[sectionName] = make(map[string]bool)
+	}
 
-	return ic.sections[sectionName]
+	ic.sensitive[sectionName][propertyName] = true
+}
+
+// IsSensitive returns true if the specified property has been marked as sensitive or its name matches one of the
+// SensitiveProperties patterns in the IniOptions.
+func (ic *IniConfig) IsSensitive(sectionName, propertyName string) bool {
+
+	propertyName = ic.normalise(propertyName)
+
+	if ic.sensitive[ic.normalise(sectionName)][propertyName] {
+		return true
+	}
+
+	for _, pattern := range ic.options.SensitiveProperties {
+
+		if matched, _ := path.Match(ic.normalise(pattern), propertyName); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The text used in place of the values of sensitive properties
+const redacted = "****"
+
+// redact returns the supplied value, or a placeholder if the property is sensitive.
+func (ic *IniConfig) redact(sectionName, propertyName, value string) string {
+
+	if ic.IsSensitive(sectionName, propertyName) {
+		return redacted
+	}
+
+	return value
+}
+
+// conversionCause returns the cause to wrap in an error describing a failure to convert the value of the specified
+// property. If the property is sensitive, a *strconv.NumError is copied with its Num redacted and any other cause, whose
+// text may include the value, is dropped.
+func (ic *IniConfig) conversionCause(sectionName, propertyName string, err error) error {
+
+	if !ic.IsSensitive(sectionName, propertyName) {
+		return err
+	}
+
+	var ne *strconv.NumError
+
+	if errors.As(err, &ne) {
+		return &strconv.NumError{Func: ne.Func, Num: redacted, Err: ne.Err}
+	}
+
+	return nil
+}
+
+// decrypt passes values enclosed in the encrypted value markers to the ValueDecryptor (if one is set). Other values
+// are returned unchanged.
+func (ic *IniConfig) decrypt(sectionName, propertyName, value string) (string, error) {
+
+	options := ic.options
+
+	if options.ValueDecryptor == nil {
+		return value, nil
+	}
+
+	prefix := options.EncryptedValuePrefix
+	suffix := options.EncryptedValueSuffix
+
+	if len(value) < len(prefix)+len(suffix) || !strings.HasPrefix(value, prefix) || !strings.HasSuffix(value, suffix) {
+		return value, nil
+	}
+
+	raw := value[len(prefix) : len(value)-len(suffix)]
+
+	if plain, err := options.ValueDecryptor(sectionName, propertyName, raw); err != nil {
+		return "", errorf("Unable to decrypt [%s].%s: %w", sectionName, propertyName, err)
+	} else {
+		return plain, nil
+	}
+}
+
+func (ic *IniConfig) findSection(sectionName string) map[string]propertyValue {
+
+	if err := ic.loadSection(sectionName); err != nil {
+		ic.debugf("Unable to load section %s: %s", sectionName, err.Error())
+	}
+
+	return ic.lookupSection(sectionName)
 }
 
 
@@ -820,8 +2537,14 @@ func (ic *IniConfig) normalise(s string) string {
 	}
 }
 
-func errorf(template string, args ...interface{}) error {
-	m := fmt.Sprintf(template, args...)
+// debugf passes a message to the Logger in the IniOptions, if one has been set.
+func (ic *IniConfig) debugf(template string, args ...interface{}) {
 
-	return errors.New(m)
+	if l := ic.options.Logger; l != nil {
+		l.Debugf(template, args...)
+	}
+}
+
+func errorf(template string, args ...interface{}) error {
+	return fmt.Errorf(template, args...)
 }
@@ -1,9 +1,12 @@
 package inifile
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -573,6 +576,20 @@ func TestBooleanHandling(t *testing.T) {
 		t.Errorf("Expected bool conversion to fail")
 	}
 
+	options.StrictBoolTrueSynonyms = []string{"1"}
+	options.StrictBoolFalseSynonyms = []string{"0", "F"}
+
+	expectBool(t, ic, "Boolean", "value2", true) //1
+	expectBool(t, ic, "Boolean", "value5", false) //0
+	expectBool(t, ic, "Boolean", "value6", false) //F
+
+	options.StrictBoolTrue = ""
+	options.StrictBoolTrueSynonyms = nil
+
+	if _, err := ic.ValueAsBool("Boolean", "value1"); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected invalid options error when no true values are set, got %v", err)
+	}
+
 }
 
 func TestColonParse(t *testing.T) {
@@ -622,3 +639,265 @@ func determineLine() string {
 	return f[len(f)-1]
 
 }
+
+func TestTaggedSections(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "tagged-sections.ini")
+
+	options := DefaultIniOptions()
+
+	ic, err := NewIniConfigFromPathWithOptions(path, options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if !ic.SectionExists("server:linux") {
+		t.Errorf("Expected qualified section to be treated literally when ActiveTags is empty")
+	}
+
+	options.ActiveTags = []string{"production", "linux"}
+
+	ic, err = NewIniConfigFromPathWithOptions(path, options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if ic.SectionExists("server:linux") || ic.SectionExists("server:windows") {
+		t.Errorf("Did not expect qualified sections to exist")
+	}
+
+	if v, _ := ic.Value("server", "port"); v != "8080" {
+		t.Errorf("Expected [server].port=8080 was %s", v)
+	}
+
+	if v, _ := ic.Value("server", "user"); v != "www-data" {
+		t.Errorf("Expected [server].user=www-data was %s", v)
+	}
+
+	if v, _ := ic.Value("server", "shell"); v != "/bin/sh" {
+		t.Errorf("Expected [server].shell=/bin/sh was %s", v)
+	}
+}
+
+func TestValueDecryptor(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "encrypted.ini")
+
+	options := DefaultIniOptions()
+
+	ic, err := NewIniConfigFromPathWithOptions(path, options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if v, _ := ic.Value("database", "password"); v != "ENC[drowssap]" {
+		t.Errorf("Expected encrypted value to be returned unchanged without a decryptor, was %s", v)
+	}
+
+	options.ValueDecryptor = func(section, property, raw string) (string, error) {
+
+		if property == "port" {
+			return "", errorf("Bad key")
+		}
+
+		r := []rune(raw)
+
+		for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+			r[i], r[j] = r[j], r[i]
+		}
+
+		return string(r), nil
+	}
+
+	if v, _ := ic.Value("database", "password"); v != "password" {
+		t.Errorf("Expected decrypted value, was %s", v)
+	}
+
+	if v, _ := ic.Value("database", "user"); v != "app" {
+		t.Errorf("Expected unencrypted value to be unchanged, was %s", v)
+	}
+
+	if _, err := ic.ValueAsInt64("database", "port"); err == nil {
+		t.Errorf("Expected decryption failure to be reported")
+	}
+}
+
+func TestSensitivePropertyRedaction(t *testing.T) {
+
+	path := typesPath()
+
+	options := DefaultIniOptions()
+	options.SensitiveProperties = []string{"str*"}
+
+	ic, err := NewIniConfigFromPathWithOptions(path, options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if _, err := ic.ValueAsInt64("int", "string"); err == nil {
+		t.Errorf("Expected string to fail")
+	} else if strings.Contains(err.Error(), "xxxx") {
+		t.Errorf("Expected value to be redacted: %s", err.Error())
+	}
+
+	if _, err := ic.ValueAsInt64("int", "float"); err == nil || !strings.Contains(err.Error(), "0.2") {
+		t.Errorf("Expected value of non-sensitive property in error")
+	}
+
+	ic.MarkSensitive("int", "float")
+
+	if _, err := ic.ValueAsInt64("int", "float"); err == nil || strings.Contains(err.Error(), "0.2") {
+		t.Errorf("Expected value to be redacted")
+	}
+
+	var ne *strconv.NumError
+
+	if _, err := ic.ValueAsInt64("int", "float"); !errors.As(err, &ne) || strings.Contains(ne.Num, "0.2") {
+		t.Errorf("Expected value to be redacted from the wrapped strconv.NumError")
+	}
+
+	ic.Add("int", "hex", "0xZZ")
+	ic.MarkSensitive("int", "hex")
+
+	if _, err := ic.ValueAsHex("int", "hex"); err == nil || strings.Contains(err.Error(), "Z") {
+		t.Errorf("Expected invalid byte to be redacted: %v", err)
+	}
+
+	if ic.IsSensitive("uint", "float") {
+		t.Errorf("Did not expect [uint].float to be sensitive")
+	}
+}
+
+type recordingLogger struct {
+	messages []string
+}
+
+func (rl *recordingLogger) Debugf(template string, args ...interface{}) {
+	rl.messages = append(rl.messages, fmt.Sprintf(template, args...))
+}
+
+func TestParserLogging(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "unparseable-lines.ini")
+
+	logger := new(recordingLogger)
+
+	options := DefaultIniOptions()
+	options.IgnoreUnparseable = true
+	options.Logger = logger
+
+	if _, err := NewIniConfigFromPathWithOptions(path, options); err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if len(logger.messages) != 1 || !strings.Contains(logger.messages[0], "line 2") {
+		t.Errorf("Expected one message about line 2, found %v", logger.messages)
+	}
+}
+
+func TestInvalidUTF8(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "invalid-utf8.ini")
+
+	options := DefaultIniOptions()
+
+	ic, err := NewIniConfigFromPathWithOptions(path, options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if v, _ := ic.Value("section", "invalid"); v != "caf\xe9 au lait" {
+		t.Errorf("Expected invalid bytes to be passed through, was %q", v)
+	}
+
+	options.InvalidUTF8 = InvalidUTF8Replace
+
+	ic, _ = NewIniConfigFromPathWithOptions(path, options)
+
+	if v, _ := ic.Value("section", "invalid"); v != "caf� au lait" {
+		t.Errorf("Expected invalid bytes to be replaced, was %q", v)
+	}
+
+	if v, _ := ic.Value("section", "valid"); v != "café" {
+		t.Errorf("Did not expect valid value to change, was %q", v)
+	}
+
+	options.InvalidUTF8 = InvalidUTF8Reject
+
+	if _, err := NewIniConfigFromPathWithOptions(path, options); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("Expected parse to fail at line 3, was %v", err)
+	}
+}
+
+func TestEmptySectionNames(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "empty-section.ini")
+
+	ic, err := NewIniConfigFromPath(path)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if v := ic.ValueOrZero(GLOBAL_SECTION, "orphan"); v != "O" {
+		t.Errorf("Expected properties after [] to be global by default, got %s", v)
+	}
+
+	o := DefaultIniOptions()
+	o.EmptySections = EmptySectionReject
+
+	if _, err := NewIniConfigFromPathWithOptions(path, o); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("Expected empty section on line 3 to be rejected, got %v", err)
+	}
+
+	o.EmptySections = EmptySectionIgnore
+
+	if ic, err := NewIniConfigFromPathWithOptions(path, o); err != nil || ic.PropertyExists(GLOBAL_SECTION, "orphan") || ic.ValueOrZero("named", "prop") != "P" {
+		t.Errorf("Expected properties after [] to be discarded (%v)", err)
+	}
+
+	o.EmptySections = EmptySectionRename
+	o.EmptySectionName = "unnamed"
+
+	if ic, err := NewIniConfigFromPathWithOptions(path, o); err != nil || ic.ValueOrZero("unnamed", "orphan") != "O" || ic.PropertyExists(GLOBAL_SECTION, "orphan") {
+		t.Errorf("Expected properties after [] to be stored in [unnamed] (%v)", err)
+	}
+}
+
+func TestPostParse(t *testing.T) {
+
+	content := "[paths]\nbase=/var/app\n"
+
+	o := DefaultIniOptions()
+	o.PostParse = func(ic *IniConfig) error {
+
+		base, err := ic.Value("paths", "base")
+
+		if err != nil {
+			return err
+		}
+
+		ic.Add("paths", "cache", base+"/cache")
+
+		return nil
+	}
+
+	ic, err := newIniConfigFromReader(strings.NewReader(content), "paths.ini", o)
+
+	if err != nil || ic.ValueOrZero("paths", "cache") != "/var/app/cache" {
+		t.Errorf("Expected derived property to be added (%v)", err)
+	}
+
+	o.PostParse = func(ic *IniConfig) error {
+		return fmt.Errorf("invariant broken")
+	}
+
+	if _, err := newIniConfigFromReader(strings.NewReader(content), "paths.ini", o); err == nil || !strings.Contains(err.Error(), "invariant broken") {
+		t.Errorf("Expected PostParse error to be returned, got %v", err)
+	}
+}
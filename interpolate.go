@@ -0,0 +1,118 @@
+package inifile
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// The built-in variables available for interpolation (when listed in InterpolationBuiltins)
+const (
+	// The name of the section containing the value being interpolated
+	BuiltinSectionName = "__name__"
+	// The absolute path of the directory containing the parsed file
+	BuiltinHere = "here"
+	// The host name reported by the operating system
+	BuiltinHostname = "hostname"
+	// The ID of the current process
+	BuiltinPID = "pid"
+)
+
+// The maximum depth of references followed when interpolating a value, to detect circular references
+const maxInterpolationDepth = 10
+
+// interpolate replaces %(name)s references in the supplied value with the value of the property called name in the same
+// section, the global section or an allowed built-in variable (in that order of precedence). %% is replaced with %.
+func (ic *IniConfig) interpolate(sectionName, value string, depth int) (string, error) {
+
+	if !strings.Contains(value, "%") {
+		return value, nil
+	}
+
+	if depth > maxInterpolationDepth {
+		return "", errorf("Interpolation of a value in section %s exceeded %d levels (circular reference?)", sectionName, maxInterpolationDepth)
+	}
+
+	var b strings.Builder
+
+	for {
+		i := strings.IndexByte(value, '%')
+
+		if i < 0 {
+			b.WriteString(value)
+			break
+		}
+
+		b.WriteString(value[:i])
+		value = value[i:]
+
+		if strings.HasPrefix(value, "%%") {
+			b.WriteByte('%')
+			value = value[2:]
+			continue
+		}
+
+		end := strings.Index(value, ")s")
+
+		if !strings.HasPrefix(value, "%(") || end < 0 {
+			return "", errorf("Bad interpolation syntax in section %s: %s (use %%%% for a literal %%)", sectionName, value)
+		}
+
+		name := value[2:end]
+		value = value[end+2:]
+
+		resolved, err := ic.resolveReference(sectionName, name, depth)
+
+		if err != nil {
+			return "", err
+		}
+
+		b.WriteString(resolved)
+	}
+
+	return b.String(), nil
+}
+
+// resolveReference finds the interpolated value of the named property or built-in variable.
+func (ic *IniConfig) resolveReference(sectionName, name string, depth int) (string, error) {
+
+	for _, candidate := range []string{sectionName, GLOBAL_SECTION} {
+
+		if v, found := ic.findSection(candidate)[ic.normalise(name)]; found {
+			return ic.interpolate(candidate, v.String(), depth+1)
+		}
+	}
+
+	for _, allowed := range ic.options.InterpolationBuiltins {
+		if allowed == name {
+			return ic.builtin(sectionName, name)
+		}
+	}
+
+	return "", errorf("Unable to interpolate %%(%s)s in section %s: no such property or built-in variable", name, sectionName)
+}
+
+// builtin returns the value of a built-in variable.
+func (ic *IniConfig) builtin(sectionName, name string) (string, error) {
+
+	switch name {
+	case BuiltinSectionName:
+		return sectionName, nil
+
+	case BuiltinHere:
+		if ic.source == "" {
+			return "", errorf("%%(%s)s is not available as this IniConfig was not loaded from a file", name)
+		}
+
+		return filepath.Abs(filepath.Dir(ic.source))
+
+	case BuiltinHostname:
+		return os.Hostname()
+
+	case BuiltinPID:
+		return strconv.Itoa(os.Getpid()), nil
+	}
+
+	return "", errorf("Unknown built-in variable %s", name)
+}
@@ -0,0 +1,53 @@
+package inifile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInterpolation(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "interpolation.ini")
+
+	options := DefaultIniOptions()
+
+	ic, err := NewIniConfigFromPathWithOptions(path, options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if v, _ := ic.Value("paths", "base"); v != "%(root)s/app" {
+		t.Errorf("Did not expect interpolation by default, was %s", v)
+	}
+
+	options.Interpolate = true
+
+	here, _ := filepath.Abs(testfiles_base)
+
+	expected := map[string]string{
+		"base":     "/srv/app",
+		"logs":     "/srv/app/logs/paths.log",
+		"config":   here + "/app.ini",
+		"discount": "50%",
+	}
+
+	for p, e := range expected {
+		if v, err := ic.Value("paths", p); err != nil || v != e {
+			t.Errorf("Expected %s for %s, was %s (%v)", e, p, v, err)
+		}
+	}
+
+	for _, p := range []string{"host", "loop", "bad"} {
+		if _, err := ic.Value("paths", p); err == nil {
+			t.Errorf("Expected interpolation of %s to fail", p)
+		}
+	}
+
+	options.InterpolationBuiltins = append(options.InterpolationBuiltins, BuiltinHostname)
+
+	if h, _ := os.Hostname(); ic.ValueOrZero("paths", "host") != h {
+		t.Errorf("Expected hostname to be interpolated")
+	}
+}
@@ -0,0 +1,40 @@
+//go:build go1.23
+
+package inifile
+
+import (
+	"iter"
+)
+
+// Sections returns an iterator over the names of the sections in this IniConfig (in the order of SectionNames) and an
+// IniSection for each, for use with range:
+//
+//		for name, section := range ic.Sections() {
+//			...
+//		}
+func (ic *IniConfig) Sections() iter.Seq2[string, *IniSection] {
+
+	return func(yield func(string, *IniSection) bool) {
+
+		for _, name := range ic.SectionNames() {
+			if !yield(name, &IniSection{name: name, ic: ic}) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the names and values of the properties in this section in the order of PropertyNames.
+// Values are retrieved with ValueOrZero, so a value that cannot be retrieved (e.g. because it could not be decrypted)
+// is yielded as an empty string.
+func (is *IniSection) All() iter.Seq2[string, string] {
+
+	return func(yield func(string, string) bool) {
+
+		for _, name := range is.PropertyNames() {
+			if !yield(name, is.ValueOrZero(name)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+//go:build go1.23
+
+package inifile
+
+import (
+	"testing"
+)
+
+func TestIterators(t *testing.T) {
+
+	ic, err := NewIniConfigFromPath(typesPath())
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	var names []string
+
+	for name, section := range ic.Sections() {
+
+		if section.Name() != name {
+			t.Errorf("Section %s yielded with name %s", section.Name(), name)
+		}
+
+		names = append(names, name)
+	}
+
+	if len(names) != len(ic.SectionNames()) || names[0] != "Boolean" {
+		t.Errorf("Unexpected sections %v", names)
+	}
+
+	for range ic.Sections() {
+		break
+	}
+
+	is, _ := ic.Section("int")
+
+	values := make(map[string]string)
+
+	for property, value := range is.All() {
+		values[property] = value
+	}
+
+	if len(values) != 4 || values["negative"] != "-1" {
+		t.Errorf("Unexpected values %v", values)
+	}
+
+	count := 0
+
+	for range is.All() {
+		count++
+		break
+	}
+
+	if count != 1 {
+		t.Errorf("Expected iteration to stop")
+	}
+}
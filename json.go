@@ -0,0 +1,50 @@
+package inifile
+
+import (
+	"encoding/json"
+)
+
+// MarshalJSON converts this IniConfig into a JSON object with a member for each section (the global section's name is
+// the empty string). Each section is an object mapping property names to string values. The values of sensitive
+// properties (see IsSensitive) are redacted, so the JSON cannot be used to recreate them with NewIniConfigFromJSON.
+func (ic *IniConfig) MarshalJSON() ([]byte, error) {
+
+	if err := ic.loadAllSections(); err != nil {
+		return nil, err
+	}
+
+	doc := make(map[string]map[string]string, len(ic.sections))
+
+	for section, properties := range ic.sections {
+
+		s := make(map[string]string, len(properties))
+
+		for property, value := range properties {
+			s[property] = ic.redact(section, property, value.String())
+		}
+
+		doc[section] = s
+	}
+
+	return json.Marshal(doc)
+}
+
+// NewIniConfigFromJSON creates an IniConfig from a JSON object in the format produced by MarshalJSON, using the
+// supplied options for subsequent access. Sections and properties are added in alphabetical order. Sensitive values
+// redacted by MarshalJSON are read as the literal text ****.
+//
+// An error is returned if the JSON is not an object whose members are objects with string values.
+func NewIniConfigFromJSON(data []byte, options *IniOptions) (*IniConfig, error) {
+
+	if options == nil {
+		return nil, errorf("Nil IniOptions provided")
+	}
+
+	var doc map[string]map[string]string
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, errorf("Unable to convert JSON to an IniConfig: %w", err)
+	}
+
+	return newIniConfigFromMap(doc, options), nil
+}
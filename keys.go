@@ -0,0 +1,81 @@
+package inifile
+
+import "strings"
+
+// assignmentSymbol returns the character that separates property names from values.
+func (ic *IniConfig) assignmentSymbol() byte {
+
+	if ic.options.UseColonAssignment {
+		return ':'
+	}
+
+	return '='
+}
+
+// matchEscapedProperty splits a property line whose name may contain the assignment symbol escaped with a \
+// (cn\=admin=x) or be enclosed in double quotes ("cn=admin"=x), as allowed by EscapedPropertyNames in IniOptions.
+// Returns the whole line, the unescaped name and the value, or nil if the line does not contain an unescaped
+// assignment symbol.
+func matchEscapedProperty(line string, assign byte) []string {
+
+	var name strings.Builder
+
+	i := 0
+
+	if strings.HasPrefix(line, "\"") {
+
+		for i = 1; i < len(line) && line[i] != '"'; i++ {
+
+			if line[i] == '\\' && i+1 < len(line) {
+				i++
+			}
+
+			name.WriteByte(line[i])
+		}
+
+		if i >= len(line) {
+			return nil
+		}
+
+		//Only whitespace may separate the closing quote from the assignment symbol
+		rest := strings.TrimLeft(line[i+1:], " \t")
+
+		if !strings.HasPrefix(rest, string(assign)) {
+			return nil
+		}
+
+		return []string{line, name.String(), rest[1:]}
+	}
+
+	for ; i < len(line); i++ {
+
+		c := line[i]
+
+		if c == '\\' && i+1 < len(line) {
+			i++
+			name.WriteByte(line[i])
+			continue
+		}
+
+		if c == assign {
+			return []string{line, name.String(), line[i+1:]}
+		}
+
+		name.WriteByte(c)
+	}
+
+	return nil
+}
+
+// escapePropertyName escapes any characters in a property name that would otherwise end the name when it is parsed with
+// EscapedPropertyNames set in IniOptions.
+func (ic *IniConfig) escapePropertyName(name string) string {
+
+	if !ic.options.EscapedPropertyNames {
+		return name
+	}
+
+	assign := string(ic.assignmentSymbol())
+
+	return strings.NewReplacer("\\", "\\\\", assign, "\\"+assign).Replace(name)
+}
@@ -0,0 +1,56 @@
+package inifile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEscapedPropertyNames(t *testing.T) {
+
+	content := "[acl]\ncn\\=admin,dc\\=example=read\n\"cn=guest,dc=example\" = none\nback\\\\slash=1\nplain=a=b\n"
+
+	o := DefaultIniOptions()
+	o.EscapedPropertyNames = true
+
+	ic, err := newIniConfigFromReader(strings.NewReader(content), "acl.ini", o)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	expected := map[string]string{
+		"cn=admin,dc=example": "read",
+		"cn=guest,dc=example": "none",
+		"back\\slash":         "1",
+		"plain":               "a=b",
+	}
+
+	for name, value := range expected {
+		if v, err := ic.Value("acl", name); err != nil || v != value {
+			t.Errorf("Expected %s=%s, got %s (%v)", name, value, v, err)
+		}
+	}
+
+	var b bytes.Buffer
+
+	if _, err := ic.WriteTo(&b); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	written, err := newIniConfigFromReader(&b, "written.ini", o)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	for name, value := range expected {
+		if v := written.ValueOrZero("acl", name); v != value {
+			t.Errorf("Expected %s=%s after writing, got %s", name, value, v)
+		}
+	}
+
+	if _, err := newIniConfigFromReader(strings.NewReader("[acl]\n\"unterminated=x\n"), "bad.ini", o); err == nil {
+		t.Errorf("Expected unterminated quoted property name to be rejected")
+	}
+}
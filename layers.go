@@ -0,0 +1,105 @@
+package inifile
+
+import (
+	"strings"
+)
+
+// AddLayer registers another IniConfig under the supplied name so that values in this IniConfig can refer to its
+// properties with ${name:section.property} references (see LayerReferences in IniOptions). This allows, for example, a
+// separately protected secrets file to be referenced from the main configuration without merging the two.
+func (ic *IniConfig) AddLayer(name string, layer *IniConfig) {
+
+	if ic.layers == nil {
+		ic.layers = make(map[string]*IniConfig)
+	}
+
+	ic.layers[name] = layer
+}
+
+// Layer returns the IniConfig registered with AddLayer under the supplied name.
+func (ic *IniConfig) Layer(name string) (*IniConfig, bool) {
+
+	layer, found := ic.layers[name]
+
+	return layer, found
+}
+
+// resolveLayerReferences replaces ${layer:section.property} references in the value with the value of the property in
+// the named layer. The section and property are separated by the last dot, and a reference without a dot refers to
+// a property in the layer's global section. $${ is replaced with a literal ${.
+func (ic *IniConfig) resolveLayerReferences(value string) (string, error) {
+
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+
+	var b strings.Builder
+
+	for {
+		i := strings.Index(value, "${")
+
+		if i < 0 {
+			b.WriteString(value)
+			break
+		}
+
+		if i > 0 && value[i-1] == '$' {
+			//Escaped reference
+			b.WriteString(value[:i+2])
+			value = value[i+2:]
+			continue
+		}
+
+		b.WriteString(value[:i])
+		value = value[i:]
+
+		end := strings.IndexByte(value, '}')
+
+		if end < 0 {
+			return "", errorf("Unterminated layer reference %s", value)
+		}
+
+		resolved, err := ic.resolveLayerReference(value[2:end])
+
+		if err != nil {
+			return "", err
+		}
+
+		b.WriteString(resolved)
+		value = value[end+1:]
+	}
+
+	return strings.Replace(b.String(), "$${", "${", -1), nil
+}
+
+// resolveLayerReference returns the value identified by a reference of the form layer:section.property.
+func (ic *IniConfig) resolveLayerReference(ref string) (string, error) {
+
+	i := strings.IndexByte(ref, ':')
+
+	if i < 0 {
+		return "", errorf("Layer reference ${%s} should be of the form ${layer:section.property}", ref)
+	}
+
+	name, path := ref[:i], ref[i+1:]
+
+	layer, found := ic.layers[name]
+
+	if !found {
+		return "", errorf("Layer reference ${%s} refers to unknown layer %s", ref, name)
+	}
+
+	section, property := GLOBAL_SECTION, path
+
+	if j := strings.LastIndexByte(path, '.'); j >= 0 {
+		section, property = path[:j], path[j+1:]
+	}
+
+	v, err := layer.Value(section, property)
+
+	if err != nil {
+		return "", errorf("Unable to resolve layer reference ${%s}: %w", ref, err)
+	}
+
+	return v, nil
+}
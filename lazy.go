@@ -0,0 +1,228 @@
+package inifile
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// NewLazyIniConfig creates an IniConfig that only indexes the positions of the sections in the supplied content.
+// Each section is parsed the first time it is accessed, which dramatically reduces the cost of creating the IniConfig when
+// an application only needs a few sections from a very large file.
+//
+// The caller must keep r available (e.g. not close the underlying file) for as long as the IniConfig is in use. Errors in
+// the content of a section are returned by the first call to Value (or any accessor built on it) for that section.
+//
+// As reading a section for the first time modifies the IniConfig, a lazily parsed IniConfig is not safe for concurrent
+// use, even if every goroutine only reads from it. Either confine it to one goroutine, guard it with a lock or use a
+// non-lazy IniConfig where configuration is shared (e.g. with AtomicIniConfig).
+func NewLazyIniConfig(r io.ReaderAt, size int64, options *IniOptions) (*IniConfig, error) {
+
+	if r == nil {
+		return nil, errorf("Nil ReaderAt provided")
+	}
+
+	if options == nil {
+		return nil, errorf("Nil IniOptions provided")
+	}
+
+	if len(strings.TrimSpace(options.CommentStart)) == 0 {
+		return nil, errorf("CommentStart field in IniOptions cannot be empty")
+	}
+
+	if options.NormalizeLineEndings {
+		return nil, errorf("NormalizeLineEndings in IniOptions is not supported for lazily parsed files")
+	}
+
+	if options.LineClassifier != nil {
+		return nil, errorf("LineClassifier in IniOptions is not supported for lazily parsed files")
+	}
+
+	if options.RecordPositions {
+		return nil, errorf("RecordPositions in IniOptions is not supported for lazily parsed files")
+	}
+
+	if options.SignatureKey != nil {
+		return nil, errorf("SignatureKey in IniOptions is not supported for lazily parsed files")
+	}
+
+	if options.VerifyChecksum {
+		return nil, errorf("VerifyChecksum in IniOptions is not supported for lazily parsed files")
+	}
+
+	if options.Decompress {
+		return nil, errorf("Decompress in IniOptions is not supported for lazily parsed files")
+	}
+
+	if options.TombstoneSectionPrefix != "" {
+		return nil, errorf("TombstoneSectionPrefix in IniOptions is not supported for lazily parsed files")
+	}
+
+	ic := new(IniConfig)
+	ic.options = options
+	ic.sections = make(sectionPropertyMap)
+
+	if f, ok := r.(interface{ Name() string }); ok {
+		ic.source = f.Name()
+	}
+
+	if err := ic.index(r, size); err != nil {
+		return nil, err
+	}
+
+	if err := ic.postParse(); err != nil {
+		return nil, err
+	}
+
+	return ic, nil
+}
+
+// A contiguous block of the source content containing a section header (except for the global section) and the lines
+// that follow it
+type sectionSpan struct {
+	offset    int64
+	length    int64
+	firstLine int
+	tagIndex  int
+}
+
+// sectionIndex records where each section's content can be found so it can be parsed on first access
+type sectionIndex struct {
+	source io.ReaderAt
+	spans  map[string][]sectionSpan
+	loaded map[string]error
+}
+
+// index scans the content for section headers, recording the location of each section without parsing any properties.
+func (ic *IniConfig) index(r io.ReaderAt, size int64) error {
+
+	sectionRx := regexp.MustCompile(rx_section)
+
+	idx := new(sectionIndex)
+	idx.source = r
+	idx.spans = make(map[string][]sectionSpan)
+	idx.loaded = make(map[string]error)
+
+	br := bufio.NewReader(io.NewSectionReader(r, 0, size))
+
+	var offset int64
+	lineNumber := 0
+
+	current := GLOBAL_SECTION
+	currentSpan := sectionSpan{tagIndex: untaggedSection}
+
+	closeSpan := func(end int64) {
+		currentSpan.length = end - currentSpan.offset
+
+		if currentSpan.tagIndex != inactiveSection && currentSpan.length > 0 {
+			key := ic.normalise(current)
+			idx.spans[key] = append(idx.spans[key], currentSpan)
+		}
+	}
+
+	for {
+		line, err := br.ReadString('\n')
+
+		if len(line) > 0 {
+
+			l := strings.TrimSpace(line)
+
+			if !ic.isComment(l) {
+
+				if matches := ic.matchSection(sectionRx, ic.stripInlineComments(l)); matches != nil {
+
+					if err := ic.checkEmptySection(matches[1], lineNumber+1); err != nil {
+						return err
+					}
+
+					closeSpan(offset)
+
+					current, currentSpan.tagIndex = ic.resolveSectionTag(matches[1])
+					currentSpan.offset = offset
+					currentSpan.firstLine = lineNumber
+				}
+			}
+
+			offset += int64(len(line))
+			lineNumber++
+		}
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	closeSpan(offset)
+
+	//Qualified sections must be merged after the unqualified section and in tag order
+	for _, spans := range idx.spans {
+		sort.SliceStable(spans, func(i, j int) bool {
+			return spans[i].tagIndex < spans[j].tagIndex
+		})
+	}
+
+	ic.lazy = idx
+
+	return nil
+}
+
+// loadSection parses the named section if this IniConfig was created with NewLazyIniConfig and the section has not
+// already been parsed. Returns any error encountered when the section was parsed.
+func (ic *IniConfig) loadSection(sectionName string) error {
+
+	idx := ic.lazy
+
+	if idx == nil {
+		return nil
+	}
+
+	key := ic.normalise(sectionName)
+
+	if err, loaded := idx.loaded[key]; loaded {
+		return err
+	}
+
+	//Mark the section as loaded before parsing, as parsing adds properties to the section being loaded
+	idx.loaded[key] = nil
+
+	for _, span := range idx.spans[key] {
+
+		if err := ic.parseFromLine(io.NewSectionReader(idx.source, span.offset, span.length), span.firstLine); err != nil {
+			idx.loaded[key] = err
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadAllSections parses every section that has not yet been parsed. Used by functions that need to see the whole file.
+func (ic *IniConfig) loadAllSections() error {
+
+	if ic.lazy == nil {
+		return nil
+	}
+
+	//Load sections in the order they appear in the source so ordering is preserved
+	keys := make([]string, 0, len(ic.lazy.spans))
+
+	for key := range ic.lazy.spans {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return ic.lazy.spans[keys[i]][0].offset < ic.lazy.spans[keys[j]][0].offset
+	})
+
+	for _, key := range keys {
+		if err := ic.loadSection(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
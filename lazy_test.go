@@ -0,0 +1,61 @@
+package inifile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLazyParsing(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "lazy.ini")
+
+	f, err := os.Open(path)
+
+	if err != nil {
+		t.Fatalf("Unable to open test file at %s: %s", path, err.Error())
+	}
+
+	defer f.Close()
+
+	fi, _ := f.Stat()
+
+	options := DefaultIniOptions()
+	options.ActiveTags = []string{"linux"}
+
+	ic, err := NewLazyIniConfig(f, fi.Size(), options)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if len(ic.sections) != 0 {
+		t.Errorf("Did not expect any sections to have been parsed")
+	}
+
+	if v, _ := ic.Value("first", "b"); v != "2" {
+		t.Errorf("Unexpected value %s", v)
+	}
+
+	if len(ic.sections) != 1 {
+		t.Errorf("Expected only one section to have been parsed")
+	}
+
+	if v, _ := ic.Value(GLOBAL_SECTION, "global"); v != "G" {
+		t.Errorf("Unexpected value %s", v)
+	}
+
+	if v, _ := ic.Value("server", "user"); v != "www-data" {
+		t.Errorf("Expected qualified section to override, found %s", v)
+	}
+
+	if _, err := ic.Value("broken", "a"); err == nil || err.Error() != "Unparseable line in file on line 8" {
+		t.Errorf("Expected error for broken section with correct line number, found %v", err)
+	}
+
+	ic.Add("new", "a", "b")
+
+	if v, _ := ic.Value("new", "a"); v != "b" {
+		t.Errorf("Unexpected value %s", v)
+	}
+}
@@ -0,0 +1,50 @@
+package inifile
+
+import (
+	"bytes"
+	"unicode"
+)
+
+// scanLinesNormalised is a bufio.SplitFunc that ends lines at LF, CRLF or a lone CR. The line ending is not included in
+// the returned line.
+func scanLinesNormalised(data []byte, atEOF bool) (int, []byte, error) {
+
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	i := bytes.IndexAny(data, "\r\n")
+
+	switch {
+	case i < 0 && atEOF:
+		return len(data), data, nil
+
+	case i < 0:
+		//Request more data
+		return 0, nil, nil
+
+	case data[i] == '\n':
+		return i + 1, data[:i], nil
+
+	case i+1 < len(data) && data[i+1] == '\n':
+		return i + 2, data[:i], nil
+
+	case i+1 < len(data) || atEOF:
+		return i + 1, data[:i], nil
+	}
+
+	//A CR at the end of the buffer might be followed by LF
+	return 0, nil, nil
+}
+
+// checkControlCharacters returns an error if the line contains a control character other than tab.
+func checkControlCharacters(line string, lineNumber int) error {
+
+	for _, r := range line {
+		if unicode.IsControl(r) && r != '\t' {
+			return parseErrorf(lineNumber, "Control character %U (forbidden in IniOptions)", r)
+		}
+	}
+
+	return nil
+}
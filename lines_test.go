@@ -0,0 +1,76 @@
+package inifile
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestScanLinesNormalised(t *testing.T) {
+
+	s := bufio.NewScanner(strings.NewReader("a\r\nb\rc\nd\r"))
+	s.Split(scanLinesNormalised)
+
+	var lines []string
+
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+
+	if strings.Join(lines, "|") != "a|b|c|d" {
+		t.Errorf("Unexpected lines %q", lines)
+	}
+}
+
+func TestNormalizeLineEndings(t *testing.T) {
+
+	content := "[section]\r\ncrlf=1\r\nmac=2\rnext=3\n"
+
+	options := DefaultIniOptions()
+	options.TrimProperties = false
+
+	ic, err := newIniConfigFromReader(strings.NewReader(content), "", options)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if v, _ := ic.Value("section", "crlf"); v != "1" {
+		t.Errorf("Expected CRLF to be removed, was %q", v)
+	}
+
+	if v, _ := ic.Value("section", "mac"); v != "2\rnext=3" {
+		t.Errorf("Expected lone CR to be kept without NormalizeLineEndings, was %q", v)
+	}
+
+	options.NormalizeLineEndings = true
+
+	ic, _ = newIniConfigFromReader(strings.NewReader(content), "", options)
+
+	if v, _ := ic.Value("section", "mac"); v != "2" {
+		t.Errorf("Expected lone CR to end the line, was %q", v)
+	}
+
+	if v, _ := ic.Value("section", "next"); v != "3" {
+		t.Errorf("Unexpected value %q", v)
+	}
+}
+
+func TestRejectControlCharacters(t *testing.T) {
+
+	options := DefaultIniOptions()
+
+	if _, err := newIniConfigFromReader(strings.NewReader("[s]\na=x\x07y\n"), "", options); err != nil {
+		t.Errorf("Did not expect control characters to be rejected by default")
+	}
+
+	options.RejectControlCharacters = true
+
+	if _, err := newIniConfigFromReader(strings.NewReader("[s]\na=x\ty\n"), "", options); err != nil {
+		t.Errorf("Did not expect tab to be rejected")
+	}
+
+	if _, err := newIniConfigFromReader(strings.NewReader("[s]\na=x\x07y\n"), "", options); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected control character to be rejected at line 2, was %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+package inifile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Severity indicates how serious a Finding is.
+type Severity int
+
+const (
+	// A matter of style that does not affect how the file is parsed
+	SeverityInfo Severity = iota
+	// Input that is accepted but is likely to be a mistake
+	SeverityWarning
+	// Input that prevents the file from being parsed
+	SeverityError
+)
+
+// String returns a lower case name for the severity.
+func (s Severity) String() string {
+
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	}
+
+	return "error"
+}
+
+// The rules checked by Lint, recorded in Finding.Rule
+const (
+	LintParse              = "parse"
+	LintIndentation        = "indentation"
+	LintCommentStyle       = "comment-style"
+	LintDuplicateKey       = "duplicate-key"
+	LintTrailingWhitespace = "trailing-whitespace"
+	LintSuspiciousValue    = "suspicious-value"
+)
+
+// Finding is a problem reported by Lint.
+type Finding struct {
+	// The line the problem was found on (0 if it does not relate to a single line)
+	Line int
+
+	// How serious the problem is
+	Severity Severity
+
+	// The rule that found the problem (LintParse, LintIndentation...)
+	Rule string
+
+	// A human-readable description of the problem
+	Message string
+}
+
+// String returns the line, severity, message and rule of the finding.
+func (f Finding) String() string {
+	return fmt.Sprintf("%d: %s: %s (%s)", f.Line, f.Severity, f.Message, f.Rule)
+}
+
+// Lint checks the supplied content for problems of style and correctness, returning them in line order. The content is
+// parsed with the supplied options (with FailOnWarnings disabled and CommentInValue set to at least
+// CommentInValueWarn); parse errors are reported as findings with SeverityError rather than returned. The returned error
+// is only set if the content cannot be read.
+//
+// The checks are: lines indented with a mixture of tabs and spaces (or differently to earlier lines), comments using a
+// different symbol to the first comment, properties defined more than once in a section, trailing whitespace and
+// suspicious values (see Warnings).
+func Lint(r io.Reader, options *IniOptions) ([]Finding, error) {
+
+	if options == nil {
+		return nil, errors.New("Nil IniOptions provided")
+	}
+
+	content, err := io.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	findings := lintLines(content, options)
+
+	lo := *options
+	lo.FailOnWarnings = false
+
+	if lo.CommentInValue == CommentInValueAllow {
+		lo.CommentInValue = CommentInValueWarn
+	}
+
+	ic, err := newIniConfigFromReader(bytes.NewReader(content), "", &lo)
+
+	if err != nil {
+
+		f := Finding{Severity: SeverityError, Rule: LintParse, Message: err.Error()}
+
+		var pe *ParseError
+
+		if errors.As(err, &pe) {
+			f.Line = pe.Line
+		}
+
+		findings = append(findings, f)
+
+	} else {
+
+		for _, s := range ic.ShadowedProperties() {
+			findings = append(findings, Finding{s.Line, SeverityWarning, LintDuplicateKey,
+				fmt.Sprintf("[%s].%s is already defined on line %d", s.Section, s.Property, s.ShadowedLine)})
+		}
+
+		for _, w := range ic.Warnings() {
+			if w.Kind != ShadowedProperty {
+				findings = append(findings, Finding{w.Line, SeverityWarning, LintSuspiciousValue, w.Message})
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Line < findings[j].Line
+	})
+
+	return findings, nil
+}
+
+// lintLines applies the checks that only need the text of each line.
+func lintLines(content []byte, options *IniOptions) []Finding {
+
+	var findings []Finding
+
+	commentStarts := append([]string{options.CommentStart}, options.ExtraCommentStarts...)
+	firstComment := ""
+	firstIndent := byte(0)
+
+	for i, line := range strings.Split(string(content), "\n") {
+
+		lineNumber := i + 1
+		line = strings.TrimSuffix(line, "\r")
+
+		if trimmed := strings.TrimRight(line, " \t"); trimmed != line && trimmed != "" {
+			findings = append(findings, Finding{lineNumber, SeverityInfo, LintTrailingWhitespace, "Line has trailing whitespace"})
+		}
+
+		text := strings.TrimLeft(line, " \t")
+
+		if text == "" {
+			continue
+		}
+
+		if indent := line[:len(line)-len(text)]; indent != "" {
+
+			if strings.Contains(indent, " ") && strings.Contains(indent, "\t") {
+				findings = append(findings, Finding{lineNumber, SeverityWarning, LintIndentation, "Line is indented with both tabs and spaces"})
+			} else if firstIndent == 0 {
+				firstIndent = indent[0]
+			} else if indent[0] != firstIndent {
+				findings = append(findings, Finding{lineNumber, SeverityInfo, LintIndentation, fmt.Sprintf("Line is indented with %s but earlier lines use %s", indentName(indent[0]), indentName(firstIndent))})
+			}
+		}
+
+		for _, cs := range commentStarts {
+
+			if cs == "" || !strings.HasPrefix(text, cs) {
+				continue
+			}
+
+			if firstComment == "" {
+				firstComment = cs
+			} else if cs != firstComment {
+				findings = append(findings, Finding{lineNumber, SeverityInfo, LintCommentStyle, fmt.Sprintf("Comment starts with %s but earlier comments use %s", cs, firstComment)})
+			}
+
+			break
+		}
+	}
+
+	return findings
+}
+
+// indentName describes an indentation character.
+func indentName(c byte) string {
+
+	if c == '\t' {
+		return "tabs"
+	}
+
+	return "spaces"
+}
@@ -0,0 +1,59 @@
+package inifile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+
+	o := DefaultIniOptions()
+	o.ExtraCommentStarts = []string{"#"}
+
+	f, err := os.Open(filepath.Join(testfiles_base, "lint.ini"))
+
+	if err != nil {
+		t.Fatalf("Problem opening test file %s", err.Error())
+	}
+
+	defer f.Close()
+
+	findings, err := Lint(f, o)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	var found []string
+
+	for _, f := range findings {
+		found = append(found, f.Rule+"@"+strings.Fields(f.String())[0])
+	}
+
+	expected := "trailing-whitespace@3: indentation@4: comment-style@5: duplicate-key@6: trailing-whitespace@7:"
+
+	if strings.Join(found, " ") != expected {
+		t.Errorf("Expected findings %s, got %s", expected, strings.Join(found, " "))
+	}
+
+	findings, err = Lint(strings.NewReader("[a]\n=x\n"), DefaultIniOptions())
+
+	if err != nil || len(findings) != 1 || findings[0].Severity != SeverityError || findings[0].Line != 2 {
+		t.Errorf("Expected a parse error finding on line 2, got %v (%v)", findings, err)
+	}
+}
+
+func TestLintParseErrorLine(t *testing.T) {
+
+	findings, err := Lint(strings.NewReader("[a]\nb=1\nnot a property\n"), DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if len(findings) != 1 || findings[0].Rule != LintParse || findings[0].Line != 3 {
+		t.Errorf("Expected a parse finding for line 3, got %v", findings)
+	}
+}
@@ -0,0 +1,122 @@
+package inifile
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// LoadErrors is returned by LoadAll when one or more files could not be loaded. It maps the path of each file that failed
+// to the error encountered.
+type LoadErrors map[string]error
+
+// Error summarises the failures, ordered by path.
+func (le LoadErrors) Error() string {
+
+	paths := make([]string, 0, len(le))
+
+	for p := range le {
+		paths = append(paths, p)
+	}
+
+	sort.Strings(paths)
+
+	messages := make([]string, len(paths))
+
+	for i, p := range paths {
+		messages[i] = p + ": " + le[p].Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// LoadAll parses each of the files at the supplied paths concurrently using a pool of workers (one per CPU) and the
+// supplied options. The returned map contains an IniConfig for every file that was parsed successfully, keyed by path.
+//
+// If any file could not be loaded, the returned error will be a LoadErrors describing every failure. The options are
+// shared between workers, so any Logger or ValueDecryptor they contain must be safe for concurrent use.
+func LoadAll(paths []string, options *IniOptions) (map[string]*IniConfig, error) {
+
+	workers := runtime.NumCPU()
+
+	if workers > len(paths) {
+		workers = len(paths)
+	}
+
+	jobs := make(chan string)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	loaded := make(map[string]*IniConfig)
+	failed := make(LoadErrors)
+
+	for i := 0; i < workers; i++ {
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for path := range jobs {
+
+				ic, err := NewIniConfigFromPathWithOptions(path, options)
+
+				mu.Lock()
+
+				if err != nil {
+					failed[path] = err
+				} else {
+					loaded[path] = ic
+				}
+
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, path := range paths {
+		jobs <- path
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if len(failed) > 0 {
+		return loaded, failed
+	}
+
+	return loaded, nil
+}
+
+// ErrValidationFailed is wrapped by errors returned by LoadAndValidate (and the AtomicIniConfig methods that use it) when
+// a file was parsed successfully but the supplied validation function rejected the result.
+var ErrValidationFailed = fmt.Errorf("configuration failed validation")
+
+// LoadAndValidate parses the file at the supplied path with the supplied options, then passes the result to validate
+// (if it is not nil) before returning it. Use it to check settings that can only be judged by the application, like
+// required properties or values that must be consistent with each other, so that code given the returned IniConfig
+// never sees a file that parsed but is not usable.
+//
+// If validate returns an error, no IniConfig is returned and the error returned wraps both ErrValidationFailed and the
+// error from validate. The IniConfig passed to validate must not be retained if validation fails.
+func LoadAndValidate(path string, validate func(*IniConfig) error, options *IniOptions) (*IniConfig, error) {
+
+	ic, err := NewIniConfigFromPathWithOptions(path, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if validate == nil {
+		return ic, nil
+	}
+
+	if err := validate(ic); err != nil {
+		return nil, wrapf(ErrValidationFailed, err, "Configuration in %s failed validation: %s", path, err.Error())
+	}
+
+	return ic, nil
+}
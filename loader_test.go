@@ -0,0 +1,67 @@
+package inifile
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadAll(t *testing.T) {
+
+	good := []string{simplePath(), typesPath(), filepath.Join(testfiles_base, "global-section.ini")}
+	bad := filepath.Join(testfiles_base, "unparseable-lines.ini")
+
+	loaded, err := LoadAll(good, DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if len(loaded) != len(good) || !loaded[typesPath()].SectionExists("uint") {
+		t.Errorf("Expected all files to be loaded")
+	}
+
+	loaded, err = LoadAll(append(good, bad), DefaultIniOptions())
+
+	if le, ok := err.(LoadErrors); !ok || len(le) != 1 || le[bad] == nil {
+		t.Errorf("Expected a single failure for %s, found %v", bad, err)
+	}
+
+	if len(loaded) != len(good) {
+		t.Errorf("Expected successfully parsed files to be returned")
+	}
+
+	if loaded, err := LoadAll(nil, DefaultIniOptions()); err != nil || len(loaded) != 0 {
+		t.Errorf("Expected empty result")
+	}
+}
+
+func TestLoadAndValidate(t *testing.T) {
+
+	rejected := errors.New("missing required property")
+
+	requireSection := func(ic *IniConfig) error {
+
+		if !ic.PropertyExists("Section1", "name1") {
+			return rejected
+		}
+
+		return nil
+	}
+
+	ic, err := LoadAndValidate(simplePath(), requireSection, DefaultIniOptions())
+
+	if err != nil || ic == nil {
+		t.Fatalf("Expected file to pass validation, got %v", err)
+	}
+
+	ic, err = LoadAndValidate(simplePath(), func(*IniConfig) error { return rejected }, DefaultIniOptions())
+
+	if ic != nil || !errors.Is(err, ErrValidationFailed) || !errors.Is(err, rejected) {
+		t.Errorf("Expected validation failure wrapping the validator's error, got %v", err)
+	}
+
+	if _, err = LoadAndValidate(filepath.Join(testfiles_base, "unparseable-lines.ini"), requireSection, DefaultIniOptions()); err == nil || errors.Is(err, ErrValidationFailed) {
+		t.Errorf("Expected parse error, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package inifile
+
+import (
+	"os"
+)
+
+// LockSuffix is appended to the path of a file to create the path of the lock file used by EditLocked
+const LockSuffix = ".lock"
+
+// EditLocked performs a read-modify-write cycle on the INI file at the supplied path while holding an advisory lock, so
+// that multiple processes editing the same file do not overwrite each other's changes. The file is parsed with the supplied
+// options (if it does not exist, edit receives an empty IniConfig) and passed to edit.
+//
+// If edit returns nil, the properties edit added, changed or deleted are written back to the file in the same way as
+// UpdateFile and RemoveFromFile, so comments, blank lines and the layout of the rest of the file are kept. Only values
+// are written back: changes edit makes to the order of properties or sections, inline comments or defaults are not, and
+// the header of a section whose properties were all deleted is left in place. The file is replaced atomically (see
+// SaveAtomic), keeping its existing permissions.
+//
+// The lock is held on a separate file (path + LockSuffix) which is left in place afterwards. All processes editing the file
+// must use EditLocked (or lock the same file) for the lock to be effective. Locking is only supported on Unix-like
+// platforms; on other platforms an error is returned.
+func EditLocked(path string, options *IniOptions, edit func(*IniConfig) error) error {
+
+	if options == nil {
+		return errorf("Nil IniOptions provided")
+	}
+
+	lf, err := os.OpenFile(path+LockSuffix, os.O_RDWR|os.O_CREATE, 0600)
+
+	if err != nil {
+		return err
+	}
+
+	defer lf.Close()
+
+	if err := lockFile(lf); err != nil {
+		return errorf("Unable to lock %s: %w", path, err)
+	}
+
+	defer unlockFile(lf)
+
+	var ic *IniConfig
+	var src []byte
+	perm := os.FileMode(0644)
+
+	if fi, err := os.Stat(path); err == nil {
+		perm = fi.Mode().Perm()
+
+		if ic, err = NewIniConfigFromPathWithOptions(path, options); err != nil {
+			return err
+		}
+
+		if src, err = os.ReadFile(path); err != nil {
+			return err
+		}
+
+	} else if os.IsNotExist(err) {
+		ic = newIniConfigFromMap(nil, options)
+	} else {
+		return err
+	}
+
+	before := ic.storedValues()
+
+	if err := edit(ic); err != nil {
+		return err
+	}
+
+	changes, removals := ic.editsSince(before)
+
+	updated, err := editContent(src, changes, removals, options)
+
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, updated, perm, false)
+}
+
+// storedValues returns a copy of the raw value of every property, keyed by section and property name.
+func (ic *IniConfig) storedValues() map[string]map[string]nilableString {
+
+	values := make(map[string]map[string]nilableString, len(ic.sections))
+
+	for section, properties := range ic.sections {
+
+		values[section] = make(map[string]nilableString, len(properties))
+
+		for property, pv := range properties {
+			values[section][property] = pv.nilableString
+		}
+	}
+
+	return values
+}
+
+// editsSince compares the raw values of this IniConfig with values previously returned by storedValues, returning the
+// properties that have been added or changed since and the properties that have been deleted.
+func (ic *IniConfig) editsSince(before map[string]map[string]nilableString) (map[string]map[string]string, map[string][]string) {
+
+	changes := make(map[string]map[string]string)
+	removals := make(map[string][]string)
+
+	for section, properties := range ic.sections {
+
+		for property, pv := range properties {
+
+			if old, found := before[section][property]; found && old == pv.nilableString {
+				continue
+			}
+
+			if changes[section] == nil {
+				changes[section] = make(map[string]string)
+			}
+
+			changes[section][property] = pv.String()
+		}
+	}
+
+	for section, properties := range before {
+
+		for property := range properties {
+
+			if _, found := ic.sections[section][property]; !found {
+				removals[section] = append(removals[section], property)
+			}
+		}
+	}
+
+	return changes, removals
+}
@@ -0,0 +1,17 @@
+//go:build !unix
+
+package inifile
+
+import (
+	"os"
+)
+
+// lockFile is not supported on this platform
+func lockFile(f *os.File) error {
+	return errorf("File locking is not supported on this platform")
+}
+
+// unlockFile is not supported on this platform
+func unlockFile(f *os.File) error {
+	return nil
+}
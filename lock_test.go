@@ -0,0 +1,79 @@
+//go:build unix
+
+package inifile
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestEditLocked(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "counter.ini")
+
+	increment := func(ic *IniConfig) error {
+		n := ic.ValueOrZeroAsInt64("counter", "value")
+		ic.Add("counter", "value", strconv.FormatInt(n+1, 10))
+
+		return nil
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if err := EditLocked(path, DefaultIniOptions(), increment); err != nil {
+				t.Errorf("Unexpected error %s", err.Error())
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	ic, err := NewIniConfigFromPath(path)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if v := ic.ValueOrZeroAsInt64("counter", "value"); v != 20 {
+		t.Errorf("Expected every edit to be applied, counter was %d", v)
+	}
+
+	if err := EditLocked(path, DefaultIniOptions(), func(ic *IniConfig) error { return errorf("Abandon") }); err == nil {
+		t.Errorf("Expected error from edit to be returned")
+	}
+}
+
+func TestEditLockedKeepsLayout(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "layout.ini")
+	os.WriteFile(path, []byte("; Settings\n[db]\nhost = localhost ;Primary\nuser=admin\n\nport=5432\n"), 0600)
+
+	err := EditLocked(path, DefaultIniOptions(), func(ic *IniConfig) error {
+		ic.Add("db", "port", "5433")
+		ic.Delete("db", "user")
+		ic.Add("cache", "size", "10")
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if b, _ := os.ReadFile(path); string(b) != "; Settings\n[db]\nhost = localhost ;Primary\n\nport=5433\n\n[cache]\nsize=10\n" {
+		t.Errorf("Expected only the edited lines to change, got:\n%s", b)
+	}
+
+	if err := EditLocked(filepath.Join(t.TempDir(), "missing.ini"), nil, func(ic *IniConfig) error { return nil }); err == nil {
+		t.Errorf("Expected nil options to be rejected")
+	}
+}
@@ -0,0 +1,25 @@
+//go:build unix
+
+package inifile
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile blocks until an exclusive advisory lock is held on the supplied file
+func lockFile(f *os.File) error {
+
+	for {
+		err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+// unlockFile releases a lock obtained with lockFile
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,85 @@
+package inifile
+
+import "strings"
+
+// Lookup returns the value of the specified property in the specified section and true, or an empty string and false if
+// the section or property does not exist or its value could not be retrieved (for example if it could not be decrypted).
+//
+// Unlike Value and ValueOrZero, no error is created when the property does not exist, making Lookup suitable for code
+// that probes for optional properties at high frequency.
+func (ic *IniConfig) Lookup(sectionName, propertyName string) (string, bool) {
+
+	if !ic.PropertyExists(sectionName, propertyName) {
+		ic.lookupMissed(sectionName, propertyName)
+		return "", false
+	}
+
+	v, err := ic.Value(sectionName, propertyName)
+
+	return v, err == nil
+}
+
+// LookupInt64 returns the value of the specified property as an int64 and true, or 0 and false if the property does not
+// exist or could not be converted to an int64 (see Lookup).
+func (ic *IniConfig) LookupInt64(sectionName, propertyName string) (int64, bool) {
+
+	if !ic.PropertyExists(sectionName, propertyName) {
+		ic.lookupMissed(sectionName, propertyName)
+		return 0, false
+	}
+
+	v, err := ic.ValueAsInt64(sectionName, propertyName)
+
+	return v, err == nil
+}
+
+// LookupUint64 returns the value of the specified property as a uint64 and true, or 0 and false if the property does not
+// exist or could not be converted to a uint64 (see Lookup).
+func (ic *IniConfig) LookupUint64(sectionName, propertyName string) (uint64, bool) {
+
+	if !ic.PropertyExists(sectionName, propertyName) {
+		ic.lookupMissed(sectionName, propertyName)
+		return 0, false
+	}
+
+	v, err := ic.ValueAsUint64(sectionName, propertyName)
+
+	return v, err == nil
+}
+
+// LookupFloat64 returns the value of the specified property as a float64 and true, or 0 and false if the property does
+// not exist or could not be converted to a float64 (see Lookup).
+func (ic *IniConfig) LookupFloat64(sectionName, propertyName string) (float64, bool) {
+
+	if !ic.PropertyExists(sectionName, propertyName) {
+		ic.lookupMissed(sectionName, propertyName)
+		return 0, false
+	}
+
+	v, err := ic.ValueAsFloat64(sectionName, propertyName)
+
+	return v, err == nil
+}
+
+// LookupBool returns the value of the specified property as a bool and true, or false and false if the property does
+// not exist or could not be converted to a bool (see Lookup).
+func (ic *IniConfig) LookupBool(sectionName, propertyName string) (bool, bool) {
+
+	if _, negated := ic.negation(sectionName, propertyName); !negated && !ic.PropertyExists(sectionName, propertyName) {
+		ic.lookupMissed(sectionName, propertyName)
+		return false, false
+	}
+
+	v, err := ic.ValueAsBool(sectionName, propertyName)
+
+	return v, err == nil
+}
+
+// HasValue returns true if the specified property exists and its value is not empty or made up only of whitespace. This
+// distinguishes a property that is present but blank (key=) from one that has a usable value.
+func (ic *IniConfig) HasValue(sectionName, propertyName string) bool {
+
+	v, found := ic.Lookup(sectionName, propertyName)
+
+	return found && strings.TrimSpace(v) != ""
+}
@@ -0,0 +1,71 @@
+package inifile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+
+	ic, err := NewIniConfigFromPath(typesPath())
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if v, found := ic.Lookup("int", "positive"); !found || v != "4" {
+		t.Errorf("Unexpected result %s %v", v, found)
+	}
+
+	if _, found := ic.Lookup("int", "missing"); found {
+		t.Errorf("Did not expect missing property to be found")
+	}
+
+	if _, found := ic.Lookup("missing", "positive"); found {
+		t.Errorf("Did not expect property in missing section to be found")
+	}
+
+	if v, found := ic.LookupInt64("int", "negative"); !found || v != -1 {
+		t.Errorf("Unexpected result %d %v", v, found)
+	}
+
+	if _, found := ic.LookupInt64("int", "string"); found {
+		t.Errorf("Did not expect unconvertible value to be found")
+	}
+
+	if _, found := ic.LookupUint64("uint", "negative"); found {
+		t.Errorf("Did not expect unconvertible value to be found")
+	}
+
+	if v, found := ic.LookupFloat64("float", "negative"); !found || v != -2.3333 {
+		t.Errorf("Unexpected result %f %v", v, found)
+	}
+
+	is, _ := ic.Section("Boolean")
+
+	if v, found := is.LookupBool("value1"); !found || !v {
+		t.Errorf("Unexpected result %v %v", v, found)
+	}
+
+	if _, found := is.LookupBool("missing"); found {
+		t.Errorf("Did not expect missing property to be found")
+	}
+}
+
+func TestHasValue(t *testing.T) {
+
+	o := DefaultIniOptions()
+	o.StripEnclosingQuotes = true
+
+	ic, err := newIniConfigFromReader(strings.NewReader("[s]\nblank=\nspaces=\"  \"\nset=x\n"), "blank.ini", o)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	for property, expected := range map[string]bool{"blank": false, "spaces": false, "set": true, "missing": false} {
+		if ic.HasValue("s", property) != expected {
+			t.Errorf("Expected HasValue for %s to be %v", property, expected)
+		}
+	}
+}
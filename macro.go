@@ -0,0 +1,54 @@
+package inifile
+
+import (
+	"strings"
+)
+
+// expandMacros replaces each %-macro in the value with the result of passing it to the MacroExpander in the IniOptions.
+// A macro is either a single character (%U) or an environment variable reference of the form %$(NAME).
+func (ic *IniConfig) expandMacros(sectionName, propertyName, value string) (string, error) {
+
+	if strings.IndexByte(value, '%') < 0 {
+		return value, nil
+	}
+
+	expander := ic.options.MacroExpander
+
+	var b strings.Builder
+
+	for i := 0; i < len(value); i++ {
+
+		c := value[i]
+
+		if c != '%' || i == len(value)-1 {
+			b.WriteByte(c)
+			continue
+		}
+
+		macro := value[i+1 : i+2]
+
+		if macro == "%" {
+			b.WriteByte('%')
+			i++
+			continue
+		}
+
+		if macro == "$" && strings.HasPrefix(value[i+2:], "(") {
+
+			if end := strings.IndexByte(value[i:], ')'); end > 0 {
+				macro = value[i+1 : i+end+1]
+			}
+		}
+
+		expanded, err := expander(sectionName, propertyName, macro)
+
+		if err != nil {
+			return "", errorf("Unable to expand %%%s in [%s].%s: %w", macro, sectionName, propertyName, err)
+		}
+
+		b.WriteString(expanded)
+		i += len(macro)
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,47 @@
+package inifile
+
+import (
+	"path"
+	"regexp"
+)
+
+// Match returns the names and values of the properties in this section whose names match the supplied glob pattern
+// (using the syntax of path.Match, e.g. handler.* or route_?), for selecting related properties such as the settings
+// of plugins. If CaseSensitive is false in the IniOptions, the pattern is matched case-insensitively. Values are
+// retrieved with ValueOrZero.
+//
+// Returns an empty map if no properties match or the pattern is malformed.
+func (is *IniSection) Match(glob string) map[string]string {
+
+	glob = is.ic.normalise(glob)
+
+	return is.matching(func(name string) bool {
+		matched, _ := path.Match(glob, name)
+		return matched
+	})
+}
+
+// MatchRegexp returns the names and values of the properties in this section whose names match the supplied regular
+// expression. Values are retrieved with ValueOrZero. If CaseSensitive is false in the IniOptions, names are matched in
+// lower case, so the expression should use lower case or the (?i) flag.
+//
+// Returns an empty map if no properties match.
+func (is *IniSection) MatchRegexp(re *regexp.Regexp) map[string]string {
+	return is.matching(re.MatchString)
+}
+
+// matching returns the names and values of the properties in this section whose names are accepted by the supplied
+// function.
+func (is *IniSection) matching(accept func(name string) bool) map[string]string {
+
+	matches := make(map[string]string)
+
+	for _, name := range is.PropertyNames() {
+
+		if accept(name) {
+			matches[name] = is.ValueOrZero(name)
+		}
+	}
+
+	return matches
+}
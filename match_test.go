@@ -0,0 +1,50 @@
+package inifile
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+
+	ic := NewIniConfigFromMap(map[string]map[string]string{"plugins": {
+		"handler.auth":  "jwt",
+		"handler.cache": "redis",
+		"route_a":       "/a",
+		"route_bb":      "/bb",
+		"timeout":       "5s",
+	}})
+
+	is, _ := ic.Section("plugins")
+
+	if m := is.Match("handler.*"); len(m) != 2 || m["handler.auth"] != "jwt" || m["handler.cache"] != "redis" {
+		t.Errorf("Unexpected matches %v", m)
+	}
+
+	if m := is.Match("route_?"); len(m) != 1 || m["route_a"] != "/a" {
+		t.Errorf("Unexpected matches %v", m)
+	}
+
+	if m := is.Match("[bad"); len(m) != 0 {
+		t.Errorf("Expected malformed pattern to match nothing")
+	}
+
+	if m := is.MatchRegexp(regexp.MustCompile(`^route_\w+$`)); len(m) != 2 || m["route_bb"] != "/bb" {
+		t.Errorf("Unexpected matches %v", m)
+	}
+}
+
+func TestMatchCaseInsensitive(t *testing.T) {
+
+	opts := DefaultIniOptions()
+	opts.CaseSensitive = false
+
+	ic, _ := newIniConfigFromReader(strings.NewReader("[plugins]\nHandler.Auth=jwt\nOther=x\n"), "test", opts)
+
+	is, _ := ic.Section("plugins")
+
+	if m := is.Match("HANDLER.*"); len(m) != 1 || m["handler.auth"] != "jwt" {
+		t.Errorf("Unexpected matches %v", m)
+	}
+}
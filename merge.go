@@ -0,0 +1,193 @@
+package inifile
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MergeStrategy controls how properties from one IniConfig are combined with those already in another.
+type MergeStrategy int
+
+const (
+	// Properties from the merged IniConfig replace existing properties with the same name
+	MergeOverride MergeStrategy = iota
+
+	// Existing properties are kept and properties with the same name in the merged IniConfig are ignored
+	MergeKeepExisting
+
+	// A section in the merged IniConfig replaces the whole of the existing section with the same name
+	MergeReplaceSections
+
+	// An error is returned if a property in the merged IniConfig already exists
+	MergeErrorOnConflict
+)
+
+// Merge adds the sections and properties of other to this IniConfig, resolving properties that exist in both according
+// to the supplied strategy. The origin of each merged value (see Dump) is preserved. Properties and sections removed
+// from other by tombstones (see TombstoneValue and TombstoneSectionPrefix in IniOptions) are first removed from this
+// IniConfig.
+//
+// If an error is returned (only possible with MergeErrorOnConflict), properties merged before the conflict was found
+// remain in this IniConfig.
+func (ic *IniConfig) Merge(other *IniConfig, strategy MergeStrategy) error {
+
+	if err := other.loadAllSections(); err != nil {
+		return err
+	}
+
+	ic.applyTombstones(other)
+
+	for _, section := range other.writeOrder() {
+
+		target := ic.normalise(section)
+
+		if strategy == MergeReplaceSections {
+			ic.deleteSection(target)
+		}
+
+		for _, property := range other.propertyOrder[section] {
+
+			exists := ic.stored(target, property)
+
+			if exists && strategy == MergeKeepExisting {
+				continue
+			}
+
+			if exists && strategy == MergeErrorOnConflict {
+				return errorf("Property [%s].%s is defined more than once", section, property)
+			}
+
+			pv := other.sections[section][property]
+
+			ic.Add(target, property, pv.String())
+
+			//Preserve where the value came from
+			stored := ic.sections[target][ic.normalise(property)]
+			stored.nilableString = pv.nilableString
+			stored.line = pv.line
+
+			if pv.line > 0 {
+				stored.source = ic.sourceIndex(other.sources[pv.source])
+			}
+
+			ic.sections[target][ic.normalise(property)] = stored
+		}
+	}
+
+	ic.warnings = append(ic.warnings, other.warnings...)
+	ic.shadowed = append(ic.shadowed, other.shadowed...)
+
+	return nil
+}
+
+// LoadDir parses every file matching the supplied pattern in lexical order of path, merging them into a single IniConfig
+// using the supplied strategy. This mirrors the way many Unix daemons read drop-in configuration directories such as
+// /etc/myapp/conf.d/*.ini.
+//
+// The pattern uses the syntax of filepath.Match with the addition that a ** path segment matches any number of
+// directories, so /etc/myapp/**/*.ini matches .ini files anywhere below /etc/myapp. An error is returned if no files match
+// the pattern or any file cannot be parsed.
+func LoadDir(pattern string, options *IniOptions, strategy MergeStrategy) (*IniConfig, error) {
+
+	paths, err := expandPattern(pattern)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(paths) == 0 {
+		return nil, errorf("No files match %s", pattern)
+	}
+
+	merged := newIniConfigFromMap(nil, options)
+
+	for _, path := range paths {
+
+		ic, err := NewIniConfigFromPathWithOptions(path, options)
+
+		if err != nil {
+			return nil, errorf("Unable to load %s: %w", path, err)
+		}
+
+		if err := merged.Merge(ic, strategy); err != nil {
+			return nil, errorf("Unable to merge %s: %w", path, err)
+		}
+	}
+
+	return merged, nil
+}
+
+// expandPattern finds the files matching a glob pattern that may contain ** segments, sorted by path.
+func expandPattern(pattern string) ([]string, error) {
+
+	pattern = filepath.Clean(pattern)
+	sep := string(filepath.Separator)
+
+	segments := strings.Split(pattern, sep)
+
+	recursiveAt := -1
+
+	for i, segment := range segments {
+		if segment == "**" {
+			recursiveAt = i
+			break
+		}
+	}
+
+	if recursiveAt < 0 {
+		paths, err := filepath.Glob(pattern)
+		sort.Strings(paths)
+
+		return paths, err
+	}
+
+	root := strings.Join(segments[:recursiveAt], sep)
+	rest := segments[recursiveAt+1:]
+
+	if root == "" && strings.HasPrefix(pattern, sep) {
+		root = sep
+	} else if root == "" {
+		root = "."
+	}
+
+	var paths []string
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+
+		if err != nil {
+			return err
+		}
+
+		relSegments := strings.Split(rel, sep)
+
+		if len(relSegments) < len(rest) {
+			return nil
+		}
+
+		tail := strings.Join(relSegments[len(relSegments)-len(rest):], sep)
+
+		if matched, err := filepath.Match(strings.Join(rest, sep), tail); err != nil {
+			return err
+		} else if matched {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+
+	sort.Strings(paths)
+
+	return paths, err
+}
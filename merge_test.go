@@ -0,0 +1,84 @@
+package inifile
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadDir(t *testing.T) {
+
+	dir := filepath.Join(testfiles_base, "conf.d")
+
+	ic, err := LoadDir(filepath.Join(dir, "*.ini"), DefaultIniOptions(), MergeOverride)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if v, _ := ic.Value("server", "port"); v != "8080" {
+		t.Errorf("Expected later file to override, was %s", v)
+	}
+
+	if v, _ := ic.Value("server", "user"); v != "nobody" {
+		t.Errorf("Expected property from earlier file, was %s", v)
+	}
+
+	if ic.PropertyExists("server", "host") {
+		t.Errorf("Did not expect nested file to be loaded")
+	}
+
+	var b bytes.Buffer
+	ic.Dump(&b, DumpOptions{ShowOrigin: true})
+
+	if !strings.Contains(b.String(), "20-override.ini:2") || !strings.Contains(b.String(), "10-base.ini:3") {
+		t.Errorf("Expected origins of merged values, found:\n%s", b.String())
+	}
+
+	ic, err = LoadDir(filepath.Join(dir, "**", "*.ini"), DefaultIniOptions(), MergeKeepExisting)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if v, _ := ic.Value("server", "port"); v != "80" {
+		t.Errorf("Expected earlier file to be kept, was %s", v)
+	}
+
+	if v, _ := ic.Value("server", "host"); v != "example.com" {
+		t.Errorf("Expected nested file to be loaded, was %s", v)
+	}
+
+	ic, _ = LoadDir(filepath.Join(dir, "*.ini"), DefaultIniOptions(), MergeReplaceSections)
+
+	if ic.PropertyExists("server", "user") {
+		t.Errorf("Expected section to be replaced")
+	}
+
+	if _, err := LoadDir(filepath.Join(dir, "*.ini"), DefaultIniOptions(), MergeErrorOnConflict); err == nil {
+		t.Errorf("Expected conflict to fail")
+	}
+
+	if _, err := LoadDir(filepath.Join(dir, "*.missing"), DefaultIniOptions(), MergeOverride); err == nil {
+		t.Errorf("Expected no matches to fail")
+	}
+}
+
+func TestMergeReplaceSections(t *testing.T) {
+
+	ic := NewIniConfigFromMap(map[string]map[string]string{"s": {"a": "1", "b": "2", "c": "3"}, "t": {"x": "1"}})
+	other := NewIniConfigFromMap(map[string]map[string]string{"s": {"z": "26"}})
+
+	if err := ic.Merge(other, MergeReplaceSections); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	section, _ := ic.Section("s")
+
+	if names := section.PropertyNames(); len(names) != 1 || names[0] != "z" {
+		t.Errorf("Expected section to only contain the merged property, got %v", names)
+	}
+
+	checkValue(t, ic, "t", "x", "1")
+}
@@ -0,0 +1,42 @@
+package inifile
+
+import (
+	"time"
+)
+
+// Metrics is implemented by types that record measurements of parsing and configuration access. It is intended to be
+// easily adapted to whichever metrics system (e.g. Prometheus or expvar) your application uses. Methods may be called
+// from multiple goroutines, so implementations must be safe for concurrent use.
+type Metrics interface {
+	// ParseCompleted records that content from the named source (usually a file path) was parsed, how long parsing took
+	// and the error that caused it to fail (or nil).
+	ParseCompleted(source string, duration time.Duration, err error)
+
+	// CacheLookup records whether a ConfigCache was able to return a previously parsed file (hit) or had to parse it.
+	CacheLookup(path string, hit bool)
+
+	// ConversionFailed records that the value of a property could not be converted to the requested type. The error
+	// wraps ErrConversion.
+	ConversionFailed(section, property string, err error)
+
+	// LookupMissed records a request for a property (or, if property is empty, a section) that does not exist.
+	LookupMissed(section, property string)
+}
+
+// conversionFailed passes a conversion error to the Metrics in the IniOptions, if set, and returns it.
+func (ic *IniConfig) conversionFailed(sectionName, propertyName string, err error) error {
+
+	if m := ic.options.Metrics; m != nil {
+		m.ConversionFailed(sectionName, propertyName, err)
+	}
+
+	return err
+}
+
+// lookupMissed tells the Metrics in the IniOptions, if set, about a request for a missing section or property.
+func (ic *IniConfig) lookupMissed(sectionName, propertyName string) {
+
+	if m := ic.options.Metrics; m != nil {
+		m.LookupMissed(sectionName, propertyName)
+	}
+}
@@ -0,0 +1,116 @@
+package inifile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingMetrics struct {
+	mu          sync.Mutex
+	parses      int
+	parseErrors int
+	hits        int
+	misses      int
+	conversions []string
+	missed      []string
+}
+
+func (rm *recordingMetrics) ParseCompleted(source string, duration time.Duration, err error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.parses++
+
+	if err != nil {
+		rm.parseErrors++
+	}
+}
+
+func (rm *recordingMetrics) CacheLookup(path string, hit bool) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if hit {
+		rm.hits++
+	} else {
+		rm.misses++
+	}
+}
+
+func (rm *recordingMetrics) ConversionFailed(section, property string, err error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if errors.Is(err, ErrConversion) {
+		rm.conversions = append(rm.conversions, section+"."+property)
+	}
+}
+
+func (rm *recordingMetrics) LookupMissed(section, property string) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.missed = append(rm.missed, section+"."+property)
+}
+
+func TestMetrics(t *testing.T) {
+
+	metrics := new(recordingMetrics)
+
+	opts := DefaultIniOptions()
+	opts.Metrics = metrics
+
+	ic, err := newIniConfigFromReader(strings.NewReader("[a]\nnumber=abc\nflag=maybe\n"), "metrics.ini", opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	newIniConfigFromReader(strings.NewReader("[a\n"), "broken.ini", opts)
+
+	if metrics.parses != 2 || metrics.parseErrors != 1 {
+		t.Errorf("Expected 2 parses and 1 failure, got %d and %d", metrics.parses, metrics.parseErrors)
+	}
+
+	ic.ValueAsInt64("a", "number")
+	ic.ValueAsFloat64("a", "number")
+	ic.ValueAsBool("a", "flag")
+
+	if strings.Join(metrics.conversions, ",") != "a.number,a.number,a.flag" {
+		t.Errorf("Unexpected conversion failures %v", metrics.conversions)
+	}
+
+	ic.Value("a", "missing")
+	ic.Lookup("a", "other")
+	ic.Section("b")
+
+	if strings.Join(metrics.missed, ",") != "a.missing,a.other,b." {
+		t.Errorf("Unexpected missed look-ups %v", metrics.missed)
+	}
+}
+
+func TestCacheMetrics(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "cached.ini")
+	os.WriteFile(path, []byte("[a]\nb=1\n"), 0600)
+
+	metrics := new(recordingMetrics)
+
+	opts := DefaultIniOptions()
+	opts.Metrics = metrics
+
+	cc, _ := NewConfigCache(1, opts)
+
+	cc.Get(path)
+	cc.Get(path)
+	cc.Get(path)
+
+	if metrics.hits != 2 || metrics.misses != 1 || metrics.parses != 1 {
+		t.Errorf("Expected 2 hits, 1 miss and 1 parse, got %d, %d and %d", metrics.hits, metrics.misses, metrics.parses)
+	}
+}
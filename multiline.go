@@ -0,0 +1,82 @@
+package inifile
+
+import (
+	"strings"
+)
+
+// A property that may be continued on the following indented lines.
+type continuation struct {
+	section  string
+	name     string
+	tagIndex int
+	line     int
+}
+
+// isIndented returns true if the untrimmed line starts with whitespace.
+func isIndented(raw string) bool {
+	return len(raw) > 0 && (raw[0] == ' ' || raw[0] == '\t')
+}
+
+// continueValue appends the (trimmed) text of a continuation line to the value of the property it continues, separated
+// by a newline. If the property was discarded because it had no value, it is added with the text as its value.
+func (ic *IniConfig) continueValue(c *continuation, tagged [][]taggedProperty, text string) {
+
+	if c.tagIndex == inactiveSection {
+		return
+	}
+
+	if c.tagIndex >= 0 {
+
+		properties := tagged[c.tagIndex]
+
+		if last := len(properties) - 1; last >= 0 && properties[last].line == c.line {
+			properties[last].value += "\n" + text
+			properties[last].null = false
+		} else {
+			tagged[c.tagIndex] = append(properties, taggedProperty{c.section, c.name, text, c.line, false})
+		}
+
+		return
+	}
+
+	section := ic.sections[ic.normalise(c.section)]
+	name := ic.normalise(c.name)
+
+	if pv, found := section[name]; found && pv.line == int32(c.line) {
+		pv.nilableString = newNilableString(pv.String() + "\n" + text)
+		section[name] = pv
+	} else {
+		ic.addFromLine(c.section, c.name, text, c.line)
+	}
+}
+
+// ValueAsLines returns the lines of a value that was continued on indented lines (see AllowContinuationLines in
+// IniOptions), as used for lists in Python's setup.cfg and tox.ini files:
+//
+//		[options]
+//		install_requires =
+//			requests
+//			click>=7
+//
+// Each line is trimmed of surrounding whitespace and empty lines are omitted.
+//
+// Returns an error if the section or property does not exist.
+func (ic *IniConfig) ValueAsLines(sectionName, propertyName string) ([]string, error) {
+
+	sv, err := ic.Value(sectionName, propertyName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []string{}
+
+	for _, line := range strings.Split(sv, "\n") {
+
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,47 @@
+package inifile
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestContinuationLines(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "setup.cfg")
+
+	options := DefaultIniOptions()
+	options.CommentStart = "#"
+	options.AllowContinuationLines = true
+	options.ActiveTags = []string{"linux"}
+
+	ic, err := NewIniConfigFromPathWithOptions(path, options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if v, _ := ic.Value("metadata", "description"); v != "A\nlonger description" {
+		t.Errorf("Unexpected value %q", v)
+	}
+
+	if v, _ := ic.ValueAsLines("options", "install_requires"); !reflect.DeepEqual(v, []string{"requests", "click>=7"}) {
+		t.Errorf("Unexpected lines %v", v)
+	}
+
+	if v, _ := ic.Value("options", "python_requires"); v != ">=3.8" {
+		t.Errorf("Unexpected value %s", v)
+	}
+
+	is, _ := ic.Section("options")
+
+	if v, _ := is.ValueAsLines("extras"); !reflect.DeepEqual(v, []string{"pyinotify"}) {
+		t.Errorf("Expected continuation in qualified section %v", v)
+	}
+
+	options.AllowContinuationLines = false
+
+	if _, err := NewIniConfigFromPathWithOptions(path, options); err == nil {
+		t.Errorf("Expected continuation lines to be unparseable without AllowContinuationLines")
+	}
+}
@@ -0,0 +1,111 @@
+package inifile
+
+import (
+	"strings"
+)
+
+// Namespace provides access to the sections of an IniConfig whose names start with a prefix, so that a library can keep
+// its configuration inside a host application's file without its section names colliding with the application's. With
+// the prefix myapp, the section db of the namespace is [myapp.db] in the file and the global section of the namespace
+// is [myapp].
+//
+// Call the Namespace(prefix) function on your IniConfig to obtain a Namespace.
+type Namespace struct {
+	prefix string
+	ic     *IniConfig
+}
+
+// Namespace returns a view of the sections whose names start with the supplied prefix followed by the SectionSeparator
+// in the IniOptions (or just the prefix if SectionSeparator is empty). Section names passed to the Namespace's methods
+// are relative to the prefix.
+func (ic *IniConfig) Namespace(prefix string) *Namespace {
+	return &Namespace{prefix: prefix, ic: ic}
+}
+
+// Prefix returns the prefix of the names of the sections in this namespace.
+func (ns *Namespace) Prefix() string {
+	return ns.prefix
+}
+
+// Namespace returns a namespace nested inside this one, e.g. calling Namespace("plugins") on the namespace myapp returns
+// the namespace myapp.plugins.
+func (ns *Namespace) Namespace(prefix string) *Namespace {
+	return &Namespace{prefix: ns.qualify(prefix), ic: ns.ic}
+}
+
+// qualify returns the full name of a section in this namespace.
+func (ns *Namespace) qualify(sectionName string) string {
+
+	if sectionName == GLOBAL_SECTION {
+		return ns.prefix
+	}
+
+	return ns.ic.childName(ns.prefix, sectionName)
+}
+
+// Section returns the section with the supplied name in this namespace (see IniConfig.Section). The returned IniSection
+// has the full name of the section.
+func (ns *Namespace) Section(sectionName string) (*IniSection, error) {
+	return ns.ic.Section(ns.qualify(sectionName))
+}
+
+// SectionExists returns true if this namespace contains a section with the supplied name (see IniConfig.SectionExists).
+func (ns *Namespace) SectionExists(sectionName string) bool {
+	return ns.ic.SectionExists(ns.qualify(sectionName))
+}
+
+// SectionNames returns the names, relative to the prefix, of the sections in this namespace that contain at least one
+// property, in the order of IniConfig.SectionNames. The section named after the prefix itself is returned as
+// GLOBAL_SECTION.
+func (ns *Namespace) SectionNames() []string {
+
+	ic := ns.ic
+	root := ic.normalise(ns.prefix)
+	prefix := ic.normalise(ic.childName(ns.prefix, ""))
+
+	var names []string
+
+	for _, name := range ic.SectionNames() {
+
+		switch {
+		case name == root:
+			names = append(names, GLOBAL_SECTION)
+		case strings.HasPrefix(name, prefix):
+			names = append(names, name[len(prefix):])
+		}
+	}
+
+	return names
+}
+
+// PropertyExists returns true if the section in this namespace contains the property (see IniConfig.PropertyExists).
+func (ns *Namespace) PropertyExists(sectionName, propertyName string) bool {
+	return ns.ic.PropertyExists(ns.qualify(sectionName), propertyName)
+}
+
+// Value returns the value of the property in the section in this namespace (see IniConfig.Value).
+func (ns *Namespace) Value(sectionName, propertyName string) (string, error) {
+	return ns.ic.Value(ns.qualify(sectionName), propertyName)
+}
+
+// Lookup returns the value of the property in the section in this namespace and whether it exists (see
+// IniConfig.Lookup).
+func (ns *Namespace) Lookup(sectionName, propertyName string) (string, bool) {
+	return ns.ic.Lookup(ns.qualify(sectionName), propertyName)
+}
+
+// ValueInto converts the property in the section in this namespace into the value pointed to by target (see
+// IniConfig.ValueInto).
+func (ns *Namespace) ValueInto(sectionName, propertyName string, target interface{}) error {
+	return ns.ic.ValueInto(ns.qualify(sectionName), propertyName, target)
+}
+
+// Add stores a property in the section in this namespace (see IniConfig.Add).
+func (ns *Namespace) Add(sectionName, propertyName, value string) {
+	ns.ic.Add(ns.qualify(sectionName), propertyName, value)
+}
+
+// SetDefault registers a default value for the property in the section in this namespace (see IniConfig.SetDefault).
+func (ns *Namespace) SetDefault(sectionName, propertyName, value string) {
+	ns.ic.SetDefault(ns.qualify(sectionName), propertyName, value)
+}
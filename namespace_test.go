@@ -0,0 +1,48 @@
+package inifile
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNamespace(t *testing.T) {
+
+	ic, _ := newIniConfigFromReader(strings.NewReader("[db]\nhost=app-db\n[myapp]\nname=lib\n[myapp.db]\nhost=lib-db\nport=5432\n[myapp.plugins.auth]\nkind=jwt\n[myappx]\na=b\n"), "test", DefaultIniOptions())
+
+	ns := ic.Namespace("myapp")
+
+	if v, err := ns.Value("db", "host"); err != nil || v != "lib-db" {
+		t.Errorf("Unexpected value %s %v", v, err)
+	}
+
+	if v, _ := ns.Value(GLOBAL_SECTION, "name"); v != "lib" {
+		t.Errorf("Unexpected global value %s", v)
+	}
+
+	var port int
+
+	if err := ns.ValueInto("db", "port", &port); err != nil || port != 5432 {
+		t.Errorf("Unexpected port %d %v", port, err)
+	}
+
+	if names := strings.Join(ns.SectionNames(), ","); names != ",db,plugins.auth" {
+		t.Errorf("Unexpected section names %q", names)
+	}
+
+	if v, found := ns.Namespace("plugins").Lookup("auth", "kind"); !found || v != "jwt" {
+		t.Errorf("Unexpected nested value %s", v)
+	}
+
+	if _, err := ns.Section("cache"); !errors.Is(err, ErrSectionNotFound) {
+		t.Errorf("Expected missing section to fail")
+	}
+
+	ns.Add("cache", "size", "10")
+
+	checkValue(t, ic, "myapp.cache", "size", "10")
+
+	if is, err := ns.Section("cache"); err != nil || is.Name() != "myapp.cache" {
+		t.Errorf("Unexpected section %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package inifile
+
+// negation returns the name of the property that negates the specified boolean property (see NegationPrefixes in
+// IniOptions) and true if one decides its value. A negating property decides the value if the property itself does not
+// exist or was set earlier: values parsed from sources read later, or from later lines of the same source, are later,
+// and values added at runtime are later than parsed values. If their order cannot be determined, the negating property
+// decides the value.
+func (ic *IniConfig) negation(sectionName, propertyName string) (string, bool) {
+
+	if len(ic.options.NegationPrefixes) == 0 {
+		return "", false
+	}
+
+	latest, found := ic.storedValue(sectionName, propertyName)
+	negation := ""
+
+	for _, prefix := range ic.options.NegationPrefixes {
+
+		if prefix == "" {
+			continue
+		}
+
+		candidate, exists := ic.storedValue(sectionName, prefix+propertyName)
+
+		if !exists {
+			continue
+		}
+
+		if !found || !candidate.setBefore(latest) {
+			latest, found, negation = candidate, true, prefix+propertyName
+		}
+	}
+
+	return negation, negation != ""
+}
+
+// setBefore returns true if this value is known to have been set before the other value.
+func (pv propertyValue) setBefore(other propertyValue) bool {
+
+	switch {
+	case pv.line == 0:
+		return false
+	case other.line == 0:
+		return true
+	case pv.source != other.source:
+		return pv.source < other.source
+	}
+
+	return pv.line < other.line
+}
@@ -0,0 +1,49 @@
+package inifile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNegationPrefixes(t *testing.T) {
+
+	dir := t.TempDir()
+
+	os.WriteFile(filepath.Join(dir, "10-base.ini"), []byte("[features]\ndebug=true\ncache=true\nno-trace=true\ntrace=true\n"), 0600)
+	os.WriteFile(filepath.Join(dir, "20-override.ini"), []byte("[features]\nno-debug=true\ndisable-metrics=true\n"), 0600)
+
+	opts := DefaultIniOptions()
+	opts.NegationPrefixes = []string{"no-", "disable-"}
+
+	ic, err := LoadDir(filepath.Join(dir, "*.ini"), opts, MergeOverride)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	expected := map[string]bool{"debug": false, "cache": true, "trace": true, "metrics": false}
+
+	for property, want := range expected {
+		if v, err := ic.ValueAsBool("features", property); err != nil || v != want {
+			t.Errorf("Expected %s to be %v, got %v %v", property, want, v, err)
+		}
+	}
+
+	if v, found := ic.LookupBool("features", "metrics"); !found || v {
+		t.Errorf("Expected LookupBool to find negated property")
+	}
+
+	ic.Add("features", "debug", "true")
+
+	if !ic.ValueOrZeroAsBool("features", "debug") {
+		t.Errorf("Expected value added at runtime to override parsed negation")
+	}
+
+	plain, _ := newIniConfigFromReader(strings.NewReader("[features]\ndebug=true\nno-debug=true\n"), "test", DefaultIniOptions())
+
+	if !plain.ValueOrZeroAsBool("features", "debug") {
+		t.Errorf("Expected negation to be ignored without NegationPrefixes")
+	}
+}
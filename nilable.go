@@ -1,12 +1,11 @@
 package inifile
 
+import "strings"
 
-// Create a new nilableString with the supplied value.
-func newNilableString(v string) *nilableString {
-	ns := new(nilableString)
-	ns.Set(v)
 
-	return ns
+// Create a new nilableString with the supplied value.
+func newNilableString(v string) nilableString {
+	return nilableString{val: v, set: true}
 }
 
 // A string where it can be determined if "" is an explicitly set value, or just the default zero value
@@ -23,11 +22,28 @@ func (ns *nilableString) Set(v string) {
 }
 
 // The currently stored value (whether or not it has been explicitly set).
-func (ns *nilableString) String() string {
+func (ns nilableString) String() string {
 	return ns.val
 }
 
 // See Nilable.IsSet
-func (ns *nilableString) IsSet() bool {
+func (ns nilableString) IsSet() bool {
 	return ns.set
-}
\ No newline at end of file
+}
+
+// A set of strings used to share a single copy of each distinct value
+type internTable map[string]string
+
+// intern returns a previously stored copy of the supplied string or stores and returns a copy of it. Storing a copy
+// means the returned string does not hold a reference to the (possibly much larger) string it was sliced from.
+func (it internTable) intern(s string) string {
+
+	if shared, found := it[s]; found {
+		return shared
+	}
+
+	shared := strings.Clone(s)
+	it[shared] = shared
+
+	return shared
+}
@@ -0,0 +1,52 @@
+package inifile
+
+// ValueOrNil returns the value of the specified property, or nil if its value in the file was one of the NullLiterals
+// in the IniOptions (meaning the property explicitly has no value). Returns an error if the section or property does not
+// exist (see Value).
+func (ic *IniConfig) ValueOrNil(sectionName, propertyName string) (*string, error) {
+
+	v, err := ic.Value(sectionName, propertyName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if pv, found := ic.storedValue(sectionName, propertyName); found && !pv.IsSet() {
+		return nil, nil
+	}
+
+	return &v, nil
+}
+
+// isNullLiteral returns true if the supplied (unquoted) value is one of the NullLiterals in the IniOptions.
+func (ic *IniConfig) isNullLiteral(value string) bool {
+
+	for _, literal := range ic.options.NullLiterals {
+		if value == literal {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parsedValue creates the stored form of a parsed value, which is unset if the value was a null literal.
+func parsedValue(value string, null bool) nilableString {
+
+	if null {
+		return nilableString{}
+	}
+
+	return newNilableString(value)
+}
+
+// writtenValue returns the text to write for a stored value, using the first of the NullLiterals in the IniOptions for a
+// value that is unset.
+func (ic *IniConfig) writtenValue(pv propertyValue) string {
+
+	if !pv.IsSet() && len(ic.options.NullLiterals) > 0 {
+		return ic.options.NullLiterals[0]
+	}
+
+	return pv.String()
+}
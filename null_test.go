@@ -0,0 +1,59 @@
+package inifile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNullLiterals(t *testing.T) {
+
+	o := DefaultIniOptions()
+	o.NullLiterals = []string{"null", "~"}
+	o.StripEnclosingQuotes = true
+
+	content := "[s]\nnothing=null\ntilde=~\nquoted=\"null\"\nset=x\n"
+
+	ic, err := newIniConfigFromReader(strings.NewReader(content), "null.ini", o)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	for _, property := range []string{"nothing", "tilde"} {
+
+		if v, err := ic.ValueOrNil("s", property); v != nil || err != nil {
+			t.Errorf("Expected %s to be nil (%v)", property, err)
+		}
+
+		if v, _ := ic.Value("s", property); v != "" {
+			t.Errorf("Expected Value of %s to be empty, got %s", property, v)
+		}
+
+		if ic.IsExplicitlySet("s", property) {
+			t.Errorf("Expected %s not to be explicitly set", property)
+		}
+	}
+
+	if v, err := ic.ValueOrNil("s", "quoted"); v == nil || *v != "null" || err != nil {
+		t.Errorf("Expected quoted literal to be a real value (%v)", err)
+	}
+
+	if is, _ := ic.Section("s"); is.ValueOrZero("set") != "x" {
+		t.Errorf("Expected set value to be x")
+	}
+
+	if _, err := ic.ValueOrNil("s", "missing"); err == nil {
+		t.Errorf("Expected error for missing property")
+	}
+
+	var b bytes.Buffer
+
+	if _, err := ic.WriteTo(&b); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if !strings.Contains(b.String(), "nothing=null") || !strings.Contains(b.String(), "tilde=null") {
+		t.Errorf("Expected null values to be written with the first literal, got\n%s", b.String())
+	}
+}
@@ -0,0 +1,35 @@
+package inifile
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// ErrInsecurePermissions is wrapped by errors returned when a file is not loaded because its permissions allow access
+// forbidden by ForbiddenPermissions in IniOptions.
+var ErrInsecurePermissions = fmt.Errorf("insecure file permissions")
+
+// checkPermissions returns an error if the permissions of the supplied file include any of the ForbiddenPermissions
+// in the IniOptions. Permissions are not checked on Windows, where Go does not report them.
+func checkPermissions(file *os.File, options *IniOptions) error {
+
+	forbidden := options.ForbiddenPermissions.Perm()
+
+	if forbidden == 0 || runtime.GOOS == "windows" {
+		return nil
+	}
+
+	fi, err := file.Stat()
+
+	if err != nil {
+		return err
+	}
+
+	if perm := fi.Mode().Perm(); perm&forbidden != 0 {
+		return wrapf(ErrInsecurePermissions, nil, "Refusing to load %s: its permissions (%04o) include %04o, which is not allowed (change them with chmod %04o %s)",
+			file.Name(), perm, perm&forbidden, perm&^forbidden, file.Name())
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package inifile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestForbiddenPermissions(t *testing.T) {
+
+	if runtime.GOOS == "windows" {
+		t.Skip("Permissions are not checked on Windows")
+	}
+
+	path := filepath.Join(t.TempDir(), "secrets.ini")
+	os.WriteFile(path, []byte("[db]\npassword=secret\n"), 0600)
+	os.Chmod(path, 0644)
+
+	opts := DefaultIniOptions()
+	opts.ForbiddenPermissions = 0077
+
+	_, err := NewIniConfigFromPathWithOptions(path, opts)
+
+	if !errors.Is(err, ErrInsecurePermissions) || !strings.Contains(err.Error(), "chmod 0600") {
+		t.Errorf("Expected world-readable file to be refused, got %v", err)
+	}
+
+	if _, err := NewIniConfigFromSourceWithOptions(NewFileSource(path), opts); !errors.Is(err, ErrInsecurePermissions) {
+		t.Errorf("Expected world-readable file Source to be refused, got %v", err)
+	}
+
+	os.Chmod(path, 0600)
+
+	if _, err := NewIniConfigFromSourceWithOptions(NewFileSource(path), opts); err != nil {
+		t.Errorf("Unexpected error %s", err.Error())
+	}
+
+	if _, err := NewIniConfigFromPathWithOptions(path, opts); err != nil {
+		t.Errorf("Unexpected error %s", err.Error())
+	}
+
+	opts.ForbiddenPermissions = os.ModeDir | 0077
+
+	if err := ValidateOptions(opts); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected non-permission bits to be invalid")
+	}
+}
@@ -0,0 +1,145 @@
+package inifile
+
+// The ways a section or property can be positioned by a Placement
+type placementMode int
+
+const (
+	placeLast placementMode = iota
+	placeFirst
+	placeAfter
+	placeSorted
+)
+
+// Placement describes where AddAt and PlaceSection position a property or section relative to the others, which
+// controls where it appears when the IniConfig is written (unless Sorted is set in the IniWriteOptions).
+type Placement struct {
+	mode  placementMode
+	after string
+}
+
+// PlaceFirst positions a property at the start of its section, or a section before every other named section (the
+// global section is always written first).
+func PlaceFirst() Placement {
+	return Placement{mode: placeFirst}
+}
+
+// PlaceLast positions a property at the end of its section, or a section after every other section. This is where Add
+// puts new properties and sections.
+func PlaceLast() Placement {
+	return Placement{mode: placeLast}
+}
+
+// PlaceAfter positions a property immediately after the named property in the same section, or a section immediately
+// after the named section.
+func PlaceAfter(name string) Placement {
+	return Placement{mode: placeAfter, after: name}
+}
+
+// PlaceSorted positions a property before the first property in its section whose name sorts after it, or a section
+// before the first section whose name sorts after it, leaving the others where they are. If the others are already in
+// alphabetical order, the result is too.
+func PlaceSorted() Placement {
+	return Placement{mode: placeSorted}
+}
+
+// AddAt stores a property in the named section like Add, then positions it within the section according to the
+// supplied Placement. A property that already exists is moved as well as having its value overwritten. A new section is
+// positioned after every other section; use PlaceSection to move it.
+//
+// Returns an error wrapping ErrPropertyNotFound if the Placement is PlaceAfter and the named property does not exist
+// in the section, in which case nothing is added.
+func (ic *IniConfig) AddAt(section, propertyName, value string, p Placement) error {
+
+	ic.loadSection(section)
+
+	normalised := ic.normalise(section)
+
+	if p.mode == placeAfter && !ic.stored(section, p.after) {
+		return wrapf(ErrPropertyNotFound, nil, "Cannot add [%s].%s after %s as %s does not exist", section, propertyName, p.after, p.after)
+	}
+
+	ic.Add(section, propertyName, value)
+
+	ic.propertyOrder[normalised] = ic.place(ic.propertyOrder[normalised], ic.normalise(propertyName), p)
+
+	return nil
+}
+
+// PlaceSection moves the named section to the position given by the supplied Placement, which controls where it is
+// written. Use it after adding the first property to a new section with Add to put the section next to related
+// sections. The global section is always written first and cannot be moved.
+//
+// Returns an error wrapping ErrSectionNotFound if the section (or the section named by PlaceAfter) does not exist.
+func (ic *IniConfig) PlaceSection(section string, p Placement) error {
+
+	if err := ic.loadAllSections(); err != nil {
+		return err
+	}
+
+	if section == GLOBAL_SECTION {
+		return errorf("The global section cannot be moved")
+	}
+
+	if ic.findSection(section) == nil {
+		ic.lookupMissed(section, "")
+		return wrapf(ErrSectionNotFound, nil, "Section %s does not exist", section)
+	}
+
+	if p.mode == placeAfter && p.after != GLOBAL_SECTION && ic.findSection(p.after) == nil {
+		ic.lookupMissed(p.after, "")
+		return wrapf(ErrSectionNotFound, nil, "Cannot place section %s after %s as %s does not exist", section, p.after, p.after)
+	}
+
+	if p.mode == placeAfter && p.after == GLOBAL_SECTION {
+		p = PlaceFirst()
+	}
+
+	ic.sectionOrder = ic.place(ic.sectionOrder, ic.normalise(section), p)
+
+	return nil
+}
+
+// place returns a copy of the supplied order with name moved to the position given by the Placement. A copy is
+// returned so that views of this IniConfig (see WithOptions) are not affected part way through the move.
+func (ic *IniConfig) place(order []string, name string, p Placement) []string {
+
+	others := make([]string, 0, len(order))
+
+	for _, candidate := range order {
+		if candidate != name {
+			others = append(others, candidate)
+		}
+	}
+
+	at := len(others)
+
+	switch p.mode {
+	case placeFirst:
+		at = 0
+	case placeAfter:
+
+		after := ic.normalise(p.after)
+
+		for i, candidate := range others {
+			if candidate == after {
+				at = i + 1
+				break
+			}
+		}
+
+	case placeSorted:
+
+		for i, candidate := range others {
+			if candidate > name {
+				at = i
+				break
+			}
+		}
+	}
+
+	placed := make([]string, 0, len(others)+1)
+	placed = append(placed, others[:at]...)
+	placed = append(placed, name)
+
+	return append(placed, others[at:]...)
+}
@@ -0,0 +1,87 @@
+package inifile
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAddAt(t *testing.T) {
+
+	ic, _ := newIniConfigFromReader(strings.NewReader("[db]\nhost=h\nuser=u\n"), "test", DefaultIniOptions())
+
+	if err := ic.AddAt("db", "port", "5432", PlaceAfter("host")); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if err := ic.AddAt("db", "driver", "pg", PlaceFirst()); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if err := ic.AddAt("db", "pool", "10", PlaceSorted()); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	is, _ := ic.Section("db")
+
+	if names := strings.Join(is.PropertyNames(), ","); names != "driver,host,pool,port,user" {
+		t.Errorf("Unexpected property order %s", names)
+	}
+
+	if err := ic.AddAt("db", "user", "admin", PlaceLast()); err != nil || strings.Join(is.PropertyNames(), ",") != "driver,host,pool,port,user" {
+		t.Errorf("Unexpected result of moving an existing property %v %v", err, is.PropertyNames())
+	}
+
+	checkValue(t, ic, "db", "user", "admin")
+
+	if err := ic.AddAt("db", "x", "y", PlaceAfter("missing")); !errors.Is(err, ErrPropertyNotFound) || ic.PropertyExists("db", "x") {
+		t.Errorf("Expected placing after a missing property to fail without adding")
+	}
+}
+
+func TestPlaceSection(t *testing.T) {
+
+	ic, _ := newIniConfigFromReader(strings.NewReader("g=1\n[app]\na=1\n[db]\nb=2\n[zoo]\nc=3\n"), "test", DefaultIniOptions())
+
+	ic.Add("db.replica", "host", "r")
+
+	if err := ic.PlaceSection("db.replica", PlaceAfter("db")); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	ic.Add("cache", "size", "1")
+
+	if err := ic.PlaceSection("cache", PlaceSorted()); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	ic.Add("first", "x", "1")
+
+	if err := ic.PlaceSection("first", PlaceFirst()); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if names := strings.Join(ic.SectionNames(), ","); names != ",first,app,cache,db,db.replica,zoo" {
+		t.Errorf("Unexpected section order %s", names)
+	}
+
+	var b bytes.Buffer
+	ic.WriteTo(&b)
+
+	if !strings.HasPrefix(b.String(), "g=1\n\n[first]\n") {
+		t.Errorf("Unexpected output %s", b.String())
+	}
+
+	if err := ic.PlaceSection("missing", PlaceFirst()); !errors.Is(err, ErrSectionNotFound) {
+		t.Errorf("Expected missing section to fail")
+	}
+
+	if err := ic.PlaceSection("app", PlaceAfter("missing")); !errors.Is(err, ErrSectionNotFound) {
+		t.Errorf("Expected missing anchor section to fail")
+	}
+
+	if err := ic.PlaceSection(GLOBAL_SECTION, PlaceLast()); err == nil {
+		t.Errorf("Expected moving the global section to fail")
+	}
+}
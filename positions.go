@@ -0,0 +1,259 @@
+package inifile
+
+import (
+	"bufio"
+	"sort"
+	"strings"
+)
+
+// ElementKind identifies the type of text an Element describes.
+type ElementKind int
+
+const (
+	// A section header, including its brackets
+	ElementSection ElementKind = iota
+	// The name of a property (including any type annotation)
+	ElementKey
+	// The value of a property (including any enclosing quotes) or one line of a continued value
+	ElementValue
+	// A comment, including its comment symbol
+	ElementComment
+)
+
+// Position is a location in parsed content.
+type Position struct {
+	// The number of bytes before the location in the content
+	Offset int
+
+	// The line of the location, starting at 1
+	Line int
+
+	// The byte position of the location within its line, starting at 1
+	Column int
+}
+
+// Span is a range of parsed content, from Start up to but not including End.
+type Span struct {
+	Start Position
+	End   Position
+}
+
+// Element describes where a section header, property name, value or comment was found in the parsed content.
+type Element struct {
+	Kind ElementKind
+	Span Span
+
+	// The section the element is part of (the section a header starts, for ElementSection)
+	Section string
+
+	// The property the element is part of (ElementKey and ElementValue only)
+	Property string
+}
+
+// Elements returns the location of every section header, property name, value and comment in the parsed content, in the
+// order they appear. Only available if RecordPositions was set in the IniOptions used to parse the content; lines
+// defined by a LineClassifier and properties in sections qualified with inactive tags are not included.
+func (ic *IniConfig) Elements() []Element {
+	return ic.elements
+}
+
+// ElementAt returns the element containing the supplied line and column (both starting at 1), or false if there is no
+// element at that position (see Elements).
+func (ic *IniConfig) ElementAt(line, column int) (Element, bool) {
+
+	i := sort.Search(len(ic.elements), func(i int) bool {
+		end := ic.elements[i].Span.End
+		return end.Line > line || (end.Line == line && end.Column > column)
+	})
+
+	if i < len(ic.elements) {
+
+		start := ic.elements[i].Span.Start
+
+		if start.Line == line && start.Column <= column {
+			return ic.elements[i], true
+		}
+	}
+
+	return Element{}, false
+}
+
+// lineOffsets records the offset of the start of the line most recently returned by a bufio.Scanner.
+type lineOffsets struct {
+	current int
+	next    int
+}
+
+// track wraps a split function so that the offset of each line is recorded.
+func (lo *lineOffsets) track(split bufio.SplitFunc) bufio.SplitFunc {
+
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+
+		advance, token, err := split(data, atEOF)
+
+		if token != nil {
+			lo.current = lo.next
+		}
+
+		lo.next += advance
+
+		return advance, token, err
+	}
+}
+
+// lineRecorder records the elements found on a single line. A nil lineRecorder (used when RecordPositions is not set)
+// records nothing.
+type lineRecorder struct {
+	ic         *IniConfig
+	raw        string
+	offset     int
+	lineNumber int
+}
+
+// record adds an element covering the bytes of the line from start up to (not including) end, ignoring empty elements.
+func (lr lineRecorder) record(kind ElementKind, start, end int, section, property string) {
+
+	if end <= start {
+		return
+	}
+
+	pos := func(col int) Position {
+		return Position{Offset: lr.offset + col, Line: lr.lineNumber, Column: col + 1}
+	}
+
+	e := Element{Kind: kind, Span: Span{pos(start), pos(end)}, Section: section, Property: property}
+
+	lr.ic.elements = append(lr.ic.elements, e)
+}
+
+// bounds returns the start of the first non-whitespace character in the line, the end of its content excluding any
+// inline comment and the start of the inline comment (or -1).
+func (lr lineRecorder) bounds() (int, int, int) {
+
+	start := len(lr.raw) - len(strings.TrimLeft(lr.raw, " \t"))
+	end := len(strings.TrimRight(lr.raw, " \t"))
+
+	c := lr.ic.inlineCommentIndex(lr.raw, start)
+
+	if c >= 0 {
+		end = len(strings.TrimRight(lr.raw[:c], " \t"))
+	}
+
+	return start, end, c
+}
+
+// inlineComment records the inline comment starting at the supplied position (if it is not -1).
+func (lr lineRecorder) inlineComment(c int, section string) {
+
+	if c >= 0 {
+		lr.record(ElementComment, c, len(strings.TrimRight(lr.raw, " \t")), section, "")
+	}
+}
+
+// comment records a line that is entirely a comment.
+func (lr *lineRecorder) comment(section string) {
+
+	if lr == nil {
+		return
+	}
+
+	start := len(lr.raw) - len(strings.TrimLeft(lr.raw, " \t"))
+
+	lr.record(ElementComment, start, len(strings.TrimRight(lr.raw, " \t")), section, "")
+}
+
+// section records a section header.
+func (lr *lineRecorder) section(section string) {
+
+	if lr == nil {
+		return
+	}
+
+	start, end, c := lr.bounds()
+
+	lr.record(ElementSection, start, end, section, "")
+	lr.inlineComment(c, section)
+}
+
+// property records the name and value of a property.
+func (lr *lineRecorder) property(section, property string) {
+
+	if lr == nil {
+		return
+	}
+
+	start, end, c := lr.bounds()
+
+	if assign := lr.ic.assignmentIndex(lr.raw[:end], start); assign >= 0 {
+
+		value := lr.raw[assign+1 : end]
+		valueStart := assign + 1 + len(value) - len(strings.TrimLeft(value, " \t"))
+
+		lr.record(ElementKey, start, len(strings.TrimRight(lr.raw[:assign], " \t")), section, property)
+		lr.record(ElementValue, valueStart, end, section, property)
+	}
+
+	lr.inlineComment(c, section)
+}
+
+// continuation records a line continuing the value of a property.
+func (lr *lineRecorder) continuation(section, property string) {
+
+	if lr == nil {
+		return
+	}
+
+	start, end, c := lr.bounds()
+
+	lr.record(ElementValue, start, end, section, property)
+	lr.inlineComment(c, section)
+}
+
+// inlineCommentIndex returns the position in the line of an inline comment starting at or after from, or -1 if there is
+// no inline comment or inline comments are not allowed.
+func (ic *IniConfig) inlineCommentIndex(line string, from int) int {
+
+	options := ic.options
+
+	if !options.AllowInlineComments || options.CommentStart == "" {
+		return -1
+	}
+
+	for i := from; i < len(line); i++ {
+
+		if options.CommentEscapePrefix != "" && strings.HasPrefix(line[i:], options.CommentEscapePrefix+options.CommentStart) {
+			i += len(options.CommentEscapePrefix)
+			continue
+		}
+
+		if strings.HasPrefix(line[i:], options.CommentStart) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// assignmentIndex returns the position in the line of the assignment symbol that ends the property name starting at
+// from, or -1 if there is none.
+func (ic *IniConfig) assignmentIndex(line string, from int) int {
+
+	assign := ic.assignmentSymbol()
+	quoted := false
+
+	for i := from; i < len(line); i++ {
+
+		c := line[i]
+
+		switch {
+		case ic.options.EscapedPropertyNames && c == '\\':
+			i++
+		case ic.options.EscapedPropertyNames && c == '"' && (i == from || quoted):
+			quoted = !quoted
+		case c == assign && !quoted:
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,50 @@
+package inifile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecordPositions(t *testing.T) {
+
+	o := DefaultIniOptions()
+	o.RecordPositions = true
+	o.AllowInlineComments = true
+	o.AllowContinuationLines = true
+
+	content := "; Header\r\n[db] ;Database\n  host = localhost ;Default\nlist=a\n  b\n"
+
+	ic, err := newIniConfigFromReader(strings.NewReader(content), "positions.ini", o)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	var found []string
+
+	for _, e := range ic.Elements() {
+
+		text := content[e.Span.Start.Offset:e.Span.End.Offset]
+		found = append(found, text)
+
+		if e.Span.End.Offset-e.Span.Start.Offset != e.Span.End.Column-e.Span.Start.Column {
+			t.Errorf("Offsets and columns of %s are inconsistent", text)
+		}
+	}
+
+	expected := "; Header|[db]|;Database|host|localhost|;Default|list|a|b"
+
+	if strings.Join(found, "|") != expected {
+		t.Errorf("Expected elements %s, got %s", expected, strings.Join(found, "|"))
+	}
+
+	e, found2 := ic.ElementAt(3, 10)
+
+	if !found2 || e.Kind != ElementValue || e.Section != "db" || e.Property != "host" || e.Span.Start.Column != 10 {
+		t.Errorf("Unexpected element at 3:10 %+v", e)
+	}
+
+	if _, found := ic.ElementAt(3, 1); found {
+		t.Errorf("Expected no element in indentation")
+	}
+}
@@ -0,0 +1,236 @@
+package inifile
+
+import (
+	"os"
+	"strings"
+)
+
+// KeyValueIniOptions returns an IniOptions object suitable for files containing only key=value lines with no sections,
+// such as /etc/os-release or /etc/sysctl.conf. It differs from DefaultIniOptions in that:
+//
+//		NoSections		true
+//		CommentStart	"#"
+//
+// All properties are stored in GLOBAL_SECTION.
+func KeyValueIniOptions() *IniOptions {
+	io := DefaultIniOptions()
+
+	io.NoSections = true
+	io.CommentStart = "#"
+
+	return io
+}
+
+// The locations of the os-release file, in the order they are checked (see os-release(5))
+var osReleasePaths = []string{"/etc/os-release", "/usr/lib/os-release"}
+
+// OSReleaseIniOptions returns an IniOptions object suitable for os-release files (see os-release(5)). It differs from
+// KeyValueIniOptions in that enclosing quotes are stripped from values.
+func OSReleaseIniOptions() *IniOptions {
+	io := KeyValueIniOptions()
+
+	io.StripEnclosingQuotes = true
+
+	return io
+}
+
+// LoadOSRelease parses the operating system identification file /etc/os-release (or /usr/lib/os-release if that file
+// does not exist), making values like ID and VERSION_ID available in GLOBAL_SECTION. Shell escape sequences in values
+// are not interpreted.
+func LoadOSRelease() (*IniConfig, error) {
+	return loadFirstExisting(osReleasePaths, OSReleaseIniOptions())
+}
+
+// loadFirstExisting parses the first of the supplied paths that exists.
+func loadFirstExisting(paths []string, options *IniOptions) (*IniConfig, error) {
+
+	for _, path := range paths {
+
+		if _, err := os.Stat(path); err == nil {
+			return NewIniConfigFromPathWithOptions(path, options)
+		}
+	}
+
+	return nil, errorf("None of %s exist", strings.Join(paths, ", "))
+}
+
+// SysctlIniOptions returns an IniOptions object suitable for sysctl.conf files (see sysctl.conf(5)). It differs from
+// KeyValueIniOptions in that blank values are kept and lines starting with ; are also comments.
+func SysctlIniOptions() *IniOptions {
+	io := KeyValueIniOptions()
+
+	io.DiscardPropertiesWithNoValue = false
+	io.ExtraCommentStarts = []string{";"}
+
+	return io
+}
+
+// LoadSysctl parses the sysctl.conf style file at the supplied path, making each kernel parameter available in
+// GLOBAL_SECTION under its dotted name (e.g. net.ipv4.ip_forward).
+func LoadSysctl(path string) (*IniConfig, error) {
+	return NewIniConfigFromPathWithOptions(path, SysctlIniOptions())
+}
+
+// SambaIniOptions returns an IniOptions object suitable for Samba's smb.conf (see smb.conf(5)). It differs from
+// DefaultIniOptions in that:
+//
+//		CaseSensitive			false
+//		CommentStart			"#"
+//		ExtraCommentStarts		[]string{";"}
+//
+// Property names containing spaces (guest account = nobody) are supported with any options. %-macros such as %U are
+// left in values unless a MacroExpander is also set.
+func SambaIniOptions() *IniOptions {
+	io := DefaultIniOptions()
+
+	io.CaseSensitive = false
+	io.CommentStart = "#"
+	io.ExtraCommentStarts = []string{";"}
+
+	return io
+}
+
+// WindowsIniOptions returns an IniOptions object that behaves like the Windows profile API (GetPrivateProfileString). It
+// differs from DefaultIniOptions in that:
+//
+//		CaseSensitive			false
+//		StripEnclosingQuotes	true
+func WindowsIniOptions() *IniOptions {
+	io := DefaultIniOptions()
+
+	io.CaseSensitive = false
+	io.StripEnclosingQuotes = true
+
+	return io
+}
+
+// MySQLIniOptions returns an IniOptions object suitable for MySQL and MariaDB option files such as my.cnf. It differs from
+// DefaultIniOptions in that:
+//
+//		CommentStart					"#"
+//		ExtraCommentStarts				[]string{";"}
+//		AllowInlineComments				true
+//		StripEnclosingQuotes			true
+//		DiscardPropertiesWithNoValue	false
+//
+// Options given without a value (skip-name-resolve) are stored with an empty value and !include and !includedir
+// directives are ignored.
+func MySQLIniOptions() *IniOptions {
+	io := DefaultIniOptions()
+
+	io.CommentStart = "#"
+	io.ExtraCommentStarts = []string{";"}
+	io.AllowInlineComments = true
+	io.StripEnclosingQuotes = true
+	io.DiscardPropertiesWithNoValue = false
+
+	valueless := valuelessKeys("", "#", ";")
+
+	io.LineClassifier = func(line string, lineNumber int) (ClassifiedLine, error) {
+
+		if strings.HasPrefix(strings.TrimSpace(line), "!") {
+			return ClassifiedLine{Kind: LineIgnored}, nil
+		}
+
+		return valueless(line, lineNumber)
+	}
+
+	return io
+}
+
+// SystemdIniOptions returns an IniOptions object suitable for systemd unit files (see systemd.syntax(7)). It differs
+// from DefaultIniOptions in that:
+//
+//		CommentStart					"#"
+//		ExtraCommentStarts				[]string{";"}
+//		AllowGlobalSection				false
+//		DiscardPropertiesWithNoValue	false
+//
+// Empty assignments (ExecStart=), which reset a list in systemd, are kept. Only the last assignment of a repeated
+// property is available with Value, but every assignment is recorded by ShadowedProperties.
+func SystemdIniOptions() *IniOptions {
+	io := DefaultIniOptions()
+
+	io.CommentStart = "#"
+	io.ExtraCommentStarts = []string{";"}
+	io.AllowGlobalSection = false
+	io.DiscardPropertiesWithNoValue = false
+
+	return io
+}
+
+// GitIniOptions returns an IniOptions object suitable for Git configuration files (see git-config(1)). It differs from
+// DefaultIniOptions in that:
+//
+//		CaseSensitive			false
+//		CommentStart			"#"
+//		ExtraCommentStarts		[]string{";"}
+//		AllowInlineComments		true
+//		StripEnclosingQuotes	true
+//
+// Variables given without a value are stored with the value true, as Git treats them. A subsection header like
+// [remote "origin"] is stored as a section with that name.
+func GitIniOptions() *IniOptions {
+	io := DefaultIniOptions()
+
+	io.CaseSensitive = false
+	io.CommentStart = "#"
+	io.ExtraCommentStarts = []string{";"}
+	io.AllowInlineComments = true
+	io.StripEnclosingQuotes = true
+	io.LineClassifier = valuelessKeys("true", "#", ";")
+
+	return io
+}
+
+// PythonIniOptions returns an IniOptions object suitable for files read by Python's configparser module with its
+// default settings. It differs from DefaultIniOptions in that:
+//
+//		CaseSensitive					false
+//		CommentStart					"#"
+//		ExtraCommentStarts				[]string{";"}
+//		DiscardPropertiesWithNoValue	false
+//		AllowContinuationLines			true
+//		Interpolate						true
+//
+// configparser also accepts : as the assignment symbol, which requires UseColonAssignment.
+func PythonIniOptions() *IniOptions {
+	io := DefaultIniOptions()
+
+	io.CaseSensitive = false
+	io.CommentStart = "#"
+	io.ExtraCommentStarts = []string{";"}
+	io.DiscardPropertiesWithNoValue = false
+	io.AllowContinuationLines = true
+	io.Interpolate = true
+
+	return io
+}
+
+// valuelessKeys returns a LineClassifier that treats lines containing only a name (no assignment symbol) as properties
+// with the supplied value.
+func valuelessKeys(value string, commentStarts ...string) func(line string, lineNumber int) (ClassifiedLine, error) {
+
+	return func(line string, lineNumber int) (ClassifiedLine, error) {
+
+		name := strings.TrimSpace(line)
+
+		for _, cs := range commentStarts {
+
+			if strings.HasPrefix(name, cs) {
+				return ClassifiedLine{}, nil
+			}
+
+			//Remove any inline comment
+			if i := strings.Index(name, cs); i >= 0 {
+				name = strings.TrimSpace(name[:i])
+			}
+		}
+
+		if name == "" || strings.HasPrefix(name, "[") || strings.ContainsRune(name, '=') {
+			return ClassifiedLine{}, nil
+		}
+
+		return ClassifiedLine{Kind: LineProperty, Name: name, Value: value}, nil
+	}
+}
@@ -0,0 +1,139 @@
+package inifile
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKeyValueIniOptions(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "key-value.conf")
+
+	ic, err := NewIniConfigFromPathWithOptions(path, KeyValueIniOptions())
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if names := ic.SectionNames(); len(names) != 1 || names[0] != GLOBAL_SECTION {
+		t.Errorf("Expected only the global section, found %v", names)
+	}
+
+	for property, expected := range map[string]string{"name": "value", "[not-a-section]": "bracketed", "list": "[a, b]"} {
+
+		if v, _ := ic.Value(GLOBAL_SECTION, property); v != expected {
+			t.Errorf("Expected %s for %s, was %s", expected, property, v)
+		}
+	}
+
+	b, _ := os.ReadFile(path)
+
+	lazy, err := NewLazyIniConfig(bytes.NewReader(b), int64(len(b)), KeyValueIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if v, _ := lazy.Value(GLOBAL_SECTION, "[not-a-section]"); v != "bracketed" {
+		t.Errorf("Expected lazy parsing to ignore sections, was %s", v)
+	}
+}
+
+func TestLoadOSRelease(t *testing.T) {
+
+	defer func(paths []string) { osReleasePaths = paths }(osReleasePaths)
+
+	osReleasePaths = []string{filepath.Join(testfiles_base, "missing"), filepath.Join(testfiles_base, "os-release")}
+
+	ic, err := LoadOSRelease()
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	for property, expected := range map[string]string{"NAME": "Example Linux", "ID": "example", "ID_LIKE": "debian ubuntu", "VERSION_ID": "1.0"} {
+
+		if v, _ := ic.Value(GLOBAL_SECTION, property); v != expected {
+			t.Errorf("Expected %s for %s, was %s", expected, property, v)
+		}
+	}
+
+	osReleasePaths = osReleasePaths[:1]
+
+	if _, err := LoadOSRelease(); err == nil {
+		t.Errorf("Expected missing file to fail")
+	}
+}
+
+func TestLoadSysctl(t *testing.T) {
+
+	ic, err := LoadSysctl(filepath.Join(testfiles_base, "sysctl.conf"))
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if v, _ := ic.ValueAsInt64(GLOBAL_SECTION, "net.ipv4.ip_forward"); v != 1 {
+		t.Errorf("Unexpected value %d", v)
+	}
+
+	if !ic.PropertyExists(GLOBAL_SECTION, "kernel.domainname") {
+		t.Errorf("Expected blank value to be kept")
+	}
+
+	if ic.PropertyExists(GLOBAL_SECTION, "vm.overcommit_memory") {
+		t.Errorf("Expected line starting with ; to be a comment")
+	}
+}
+
+func TestSambaIniOptions(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "smb.conf")
+
+	options := SambaIniOptions()
+
+	ic, err := NewIniConfigFromPathWithOptions(path, options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if v, _ := ic.Value("GLOBAL", "guest account"); v != "nobody" {
+		t.Errorf("Expected name with spaces to be found case-insensitively, was %s", v)
+	}
+
+	if ic.PropertyExists("global", "security") {
+		t.Errorf("Expected line starting with ; to be a comment")
+	}
+
+	if v, _ := ic.Value("homes", "path"); v != "%H" {
+		t.Errorf("Expected macro to be left in place, was %s", v)
+	}
+
+	options.MacroExpander = func(section, property, macro string) (string, error) {
+
+		switch macro {
+		case "U":
+			return "alice", nil
+		case "m":
+			return "client1", nil
+		case "$(LOGDIR)":
+			return "/var/log/samba", nil
+		}
+
+		return "", errorf("Unsupported macro")
+	}
+
+	for property, expected := range map[string]string{"comment": "Home directory of alice (100%)", "log file": "/var/log/samba/client1.log"} {
+
+		if v, _ := ic.Value("homes", property); v != expected {
+			t.Errorf("Expected %s for %s, was %s", expected, property, v)
+		}
+	}
+
+	if _, err := ic.Value("homes", "path"); err == nil {
+		t.Errorf("Expected expander error to be returned")
+	}
+}
@@ -0,0 +1,346 @@
+package inifile
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// The number of unchanged lines shown either side of a change in a unified diff
+const diffContext = 3
+
+// Preview returns the content that Save would write to the file at the supplied path and a unified diff between the
+// current contents of the file and that content, without writing anything. The diff is empty if the file would not
+// change. A file that does not exist is treated as empty.
+func (ic *IniConfig) Preview(path string) (newContent []byte, unified string, err error) {
+	return ic.PreviewWithOptions(path, DefaultIniWriteOptions())
+}
+
+// PreviewWithOptions behaves like Preview, with the layout of the content controlled by the supplied IniWriteOptions
+// (see SaveWithOptions).
+func (ic *IniConfig) PreviewWithOptions(path string, wo *IniWriteOptions) (newContent []byte, unified string, err error) {
+
+	var b bytes.Buffer
+
+	if _, err := ic.WriteToWithOptions(&b, wo); err != nil {
+		return nil, "", err
+	}
+
+	current, err := os.ReadFile(path)
+
+	if err != nil && !os.IsNotExist(err) {
+		return nil, "", err
+	}
+
+	return b.Bytes(), unifiedDiff(string(current), b.String(), path), nil
+}
+
+// NeedsSave returns true if Save would change the contents of the file at the supplied path (or create it), so that
+// configuration management runs can skip writing an unchanged file and avoid updating its modification time or
+// triggering unnecessary reloads. The content is rendered in memory and its SHA-256 hash compared with that of the
+// file.
+func (ic *IniConfig) NeedsSave(path string) (bool, error) {
+	return ic.NeedsSaveWithOptions(path, DefaultIniWriteOptions())
+}
+
+// NeedsSaveWithOptions behaves like NeedsSave, comparing the file with the content SaveWithOptions would write with the
+// supplied IniWriteOptions. Options that make every save different, such as a GeneratedBy header with a timestamp,
+// cause this to always return true.
+func (ic *IniConfig) NeedsSaveWithOptions(path string, wo *IniWriteOptions) (bool, error) {
+
+	rendered := sha256.New()
+
+	if _, err := ic.WriteToWithOptions(rendered, wo); err != nil {
+		return false, err
+	}
+
+	f, err := os.Open(path)
+
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	defer f.Close()
+
+	current := sha256.New()
+
+	if _, err := io.Copy(current, f); err != nil {
+		return false, err
+	}
+
+	return !bytes.Equal(rendered.Sum(nil), current.Sum(nil)), nil
+}
+
+// A line in a diff, prefixed with ' ' (unchanged), '-' (removed) or '+' (added)
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// unifiedDiff returns a unified diff (as produced by diff -u) between two versions of the content of the file at the
+// supplied path, or an empty string if they are the same.
+func unifiedDiff(before, after, path string) string {
+
+	if before == after {
+		return ""
+	}
+
+	lines := diffLines(splitLinesKeepEnds(before), splitLinesKeepEnds(after))
+
+	var b strings.Builder
+
+	b.WriteString("--- " + path + "\n")
+	b.WriteString("+++ " + path + "\n")
+
+	for start := 0; start < len(lines); {
+
+		//Find the next change and the extent of the hunk containing it
+		first := start
+
+		for first < len(lines) && lines[first].op == ' ' {
+			first++
+		}
+
+		if first == len(lines) {
+			break
+		}
+
+		last := first
+
+		for i := first; i < len(lines) && i-last <= 2*diffContext; i++ {
+			if lines[i].op != ' ' {
+				last = i
+			}
+		}
+
+		from := first - diffContext
+
+		if from < start {
+			from = start
+		}
+
+		to := last + 1 + diffContext
+
+		if to > len(lines) {
+			to = len(lines)
+		}
+
+		writeHunk(&b, lines, from, to)
+
+		start = to
+	}
+
+	return b.String()
+}
+
+// writeHunk writes the hunk containing lines[from:to].
+func writeHunk(b *strings.Builder, lines []diffLine, from, to int) {
+
+	oldStart, newStart := 1, 1
+
+	for _, l := range lines[:from] {
+
+		if l.op != '+' {
+			oldStart++
+		}
+
+		if l.op != '-' {
+			newStart++
+		}
+	}
+
+	oldCount, newCount := 0, 0
+
+	for _, l := range lines[from:to] {
+
+		if l.op != '+' {
+			oldCount++
+		}
+
+		if l.op != '-' {
+			newCount++
+		}
+	}
+
+	//An empty range starts at the line before it
+	if oldCount == 0 {
+		oldStart--
+	}
+
+	if newCount == 0 {
+		newStart--
+	}
+
+	fmt.Fprintf(b, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+
+	for _, l := range lines[from:to] {
+
+		b.WriteByte(l.op)
+		b.WriteString(l.text)
+
+		if !strings.HasSuffix(l.text, "\n") {
+			b.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats the start and length of one side of a hunk, omitting a length of one.
+func hunkRange(start, count int) string {
+
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// diffLines returns the changes needed to turn the lines before into the lines after, using Myers' linear space
+// algorithm so that the memory needed grows with the length of the content rather than its square.
+func diffLines(before, after []string) []diffLine {
+	return appendDiff(nil, before, after)
+}
+
+// appendDiff appends the changes needed to turn a into b to the supplied lines. Common leading and trailing lines are
+// matched directly and the rest is split at the middle snake (see middleSnake) and diffed recursively.
+func appendDiff(lines []diffLine, a, b []string) []diffLine {
+
+	prefix := 0
+
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		lines = append(lines, diffLine{' ', a[prefix]})
+		prefix++
+	}
+
+	a, b = a[prefix:], b[prefix:]
+
+	suffix := 0
+
+	for suffix < len(a) && suffix < len(b) && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	common := a[len(a)-suffix:]
+	a, b = a[:len(a)-suffix], b[:len(b)-suffix]
+
+	switch {
+	case len(a) == 0:
+
+		for _, l := range b {
+			lines = append(lines, diffLine{'+', l})
+		}
+
+	case len(b) == 0:
+
+		for _, l := range a {
+			lines = append(lines, diffLine{'-', l})
+		}
+
+	default:
+
+		x, y, u, v := middleSnake(a, b)
+
+		lines = appendDiff(lines, a[:x], b[:y])
+
+		for _, l := range a[x:u] {
+			lines = append(lines, diffLine{' ', l})
+		}
+
+		lines = appendDiff(lines, a[u:], b[v:])
+	}
+
+	for _, l := range common {
+		lines = append(lines, diffLine{' ', l})
+	}
+
+	return lines
+}
+
+// middleSnake finds the middle snake of a shortest edit script turning a into b: a run of matching lines from a[x:u]
+// to b[y:v] that splits the edits needed into two halves. Both a and b must be non-empty.
+func middleSnake(a, b []string) (x, y, u, v int) {
+
+	n, m := len(a), len(b)
+	delta := n - m
+	max := (n + m + 1) / 2
+	offset := max + 1
+
+	//forward[offset+k] is the furthest x reached on diagonal k (x-y) from the start, backward[offset+k] the furthest
+	//distance reached on diagonal k from the end
+	forward := make([]int, 2*max+3)
+	backward := make([]int, 2*max+3)
+
+	for d := 0; d <= max; d++ {
+
+		for k := -d; k <= d; k += 2 {
+
+			if k == -d || k != d && forward[offset+k-1] < forward[offset+k+1] {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+
+			y = x - k
+			u, v = x, y
+
+			for u < n && v < m && a[u] == b[v] {
+				u++
+				v++
+			}
+
+			forward[offset+k] = u
+
+			if reverse := delta - k; delta%2 != 0 && reverse >= -(d-1) && reverse <= d-1 && u+backward[offset+reverse] >= n {
+				return x, y, u, v
+			}
+		}
+
+		for k := -d; k <= d; k += 2 {
+
+			var back int
+
+			if k == -d || k != d && backward[offset+k-1] < backward[offset+k+1] {
+				back = backward[offset+k+1]
+			} else {
+				back = backward[offset+k-1] + 1
+			}
+
+			end := back
+
+			for end < n && end-k < m && a[n-1-end] == b[m-1-end+k] {
+				end++
+			}
+
+			backward[offset+k] = end
+
+			if ahead := delta - k; delta%2 == 0 && ahead >= -d && ahead <= d && end+forward[offset+ahead] >= n {
+				return n - end, m - end + k, n - back, m - back + k
+			}
+		}
+	}
+
+	//Not reached: a shortest edit script has at most n+m edits
+	return 0, 0, 0, 0
+}
+
+// splitLinesKeepEnds splits the supplied content into lines, each keeping its line ending.
+func splitLinesKeepEnds(s string) []string {
+
+	if s == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(s, "\n")
+
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
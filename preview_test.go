@@ -0,0 +1,201 @@
+package inifile
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPreview(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "config.ini")
+
+	original := "[a]\nb=1\nc=2\nd=3\ne=4\nf=5\ng=6\nh=7\ni=8\nj=9\n"
+	os.WriteFile(path, []byte(original), 0600)
+
+	ic, err := NewIniConfigFromPath(path)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if content, diff, err := ic.Preview(path); err != nil || diff != "" || string(content) != original {
+		t.Errorf("Expected no changes, got %q %v", diff, err)
+	}
+
+	ic.Add("a", "b", "10")
+	ic.Add("a", "k", "11")
+
+	content, diff, err := ic.Preview(path)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if string(content) != "[a]\nb=10\nc=2\nd=3\ne=4\nf=5\ng=6\nh=7\ni=8\nj=9\nk=11\n" {
+		t.Errorf("Unexpected content %s", string(content))
+	}
+
+	expected := "--- " + path + "\n+++ " + path + "\n" +
+		"@@ -1,5 +1,5 @@\n [a]\n-b=1\n+b=10\n c=2\n d=3\n e=4\n" +
+		"@@ -8,3 +8,4 @@\n h=7\n i=8\n j=9\n+k=11\n"
+
+	if diff != expected {
+		t.Errorf("Unexpected diff:\n%s\nexpected:\n%s", diff, expected)
+	}
+
+	if b, _ := os.ReadFile(path); string(b) != original {
+		t.Errorf("Preview should not modify the file")
+	}
+}
+
+func TestPreviewNewFile(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "new.ini")
+
+	ic := NewIniConfigFromMap(map[string]map[string]string{"a": {"b": "1"}})
+
+	_, diff, err := ic.Preview(path)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if expected := "--- " + path + "\n+++ " + path + "\n@@ -0,0 +1,2 @@\n+[a]\n+b=1\n"; diff != expected {
+		t.Errorf("Unexpected diff:\n%s", diff)
+	}
+}
+
+func TestUnifiedDiffNoNewline(t *testing.T) {
+
+	diff := unifiedDiff("a=1", "a=2\n", "f")
+
+	if expected := "--- f\n+++ f\n@@ -1 +1 @@\n-a=1\n\\ No newline at end of file\n+a=2\n"; diff != expected {
+		t.Errorf("Unexpected diff:\n%s", diff)
+	}
+}
+
+func TestNeedsSave(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "config.ini")
+
+	ic := NewIniConfigFromMap(map[string]map[string]string{"a": {"b": "1"}})
+
+	if needed, err := ic.NeedsSave(path); err != nil || !needed {
+		t.Errorf("Expected missing file to need saving %v", err)
+	}
+
+	ic.Save(path)
+
+	if needed, err := ic.NeedsSave(path); err != nil || needed {
+		t.Errorf("Expected unchanged file not to need saving %v", err)
+	}
+
+	ic.Add("a", "b", "2")
+
+	if needed, err := ic.NeedsSave(path); err != nil || !needed {
+		t.Errorf("Expected changed value to need saving %v", err)
+	}
+
+	wo := DefaultIniWriteOptions()
+	wo.SpaceAroundAssignment = true
+	ic.SaveWithOptions(path, wo)
+
+	if needed, _ := ic.NeedsSaveWithOptions(path, wo); needed {
+		t.Errorf("Expected file saved with the same options not to need saving")
+	}
+
+	if needed, _ := ic.NeedsSave(path); !needed {
+		t.Errorf("Expected different layout to need saving")
+	}
+}
+
+func TestDiffLinesMinimal(t *testing.T) {
+
+	rng := rand.New(rand.NewSource(1))
+
+	random := func() []string {
+
+		lines := make([]string, rng.Intn(12))
+
+		for i := range lines {
+			lines[i] = string(rune('a' + rng.Intn(3)))
+		}
+
+		return lines
+	}
+
+	for i := 0; i < 2000; i++ {
+
+		before, after := random(), random()
+
+		var kept, removed, added []string
+
+		for _, l := range diffLines(before, after) {
+			switch l.op {
+			case ' ':
+				kept = append(kept, l.text)
+				removed = append(removed, l.text)
+				added = append(added, l.text)
+			case '-':
+				removed = append(removed, l.text)
+			case '+':
+				added = append(added, l.text)
+			}
+		}
+
+		if strings.Join(removed, "") != strings.Join(before, "") || strings.Join(added, "") != strings.Join(after, "") {
+			t.Fatalf("Diff of %v and %v does not reproduce both sides", before, after)
+		}
+
+		//The common lines must form a longest common subsequence
+		lcs := make([][]int, len(before)+1)
+
+		for i := range lcs {
+			lcs[i] = make([]int, len(after)+1)
+		}
+
+		for i := len(before) - 1; i >= 0; i-- {
+			for j := len(after) - 1; j >= 0; j-- {
+				if before[i] == after[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else if lcs[i+1][j] > lcs[i][j+1] {
+					lcs[i][j] = lcs[i+1][j]
+				} else {
+					lcs[i][j] = lcs[i][j+1]
+				}
+			}
+		}
+
+		if len(kept) != lcs[0][0] {
+			t.Fatalf("Diff of %v and %v keeps %d lines, expected %d", before, after, len(kept), lcs[0][0])
+		}
+	}
+}
+
+func TestDiffLinesLargeContent(t *testing.T) {
+
+	before := make([]string, 50000)
+
+	for i := range before {
+		before[i] = fmt.Sprintf("key%d=%d\n", i, i)
+	}
+
+	after := append([]string{"first=1\n"}, before[1:len(before)-1]...)
+	after = append(after, "last=1\n")
+
+	changed := 0
+
+	for _, l := range diffLines(before, after) {
+		if l.op != ' ' {
+			changed++
+		}
+	}
+
+	if changed != 4 {
+		t.Errorf("Expected 4 changed lines, got %d", changed)
+	}
+}
@@ -0,0 +1,204 @@
+package inifile
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The registry hives a root key passed to WriteReg may start with
+var regHives = []string{"HKEY_LOCAL_MACHINE", "HKEY_CURRENT_USER", "HKEY_CLASSES_ROOT", "HKEY_USERS", "HKEY_CURRENT_CONFIG"}
+
+// WriteReg writes this IniConfig to w as a Windows registry (.reg) file that can be imported with regedit. Global
+// properties become values of the supplied root key (e.g. HKEY_LOCAL_MACHINE\SOFTWARE\MyApp) and each named section
+// becomes a subkey of the root key holding a value for each property. Sections are written in the order of
+// SectionNames and properties in the order they were first parsed or added.
+//
+// If schema is nil every value is written as a string (REG_SZ). Otherwise the type of each property described by the
+// schema decides how it is written:
+//
+//		bool                           REG_DWORD, 1 for true and 0 for false
+//		int32, uint16, uint32          REG_DWORD
+//		int, int64, uint64             REG_QWORD
+//		string, float32, float64       REG_SZ
+//
+// Properties not described by the schema are written as strings. Values are retrieved with Value, so interpolation and
+// decryption are applied. The file is written with CRLF line endings and without a byte order mark; values containing
+// characters outside ASCII should be converted to UTF-16 before importing.
+//
+// An error is returned if the root key does not start with a registry hive name, a value cannot be converted to the
+// type declared in the schema, a value contains a line break or the writer fails.
+func (ic *IniConfig) WriteReg(w io.Writer, rootKey string, schema *IniSchema) error {
+
+	rootKey = strings.TrimRight(rootKey, `\`)
+
+	if !isRegRoot(rootKey) {
+		return errorf("Registry key %s does not start with one of %s", rootKey, strings.Join(regHives, ", "))
+	}
+
+	types := make(map[string]string)
+
+	if schema != nil {
+		for _, sp := range schema.properties {
+			types[ic.normalise(sp.Section)+"\x00"+ic.normalise(sp.Property)] = sp.Type
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString("Windows Registry Editor Version 5.00\r\n")
+
+	current := ""
+
+	err := ic.Walk(func(section, property, value string) error {
+
+		key := rootKey
+
+		if section != GLOBAL_SECTION {
+			key += `\` + section
+		}
+
+		if key != current {
+			fmt.Fprintf(bw, "\r\n[%s]\r\n", key)
+			current = key
+		}
+
+		data, err := ic.regData(types[section+"\x00"+property], section, property, value)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = bw.WriteString(regString(property) + "=" + data + "\r\n")
+
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// isRegRoot returns true if the supplied key is, or is under, one of the registry hives.
+func isRegRoot(key string) bool {
+
+	for _, hive := range regHives {
+
+		if key == hive || strings.HasPrefix(key, hive+`\`) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// regData returns the data of a registry value for the supplied property, in the format used by .reg files for the
+// declared type.
+func (ic *IniConfig) regData(declared, section, property, value string) (string, error) {
+
+	switch declared {
+	case "bool":
+
+		b, err := ic.ValueAsBool(section, property)
+
+		if err != nil {
+			return "", err
+		}
+
+		if b {
+			return "dword:00000001", nil
+		}
+
+		return "dword:00000000", nil
+
+	case "int32", "uint16", "uint32":
+
+		var d uint32
+
+		switch declared {
+		case "int32":
+
+			v, err := ic.ValueAsInt32(section, property)
+
+			if err != nil {
+				return "", err
+			}
+
+			d = uint32(v)
+
+		case "uint16":
+
+			v, err := ic.ValueAsUint16(section, property)
+
+			if err != nil {
+				return "", err
+			}
+
+			d = uint32(v)
+
+		default:
+
+			v, err := ic.ValueAsUint32(section, property)
+
+			if err != nil {
+				return "", err
+			}
+
+			d = v
+		}
+
+		return fmt.Sprintf("dword:%08x", d), nil
+
+	case "int", "int64", "uint64":
+
+		var q uint64
+
+		if declared == "uint64" {
+
+			v, err := ic.ValueAsUint64(section, property)
+
+			if err != nil {
+				return "", err
+			}
+
+			q = v
+
+		} else {
+
+			v, err := ic.ValueAsInt64(section, property)
+
+			if err != nil {
+				return "", err
+			}
+
+			q = uint64(v)
+		}
+
+		var b [8]byte
+
+		binary.LittleEndian.PutUint64(b[:], q)
+
+		hex := make([]string, len(b))
+
+		for i, v := range b {
+			hex[i] = fmt.Sprintf("%02x", v)
+		}
+
+		return "hex(b):" + strings.Join(hex, ","), nil
+	}
+
+	if strings.ContainsAny(value, "\r\n") {
+		return "", errorf("Value of [%s].%s contains a line break and cannot be written as a registry string", section, property)
+	}
+
+	return regString(value), nil
+}
+
+// regString returns the supplied text quoted for a .reg file.
+func regString(s string) string {
+	return `"` + strings.Replace(strings.Replace(s, `\`, `\\`, -1), `"`, `\"`, -1) + `"`
+}
@@ -0,0 +1,68 @@
+package inifile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteReg(t *testing.T) {
+
+	ic, _ := newIniConfigFromReader(strings.NewReader("name=My \"App\"\n[server]\nport=8080\nbig=-2\nenabled=true\npath=C:\\data\n"), "test", DefaultIniOptions())
+
+	var b bytes.Buffer
+
+	if err := ic.WriteReg(&b, `HKEY_LOCAL_MACHINE\SOFTWARE\MyApp\`, nil); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	expected := "Windows Registry Editor Version 5.00\r\n\r\n[HKEY_LOCAL_MACHINE\\SOFTWARE\\MyApp]\r\n\"name\"=\"My \\\"App\\\"\"\r\n" +
+		"\r\n[HKEY_LOCAL_MACHINE\\SOFTWARE\\MyApp\\server]\r\n\"port\"=\"8080\"\r\n\"big\"=\"-2\"\r\n\"enabled\"=\"true\"\r\n\"path\"=\"C:\\\\data\"\r\n"
+
+	if b.String() != expected {
+		t.Errorf("Unexpected output %q", b.String())
+	}
+
+	schema, err := NewIniSchemaFromIniConfig(NewIniConfigFromMap(map[string]map[string]string{
+		"server.port":    {"type": "uint16"},
+		"server.big":     {"type": "int64"},
+		"server.enabled": {"type": "bool"},
+	}))
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	b.Reset()
+
+	if err := ic.WriteReg(&b, `HKEY_CURRENT_USER\Software\MyApp`, schema); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	for _, line := range []string{"\"port\"=dword:00001f90\r\n", "\"big\"=hex(b):fe,ff,ff,ff,ff,ff,ff,ff\r\n", "\"enabled\"=dword:00000001\r\n", "\"path\"=\"C:\\\\data\"\r\n"} {
+		if !strings.Contains(b.String(), line) {
+			t.Errorf("Expected typed output to contain %q, got %q", line, b.String())
+		}
+	}
+}
+
+func TestWriteRegErrors(t *testing.T) {
+
+	ic := NewIniConfigFromMap(map[string]map[string]string{"a": {"b": "x"}})
+
+	if err := ic.WriteReg(&bytes.Buffer{}, `SOFTWARE\MyApp`, nil); err == nil {
+		t.Errorf("Expected root key without a hive to fail")
+	}
+
+	schema, _ := NewIniSchemaFromIniConfig(NewIniConfigFromMap(map[string]map[string]string{"a.b": {"type": "int32"}}))
+
+	if err := ic.WriteReg(&bytes.Buffer{}, `HKEY_USERS\x`, schema); err == nil {
+		t.Errorf("Expected value that does not match the schema to fail")
+	}
+
+	ic.Add("a", "b", "line\nbreak")
+
+	if err := ic.WriteReg(&bytes.Buffer{}, `HKEY_USERS\x`, nil); err == nil {
+		t.Errorf("Expected value with a line break to fail")
+	}
+}
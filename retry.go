@@ -0,0 +1,51 @@
+package inifile
+
+import (
+	"errors"
+	"io"
+	"syscall"
+	"time"
+)
+
+// retryingReader retries reads that fail with a transient error (see isTransient), as can happen when a file is read
+// from a network filesystem.
+type retryingReader struct {
+	r       io.Reader
+	retries int
+	delay   time.Duration
+	debugf  func(format string, args ...interface{})
+}
+
+func (rr *retryingReader) Read(p []byte) (int, error) {
+
+	n, err := rr.r.Read(p)
+
+	for attempt := 1; n == 0 && err != nil && attempt <= rr.retries && isTransient(err); attempt++ {
+
+		rr.debugf("Retrying read after transient error (attempt %d of %d): %s", attempt, rr.retries, err.Error())
+
+		time.Sleep(rr.delay)
+		n, err = rr.r.Read(p)
+	}
+
+	return n, err
+}
+
+// isTransient returns true if the supplied error reports a timeout or temporary condition, or a low-level I/O error
+// that may not happen again.
+func isTransient(err error) bool {
+
+	var timeout interface{ Timeout() bool }
+
+	if errors.As(err, &timeout) && timeout.Timeout() {
+		return true
+	}
+
+	var temporary interface{ Temporary() bool }
+
+	if errors.As(err, &temporary) && temporary.Temporary() {
+		return true
+	}
+
+	return errors.Is(err, syscall.EIO)
+}
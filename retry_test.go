@@ -0,0 +1,68 @@
+package inifile
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// failingReader returns its content and then fails the number of times given by failures before returning io.EOF
+type failingReader struct {
+	content  io.Reader
+	failures int
+	err      error
+}
+
+func (fr *failingReader) Read(p []byte) (int, error) {
+
+	n, err := fr.content.Read(p)
+
+	if err == io.EOF && fr.failures > 0 {
+		fr.failures--
+		return 0, fr.err
+	}
+
+	return n, err
+}
+
+func TestReadErrorReported(t *testing.T) {
+
+	r := &failingReader{content: strings.NewReader("[a]\nb=1\nc=2\n"), failures: 1, err: errors.New("disk on fire")}
+
+	_, err := newIniConfigFromReader(r, "test.ini", DefaultIniOptions())
+
+	if err == nil || err.Error() != "Unable to read test.ini after line 3: disk on fire" {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestReadRetries(t *testing.T) {
+
+	opts := DefaultIniOptions()
+	opts.ReadRetries = 2
+	opts.ReadRetryDelay = 0
+
+	r := &failingReader{content: strings.NewReader("[a]\nb=1\n"), failures: 2, err: syscall.EIO}
+
+	ic, err := newIniConfigFromReader(r, "test.ini", opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, "a", "b", "1")
+
+	r = &failingReader{content: strings.NewReader("[a]\nb=1\n"), failures: 3, err: syscall.EIO}
+
+	if _, err := newIniConfigFromReader(r, "test.ini", opts); !errors.Is(err, syscall.EIO) {
+		t.Errorf("Expected error after retries are exhausted, got %v", err)
+	}
+
+	r = &failingReader{content: strings.NewReader("[a]\nb=1\n"), failures: 1, err: errors.New("permanent")}
+
+	if _, err := newIniConfigFromReader(r, "test.ini", opts); err == nil {
+		t.Errorf("Expected error that is not transient not to be retried")
+	}
+}
@@ -0,0 +1,211 @@
+package inifile
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrValueNotAllowed is wrapped by errors returned by IniSchema.Validate when a value is not one of the allowed values
+// for its property.
+var ErrValueNotAllowed = fmt.Errorf("value not allowed")
+
+// SchemaProperty describes a property that may appear in a configuration validated by an IniSchema.
+type SchemaProperty struct {
+	// The section containing the property (GLOBAL_SECTION for the global section)
+	Section string
+
+	// The name of the property
+	Property string
+
+	// The type the value must be convertible to (see TypeAnnotations in IniOptions for the supported types)
+	Type string
+
+	// Whether the property must be present (or have a default)
+	Required bool
+
+	// The value used by ApplyDefaults if the property is missing. Only used if HasDefault is true
+	Default    string
+	HasDefault bool
+
+	// If not empty, the value must be one of these strings
+	Allowed []string
+}
+
+// IniSchema describes the properties a configuration is expected to contain, so that a file can be validated against a
+// contract that is versioned alongside it.
+type IniSchema struct {
+	properties []SchemaProperty
+}
+
+// NewIniSchemaFromPath loads an IniSchema from the INI file at the supplied path (see NewIniSchemaFromIniConfig for
+// the format of the file).
+func NewIniSchemaFromPath(path string) (*IniSchema, error) {
+
+	ic, err := NewIniConfigFromPath(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIniSchemaFromIniConfig(ic)
+}
+
+// NewIniSchemaFromIniConfig builds an IniSchema from an INI document in which each section describes one property.
+// The section is named after the section and property it describes, separated by the last dot in the name:
+//
+//		[server.port]
+//		type=int
+//		required=true
+//
+//		[server.mode]
+//		default=production
+//		allowed=development, production
+//
+//		[.debug]
+//		type=bool
+//
+// ([.debug] describes a property in the global section). The section and property can instead be given with section
+// and property keys, for names that contain dots. The type defaults to string and required to false. Allowed values are
+// separated by commas.
+//
+// Returns an error if a section does not identify a property, a type is not supported or a default value does not
+// match the type or allowed values.
+func NewIniSchemaFromIniConfig(ic *IniConfig) (*IniSchema, error) {
+
+	schema := new(IniSchema)
+
+	for _, name := range ic.SectionNames() {
+
+		if name == GLOBAL_SECTION {
+			continue
+		}
+
+		is, _ := ic.Section(name)
+
+		sp := SchemaProperty{Type: "string"}
+
+		if i := strings.LastIndexByte(name, '.'); i >= 0 {
+			sp.Section, sp.Property = name[:i], name[i+1:]
+		}
+
+		if v, found := is.Lookup("section"); found {
+			sp.Section = v
+		}
+
+		if v, found := is.Lookup("property"); found {
+			sp.Property = v
+		}
+
+		if sp.Property == "" {
+			return nil, errorf("Schema section [%s] does not identify a property", name)
+		}
+
+		if v, found := is.Lookup("type"); found {
+			sp.Type = strings.ToLower(v)
+		}
+
+		if !isAnnotationType(sp.Type) {
+			return nil, errorf("Schema section [%s] has unsupported type %s", name, sp.Type)
+		}
+
+		if is.PropertyExists("required") {
+
+			required, err := is.ValueAsBool("required")
+
+			if err != nil {
+				return nil, err
+			}
+
+			sp.Required = required
+		}
+
+		if v, found := is.Lookup("allowed"); found {
+			for _, a := range strings.Split(v, ",") {
+				sp.Allowed = append(sp.Allowed, strings.TrimSpace(a))
+			}
+		}
+
+		if v, found := is.Lookup("default"); found {
+
+			sp.Default, sp.HasDefault = v, true
+
+			check := newIniConfigFromMap(map[string]map[string]string{sp.Section: {sp.Property: v}}, DefaultIniOptions())
+
+			if err := sp.check(check); err != nil {
+				return nil, errorf("Invalid default in schema section [%s]: %w", name, err)
+			}
+		}
+
+		schema.properties = append(schema.properties, sp)
+	}
+
+	return schema, nil
+}
+
+// Properties returns the properties described by this schema in the order they were defined.
+func (s *IniSchema) Properties() []SchemaProperty {
+	return append([]SchemaProperty(nil), s.properties...)
+}
+
+// Validate checks the supplied IniConfig against this schema.
+//
+// Returns nil if the configuration is valid, or an error joining an error for each required property that is missing
+// and has no default (wrapping ErrPropertyNotFound), each value that cannot be converted to its type (wrapping
+// ErrConversion) and each value that is not allowed (wrapping ErrValueNotAllowed).
+func (s *IniSchema) Validate(ic *IniConfig) error {
+
+	var errs []error
+
+	for _, sp := range s.properties {
+
+		if !ic.PropertyExists(sp.Section, sp.Property) {
+
+			if sp.Required && !sp.HasDefault {
+				errs = append(errs, wrapf(ErrPropertyNotFound, nil, "Required property [%s].%s is missing", sp.Section, sp.Property))
+			}
+
+			continue
+		}
+
+		if err := sp.check(ic); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ApplyDefaults adds the default value of each property in this schema that has a default and is missing from the
+// supplied IniConfig.
+func (s *IniSchema) ApplyDefaults(ic *IniConfig) {
+
+	for _, sp := range s.properties {
+
+		if sp.HasDefault && !ic.PropertyExists(sp.Section, sp.Property) {
+			ic.Add(sp.Section, sp.Property, sp.Default)
+		}
+	}
+}
+
+// check returns an error if the value of the property in the IniConfig does not match its type or allowed values.
+func (sp *SchemaProperty) check(ic *IniConfig) error {
+
+	if err := ic.checkType(sp.Type, sp.Section, sp.Property); err != nil {
+		return err
+	}
+
+	if len(sp.Allowed) == 0 {
+		return nil
+	}
+
+	v, _ := ic.Value(sp.Section, sp.Property)
+
+	for _, a := range sp.Allowed {
+		if v == a {
+			return nil
+		}
+	}
+
+	return wrapf(ErrValueNotAllowed, nil, "Value of [%s].%s (%s) is not one of %s", sp.Section, sp.Property, ic.redact(sp.Section, sp.Property, v), strings.Join(sp.Allowed, ", "))
+}
@@ -0,0 +1,82 @@
+package inifile
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIniSchema(t *testing.T) {
+
+	schema, err := NewIniSchemaFromPath(filepath.Join(testfiles_base, "schema.ini"))
+
+	if err != nil {
+		t.Fatalf("Problem loading schema %s", err.Error())
+	}
+
+	if p := schema.Properties(); len(p) != 5 || p[3].Property != "max.connections" || p[4].Section != GLOBAL_SECTION {
+		t.Errorf("Unexpected properties %v", p)
+	}
+
+	ic, err := NewIniConfigFromPath(filepath.Join(testfiles_base, "schema-config.ini"))
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	err = schema.Validate(ic)
+
+	if !errors.Is(err, ErrValueNotAllowed) || !errors.Is(err, ErrConversion) {
+		t.Errorf("Expected allowed value and conversion errors, was %v", err)
+	}
+
+	for _, expected := range []string{"staging", "max.connections", "debug"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to mention %s: %s", expected, err.Error())
+		}
+	}
+
+	ic.Delete("server", "port")
+
+	if err := schema.Validate(ic); !errors.Is(err, ErrPropertyNotFound) {
+		t.Errorf("Expected missing required property to be reported, was %v", err)
+	}
+
+	ic.Add("server", "port", "80")
+	ic.Add("server", "mode", "development")
+	ic.Add("server", "max.connections", "100")
+	ic.Add(GLOBAL_SECTION, "debug", "true")
+
+	if err := schema.Validate(ic); err != nil {
+		t.Errorf("Unexpected error %s", err.Error())
+	}
+
+	schema.ApplyDefaults(ic)
+
+	if v, _ := ic.Value("server", "host"); v != "localhost" {
+		t.Errorf("Expected default to be applied, was %s", v)
+	}
+
+	if v, _ := ic.Value("server", "mode"); v != "development" {
+		t.Errorf("Did not expect default to replace value, was %s", v)
+	}
+}
+
+func TestInvalidIniSchema(t *testing.T) {
+
+	for _, content := range []string{
+		"[noproperty]\ntype=int\n",
+		"[s.p]\ntype=complex\n",
+		"[s.p]\ntype=int\ndefault=x\n",
+		"[s.p]\nallowed=a,b\ndefault=c\n",
+		"[s.p]\nrequired=perhaps\n",
+	} {
+
+		ic, _ := newIniConfigFromReader(strings.NewReader(content), "", DefaultIniOptions())
+
+		if _, err := NewIniSchemaFromIniConfig(ic); err == nil {
+			t.Errorf("Expected schema to be invalid:\n%s", content)
+		}
+	}
+}
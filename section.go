@@ -1,5 +1,9 @@
 package inifile
 
+import (
+	"net/url"
+)
+
 // IniSection provides access to an IniConfig object within the context of a single section.
 //
 // Call the Section(sectionName) function on your IniConfig to obtain an IniSection
@@ -13,6 +17,13 @@ func (is *IniSection) Name() string{
 	return is.name
 }
 
+//PropertyNames returns the names of the properties in this section in the order they were first parsed or added
+func (is *IniSection) PropertyNames() []string {
+	names := is.ic.propertyOrder[is.ic.normalise(is.name)]
+
+	return append([]string(nil), names...)
+}
+
 //See IniConfig.PropertyExists
 func (is *IniSection) PropertyExists(propertyName string) bool {
 	return is.ic.PropertyExists(is.name, propertyName)
@@ -69,8 +80,198 @@ func (is *IniSection) ValueOrZeroAsBool(propertyName string) (bool) {
 	return is.ic.ValueOrZeroAsBool(is.name, propertyName)
 }
 
+//See IniConfig.ValueAsInt
+func (is *IniSection) ValueAsInt(propertyName string) (int, error) {
+	return is.ic.ValueAsInt(is.name, propertyName)
+}
+
+//See IniConfig.ValueOrZeroAsInt
+func (is *IniSection) ValueOrZeroAsInt(propertyName string) (int) {
+	return is.ic.ValueOrZeroAsInt(is.name, propertyName)
+}
+
+//See IniConfig.ValueAsInt32
+func (is *IniSection) ValueAsInt32(propertyName string) (int32, error) {
+	return is.ic.ValueAsInt32(is.name, propertyName)
+}
+
+//See IniConfig.ValueOrZeroAsInt32
+func (is *IniSection) ValueOrZeroAsInt32(propertyName string) (int32) {
+	return is.ic.ValueOrZeroAsInt32(is.name, propertyName)
+}
+
+//See IniConfig.ValueAsUint32
+func (is *IniSection) ValueAsUint32(propertyName string) (uint32, error) {
+	return is.ic.ValueAsUint32(is.name, propertyName)
+}
+
+//See IniConfig.ValueOrZeroAsUint32
+func (is *IniSection) ValueOrZeroAsUint32(propertyName string) (uint32) {
+	return is.ic.ValueOrZeroAsUint32(is.name, propertyName)
+}
+
+//See IniConfig.ValueAsUint16
+func (is *IniSection) ValueAsUint16(propertyName string) (uint16, error) {
+	return is.ic.ValueAsUint16(is.name, propertyName)
+}
+
+//See IniConfig.ValueOrZeroAsUint16
+func (is *IniSection) ValueOrZeroAsUint16(propertyName string) (uint16) {
+	return is.ic.ValueOrZeroAsUint16(is.name, propertyName)
+}
+
+//See IniConfig.ValueAsFloat32
+func (is *IniSection) ValueAsFloat32(propertyName string) (float32, error) {
+	return is.ic.ValueAsFloat32(is.name, propertyName)
+}
+
+//See IniConfig.ValueOrZeroAsFloat32
+func (is *IniSection) ValueOrZeroAsFloat32(propertyName string) (float32) {
+	return is.ic.ValueOrZeroAsFloat32(is.name, propertyName)
+}
+
+
+//See IniConfig.ValueAsMap
+func (is *IniSection) ValueAsMap(propertyName string, pairSep, kvSep string) (map[string]string, error) {
+	return is.ic.ValueAsMap(is.name, propertyName, pairSep, kvSep)
+}
+
+//See IniConfig.ValueAsBase64
+func (is *IniSection) ValueAsBase64(propertyName string) ([]byte, error) {
+	return is.ic.ValueAsBase64(is.name, propertyName)
+}
+
+//See IniConfig.ValueAsHex
+func (is *IniSection) ValueAsHex(propertyName string) ([]byte, error) {
+	return is.ic.ValueAsHex(is.name, propertyName)
+}
+
+//See IniConfig.ValueAsPercent
+func (is *IniSection) ValueAsPercent(propertyName string) (float64, error) {
+	return is.ic.ValueAsPercent(is.name, propertyName)
+}
 
 //See IniConfig.Add
 func (is *IniSection) Add(propertyName string, value string) {
 	is.ic.Add(is.name, propertyName, value)
-}
\ No newline at end of file
+}
+//See IniConfig.Values
+func (is *IniSection) Values(propertyName string) ([]string, error) {
+	return is.ic.Values(is.name, propertyName)
+}
+
+//See IniConfig.ValueAsStringSlice
+func (is *IniSection) ValueAsStringSlice(propertyName string, sep string) ([]string, error) {
+	return is.ic.ValueAsStringSlice(is.name, propertyName, sep)
+}
+
+//See IniConfig.PropertyNamesWithPrefix
+func (is *IniSection) PropertyNamesWithPrefix(prefix string) []string {
+	return is.ic.PropertyNamesWithPrefix(is.name, prefix)
+}
+
+//See IniConfig.Lookup
+func (is *IniSection) Lookup(propertyName string) (string, bool) {
+	return is.ic.Lookup(is.name, propertyName)
+}
+
+//See IniConfig.LookupInt64
+func (is *IniSection) LookupInt64(propertyName string) (int64, bool) {
+	return is.ic.LookupInt64(is.name, propertyName)
+}
+
+//See IniConfig.LookupUint64
+func (is *IniSection) LookupUint64(propertyName string) (uint64, bool) {
+	return is.ic.LookupUint64(is.name, propertyName)
+}
+
+//See IniConfig.LookupFloat64
+func (is *IniSection) LookupFloat64(propertyName string) (float64, bool) {
+	return is.ic.LookupFloat64(is.name, propertyName)
+}
+
+//See IniConfig.LookupBool
+func (is *IniSection) LookupBool(propertyName string) (bool, bool) {
+	return is.ic.LookupBool(is.name, propertyName)
+}
+
+//See IniConfig.ValueAsLines
+func (is *IniSection) ValueAsLines(propertyName string) ([]string, error) {
+	return is.ic.ValueAsLines(is.name, propertyName)
+}
+
+//See IniConfig.SetDefault
+func (is *IniSection) SetDefault(propertyName, value string) {
+	is.ic.SetDefault(is.name, propertyName, value)
+}
+
+//See IniConfig.Origin
+func (is *IniSection) Origin(propertyName string) (ValueOrigin, bool) {
+	return is.ic.Origin(is.name, propertyName)
+}
+
+//Len returns the number of properties in this section, not including properties that only have default values (see
+//IniConfig.SetDefault)
+func (is *IniSection) Len() int {
+	return len(is.ic.findSection(is.name))
+}
+
+//See IniConfig.HasValue
+func (is *IniSection) HasValue(propertyName string) bool {
+	return is.ic.HasValue(is.name, propertyName)
+}
+
+//See IniConfig.IsExplicitlySet
+func (is *IniSection) IsExplicitlySet(propertyName string) bool {
+	return is.ic.IsExplicitlySet(is.name, propertyName)
+}
+
+//See IniConfig.ValueOrNil
+func (is *IniSection) ValueOrNil(propertyName string) (*string, error) {
+	return is.ic.ValueOrNil(is.name, propertyName)
+}
+
+//See IniConfig.ValueAsBoolDefault
+func (is *IniSection) ValueAsBoolDefault(propertyName string, def bool) bool {
+	return is.ic.ValueAsBoolDefault(is.name, propertyName, def)
+}
+
+//See IniConfig.ValueAsOptionalBool
+func (is *IniSection) ValueAsOptionalBool(propertyName string) (bool, bool, error) {
+	return is.ic.ValueAsOptionalBool(is.name, propertyName)
+}
+
+//See IniConfig.MySQLDSN
+func (is *IniSection) MySQLDSN() (string, error) {
+	return is.ic.MySQLDSN(is.name)
+}
+
+//See IniConfig.PostgresDSN
+func (is *IniSection) PostgresDSN() (string, error) {
+	return is.ic.PostgresDSN(is.name)
+}
+
+//See IniConfig.URL
+func (is *IniSection) URL(scheme string) (*url.URL, error) {
+	return is.ic.URL(is.name, scheme)
+}
+
+//See IniConfig.SMTPAddress
+func (is *IniSection) SMTPAddress() (string, error) {
+	return is.ic.SMTPAddress(is.name)
+}
+
+//See IniConfig.ValueInto
+func (is *IniSection) ValueInto(propertyName string, target interface{}) error {
+	return is.ic.ValueInto(is.name, propertyName, target)
+}
+
+//See IniConfig.SetInlineComment
+func (is *IniSection) SetInlineComment(propertyName, comment string) error {
+	return is.ic.SetInlineComment(is.name, propertyName, comment)
+}
+
+//See IniConfig.InlineComment
+func (is *IniSection) InlineComment(propertyName string) (string, bool) {
+	return is.ic.InlineComment(is.name, propertyName)
+}
@@ -0,0 +1,41 @@
+package inifile
+
+// Shadowing records a property that was defined more than once in the same section of a file, where the later
+// definition replaced the earlier one.
+type Shadowing struct {
+	// The section containing the property
+	Section string
+
+	// The name of the property
+	Property string
+
+	// The value that replaced the earlier definition and the line it was parsed from
+	Value string
+	Line  int
+
+	// The value that was replaced and the line it was parsed from
+	ShadowedValue string
+	ShadowedLine  int
+}
+
+// ShadowedProperties returns every definition of a property that was replaced by a later definition in the same section
+// while parsing, in the order the later definitions were found. A property defined three times is reported twice. Values
+// replaced with Add or by properties from qualified sections (see ActiveTags) are not included.
+func (ic *IniConfig) ShadowedProperties() []Shadowing {
+	return ic.shadowed
+}
+
+// recordShadowing records that the property defined on the specified line replaced an earlier definition.
+func (ic *IniConfig) recordShadowing(section, property, value string, line int, previous propertyValue) {
+
+	s := Shadowing{
+		Section:       section,
+		Property:      property,
+		Value:         value,
+		Line:          line,
+		ShadowedValue: previous.String(),
+		ShadowedLine:  int(previous.line),
+	}
+
+	ic.shadowed = append(ic.shadowed, s)
+}
@@ -0,0 +1,40 @@
+package inifile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShadowedProperties(t *testing.T) {
+
+	content := "[server]\nport=80\nhost=a\nport=8080\n\n[other]\nport=1\n[server]\nport=9090\n"
+
+	ic, err := newIniConfigFromReader(strings.NewReader(content), "shadowed.ini", DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	shadowed := ic.ShadowedProperties()
+
+	if len(shadowed) != 2 {
+		t.Fatalf("Expected 2 shadowed definitions, got %d", len(shadowed))
+	}
+
+	expected := []Shadowing{
+		{Section: "server", Property: "port", Value: "8080", Line: 4, ShadowedValue: "80", ShadowedLine: 2},
+		{Section: "server", Property: "port", Value: "9090", Line: 9, ShadowedValue: "8080", ShadowedLine: 4},
+	}
+
+	for i, s := range shadowed {
+		if s != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], s)
+		}
+	}
+
+	ic.Add("server", "host", "b")
+
+	if len(ic.ShadowedProperties()) != 2 {
+		t.Errorf("Expected values replaced with Add not to be reported")
+	}
+}
@@ -0,0 +1,127 @@
+package inifile
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SignatureLabel follows the comment symbol in the comment that holds an embedded signature, e.g.
+//	; signature: 6vF0...
+const SignatureLabel = "signature:"
+
+// ErrInvalidSignature is wrapped by errors returned when content is not correctly signed with the private key matching
+// IniOptions.SignatureKey.
+var ErrInvalidSignature = fmt.Errorf("signature verification failed")
+
+// SignContent returns the supplied INI content with an embedded signature comment, created with the supplied private
+// key, as its last line (replacing any existing signature comment). Content signed in this way can be parsed with
+// SignatureKey in the IniOptions set to the matching public key.
+func SignContent(src []byte, key ed25519.PrivateKey, options *IniOptions) ([]byte, error) {
+
+	if options == nil {
+		return nil, errorf("Nil IniOptions provided")
+	}
+
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, errorf("Invalid Ed25519 private key (%d bytes)", len(key))
+	}
+
+	body, _ := splitFooter(src, options.CommentStart, SignatureLabel)
+
+	var b bytes.Buffer
+
+	b.Write(body)
+
+	if len(body) > 0 && body[len(body)-1] != '\n' {
+		b.WriteString(LF)
+	}
+
+	signed := b.Bytes()
+
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, signed))
+
+	b.WriteString(options.CommentStart + " " + SignatureLabel + " " + signature + LF)
+
+	return b.Bytes(), nil
+}
+
+// verifiedContent reads the content to be parsed and checks it has been signed with the private key matching the
+// SignatureKey in the IniOptions, returning the content without any embedded signature comment.
+func (ic *IniConfig) verifiedContent(r io.Reader) (io.Reader, error) {
+
+	options := ic.options
+
+	if len(options.SignatureKey) != ed25519.PublicKeySize {
+		return nil, errorf("Invalid Ed25519 public key in SignatureKey (%d bytes)", len(options.SignatureKey))
+	}
+
+	src, err := io.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if options.DetachedSignature != nil {
+
+		if !ed25519.Verify(options.SignatureKey, src, options.DetachedSignature) {
+			return nil, wrapf(ErrInvalidSignature, nil, "Detached signature does not match the content of %s", ic.sourceName())
+		}
+
+		return bytes.NewReader(src), nil
+	}
+
+	body, encoded := splitFooter(src, options.CommentStart, SignatureLabel)
+
+	if encoded == "" {
+		return nil, wrapf(ErrInvalidSignature, nil, "No signature comment found in %s", ic.sourceName())
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(encoded)
+
+	if err != nil {
+		return nil, wrapf(ErrInvalidSignature, err, "Signature comment in %s is not valid base64", ic.sourceName())
+	}
+
+	if !ed25519.Verify(options.SignatureKey, body, signature) {
+		return nil, wrapf(ErrInvalidSignature, nil, "Signature does not match the content of %s", ic.sourceName())
+	}
+
+	return bytes.NewReader(body), nil
+}
+
+// splitFooter separates content whose last non-blank line is a comment starting with the supplied label (e.g. a
+// signature comment) into the content before that line and the text following the label. If the last line is not
+// such a comment, the content is returned with empty text.
+func splitFooter(src []byte, commentStart, label string) ([]byte, string) {
+
+	content := bytes.TrimRight(src, " \t\r\n")
+	start := bytes.LastIndexByte(content, '\n') + 1
+
+	line := strings.TrimSpace(string(content[start:]))
+
+	if commentStart == "" || !strings.HasPrefix(line, commentStart) {
+		return src, ""
+	}
+
+	comment := strings.TrimSpace(strings.TrimPrefix(line, commentStart))
+
+	if !strings.HasPrefix(comment, label) {
+		return src, ""
+	}
+
+	return src[:start], strings.TrimSpace(strings.TrimPrefix(comment, label))
+}
+
+// sourceName describes where the content of this IniConfig was loaded from for use in messages.
+func (ic *IniConfig) sourceName() string {
+
+	if ic.source == "" {
+		return "content"
+	}
+
+	return ic.source
+}
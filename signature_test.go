@@ -0,0 +1,80 @@
+package inifile
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+)
+
+func TestSignedContent(t *testing.T) {
+
+	public, private, _ := ed25519.GenerateKey(nil)
+
+	opts := DefaultIniOptions()
+
+	signed, err := SignContent([]byte("[a]\nb=1"), private, opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if !bytes.HasPrefix(signed, []byte("[a]\nb=1\n; signature: ")) {
+		t.Errorf("Unexpected signed content %s", string(signed))
+	}
+
+	opts.SignatureKey = public
+
+	ic, err := newIniConfigFromReader(bytes.NewReader(signed), "signed.ini", opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, "a", "b", "1")
+
+	//Re-signing replaces the existing signature
+	if resigned, _ := SignContent(signed, private, opts); !bytes.Equal(resigned, signed) {
+		t.Errorf("Expected re-signing to replace the signature")
+	}
+
+	tampered := bytes.Replace(signed, []byte("b=1"), []byte("b=2"), 1)
+
+	if _, err := newIniConfigFromReader(bytes.NewReader(tampered), "signed.ini", opts); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected tampered content to be rejected, got %v", err)
+	}
+
+	if _, err := newIniConfigFromReader(bytes.NewReader([]byte("[a]\nb=1\n")), "unsigned.ini", opts); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected unsigned content to be rejected, got %v", err)
+	}
+
+	other, _, _ := ed25519.GenerateKey(nil)
+	opts.SignatureKey = other
+
+	if _, err := newIniConfigFromReader(bytes.NewReader(signed), "signed.ini", opts); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected content signed with a different key to be rejected, got %v", err)
+	}
+}
+
+func TestDetachedSignature(t *testing.T) {
+
+	public, private, _ := ed25519.GenerateKey(nil)
+
+	content := []byte("[a]\nb=1\n")
+
+	opts := DefaultIniOptions()
+	opts.SignatureKey = public
+	opts.DetachedSignature = ed25519.Sign(private, content)
+
+	ic, err := newIniConfigFromReader(bytes.NewReader(content), "detached.ini", opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, "a", "b", "1")
+
+	if _, err := newIniConfigFromReader(bytes.NewReader([]byte("[a]\nb=2\n")), "detached.ini", opts); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected tampered content to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package inifile
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+// Source is implemented by types that can supply the contents of an INI file from any backend (a local file,
+// object storage, a key/value store, a database etc).
+type Source interface {
+	// Open returns a reader positioned at the start of the INI content. The caller is responsible for closing it.
+	Open() (io.ReadCloser, error)
+
+	// Name returns a human-readable identifier for the source (e.g. a path or URL) for use in error messages.
+	Name() string
+
+	// ModTime returns the time the content was last modified, or the zero time if this is not known.
+	ModTime() (time.Time, error)
+}
+
+// NewFileSource creates a Source backed by the file at the supplied path.
+func NewFileSource(path string) Source {
+	return &fileSource{path: path}
+}
+
+// A Source backed by a file on the local filesystem
+type fileSource struct {
+	path string
+}
+
+// See Source.Open
+func (fs *fileSource) Open() (io.ReadCloser, error) {
+	return os.Open(fs.path)
+}
+
+// See Source.Name
+func (fs *fileSource) Name() string {
+	return fs.path
+}
+
+// See Source.ModTime
+func (fs *fileSource) ModTime() (time.Time, error) {
+
+	if fi, err := os.Stat(fs.path); err != nil {
+		return time.Time{}, err
+	} else {
+		return fi.ModTime(), nil
+	}
+}
+
+// NewIniConfigFromSource loads the INI content supplied by the Source into a new IniConfig object.
+// The IniOptions used will be those returned from DefaultIniOptions()
+//
+// An error will be returned if there was a problem opening the source or parsing its content as an INI file.
+func NewIniConfigFromSource(src Source) (*IniConfig, error) {
+	return NewIniConfigFromSourceWithOptions(src, DefaultIniOptions())
+}
+
+// NewIniConfigFromSourceWithOptions loads the INI content supplied by the Source into a new IniConfig object using
+// the supplied options. The reader returned by the Source is closed once parsing is complete.
+//
+// If ForbiddenPermissions is set in the options, the permissions of a Source created with NewFileSource (or any Source
+// whose Open returns an *os.File) are checked, and an error is returned for any other Source as its permissions cannot
+// be checked.
+//
+// An error will be returned if there was a problem opening the source or parsing its content as an INI file.
+func NewIniConfigFromSourceWithOptions(src Source, options *IniOptions) (*IniConfig, error) {
+
+	if src == nil {
+		return nil, errorf("Nil Source provided")
+	}
+
+	r, err := src.Open()
+
+	if err != nil {
+		return nil, errorf("Unable to open %s: %w", src.Name(), err)
+	}
+
+	defer r.Close()
+
+	if options != nil && options.ForbiddenPermissions.Perm() != 0 {
+
+		f, isFile := r.(*os.File)
+
+		if !isFile {
+			return nil, errorf("Unable to load %s: ForbiddenPermissions is set in IniOptions but the Source is not a local file, so its permissions cannot be checked", src.Name())
+		}
+
+		if err := checkPermissions(f, options); err != nil {
+			return nil, err
+		}
+	}
+
+	return newIniConfigFromReader(r, src.Name(), options)
+}
@@ -0,0 +1,64 @@
+package inifile
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+type stringSource struct {
+	content string
+}
+
+func (ss *stringSource) Open() (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(ss.content)), nil
+}
+
+func (ss *stringSource) Name() string {
+	return "string"
+}
+
+func (ss *stringSource) ModTime() (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func TestCustomSource(t *testing.T) {
+
+	ic, err := NewIniConfigFromSource(&stringSource{"[section]\nname=value"})
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if v, _ := ic.Value("section", "name"); v != "value" {
+		t.Errorf("Unexpected value %s", v)
+	}
+}
+
+func TestFileSource(t *testing.T) {
+
+	src := NewFileSource(simplePath())
+
+	if src.Name() != simplePath() {
+		t.Errorf("Unexpected name %s", src.Name())
+	}
+
+	if mt, err := src.ModTime(); err != nil || mt.IsZero() {
+		t.Errorf("Expected a modification time")
+	}
+
+	ic, err := NewIniConfigFromSource(src)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if !ic.SectionExists("Section1") {
+		t.Errorf("Expected Section1 to exist")
+	}
+
+	if _, err := NewIniConfigFromSource(NewFileSource("missing.ini")); err == nil {
+		t.Errorf("Expected missing file to fail")
+	}
+}
@@ -0,0 +1,65 @@
+package inifile
+
+import (
+	"io"
+)
+
+// Stats summarises the contents of an IniConfig and the input it was parsed from.
+type Stats struct {
+	// The number of sections containing at least one property (including the global section)
+	Sections int
+
+	// The total number of properties in all sections
+	Properties int
+
+	// The number of properties whose value is the empty string
+	EmptyValues int
+
+	// The number of times a property was defined again in the same section while parsing
+	DuplicateProperties int
+
+	// The length in bytes of the longest line parsed (excluding the line ending)
+	LongestLine int
+
+	// The total number of bytes parsed
+	BytesParsed int64
+}
+
+// Stats returns counts of the sections, properties and empty values currently held in this IniConfig along with
+// information gathered while parsing. Properties added with Add are counted, but do not affect the parsing statistics.
+func (ic *IniConfig) Stats() Stats {
+
+	if err := ic.loadAllSections(); err != nil {
+		ic.debugf("Unable to load all sections: %s", err.Error())
+	}
+
+	st := ic.parseStats
+
+	st.Sections = len(ic.sections)
+
+	for _, properties := range ic.sections {
+
+		st.Properties += len(properties)
+
+		for _, v := range properties {
+			if v.String() == "" {
+				st.EmptyValues++
+			}
+		}
+	}
+
+	return st
+}
+
+// countingReader records the number of bytes read from the underlying reader
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+
+	return n, err
+}
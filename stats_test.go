@@ -0,0 +1,28 @@
+package inifile
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+
+	options := DefaultIniOptions()
+	options.DiscardPropertiesWithNoValue = false
+
+	ic, err := NewIniConfigFromPathWithOptions(filepath.Join(testfiles_base, "suspicious.ini"), options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	ic.Add("other", "empty", "")
+
+	st := ic.Stats()
+
+	expected := Stats{Sections: 2, Properties: 3, EmptyValues: 1, DuplicateProperties: 1, LongestLine: 16, BytesParsed: 50}
+
+	if st != expected {
+		t.Errorf("Expected %+v, was %+v", expected, st)
+	}
+}
@@ -0,0 +1,100 @@
+package inifile
+
+import (
+	"io"
+	"text/template"
+)
+
+// TemplateData is the data passed to templates by RenderTemplate. In a template, sections can be ranged over in order:
+//	{{range .Sections}}[{{.Name}}]{{range .Properties}} {{.Name}}={{.Value}}{{end}}{{end}}
+// or values retrieved directly:
+//	listen {{.Value "server" "port"}};
+//	{{index .Values "server" "host"}}
+type TemplateData struct {
+	// The sections returned by IniConfig.SectionNames, in the same order. As with SectionNames, a section with no
+	// properties (e.g. a header with nothing after it) is not included.
+	Sections []TemplateSection
+
+	// The value of every property, keyed by section and then property name
+	Values map[string]map[string]string
+}
+
+// TemplateSection is a section of the configuration passed to a template.
+type TemplateSection struct {
+	Name string
+
+	// The properties in the section, in the order they were first parsed or added
+	Properties []TemplateProperty
+}
+
+// TemplateProperty is a property of the configuration passed to a template.
+type TemplateProperty struct {
+	Name  string
+	Value string
+}
+
+// Value returns the value of the named property, or an empty string if it does not exist.
+func (td *TemplateData) Value(section, property string) string {
+	return td.Values[section][property]
+}
+
+// Section returns the named section, or a section with no properties if it does not exist.
+func (td *TemplateData) Section(name string) TemplateSection {
+
+	for _, s := range td.Sections {
+		if s.Name == name {
+			return s
+		}
+	}
+
+	return TemplateSection{Name: name}
+}
+
+// Value returns the value of the named property, or an empty string if it does not exist.
+func (ts TemplateSection) Value(property string) string {
+
+	for _, p := range ts.Properties {
+		if p.Name == property {
+			return p.Value
+		}
+	}
+
+	return ""
+}
+
+// RenderTemplate executes the supplied template with a TemplateData describing every section and property of this
+// IniConfig, writing the output to w. This allows derived files (e.g. nginx snippets or systemd drop-ins) to be
+// generated from parsed configuration.
+//
+// Values are retrieved with Value, so interpolation and decryption are applied. An error is returned if a value cannot
+// be retrieved or the template fails.
+func (ic *IniConfig) RenderTemplate(tmpl *template.Template, w io.Writer) error {
+
+	if tmpl == nil {
+		return errorf("Nil template provided")
+	}
+
+	td := &TemplateData{Values: make(map[string]map[string]string)}
+
+	for _, section := range ic.SectionNames() {
+
+		ts := TemplateSection{Name: section}
+		td.Values[section] = make(map[string]string)
+
+		for _, property := range ic.propertyOrder[section] {
+
+			value, err := ic.Value(section, property)
+
+			if err != nil {
+				return err
+			}
+
+			ts.Properties = append(ts.Properties, TemplateProperty{Name: property, Value: value})
+			td.Values[section][property] = value
+		}
+
+		td.Sections = append(td.Sections, ts)
+	}
+
+	return tmpl.Execute(w, td)
+}
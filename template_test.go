@@ -0,0 +1,80 @@
+package inifile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestRenderTemplate(t *testing.T) {
+
+	src := "[server]\nhost=example.com\nport=8080\n[upstream]\na=10.0.0.1\nb=10.0.0.2\n"
+
+	ic, err := newIniConfigFromReader(strings.NewReader(src), "nginx.ini", DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	tmpl := template.Must(template.New("nginx").Parse(
+		`upstream app {
+{{- range (.Section "upstream").Properties}}
+    server {{.Value}};
+{{- end}}
+}
+server {
+    listen {{.Value "server" "port"}};
+    server_name {{index .Values "server" "host"}};
+}
+`))
+
+	var b bytes.Buffer
+
+	if err := ic.RenderTemplate(tmpl, &b); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	expected := "upstream app {\n    server 10.0.0.1;\n    server 10.0.0.2;\n}\nserver {\n    listen 8080;\n    server_name example.com;\n}\n"
+
+	if b.String() != expected {
+		t.Errorf("Unexpected output:\n%s", b.String())
+	}
+
+	if err := ic.RenderTemplate(nil, &b); err == nil {
+		t.Errorf("Expected error for nil template")
+	}
+}
+
+func TestRenderTemplateSectionOrder(t *testing.T) {
+
+	src := "a=1\n[empty]\n[second]\nb=2\n[first]\nc=3\n"
+
+	ic, err := newIniConfigFromReader(strings.NewReader(src), "order.ini", DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	tmpl := template.Must(template.New("order").Parse(`{{range .Sections}}[{{.Name}}]{{end}}`))
+
+	var b bytes.Buffer
+
+	if err := ic.RenderTemplate(tmpl, &b); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	expected := ""
+
+	for _, section := range ic.SectionNames() {
+		expected += "[" + section + "]"
+	}
+
+	if b.String() != expected {
+		t.Errorf("Expected sections %s, got %s", expected, b.String())
+	}
+
+	if strings.Contains(b.String(), "[empty]") {
+		t.Errorf("Expected section without properties to be left out")
+	}
+}
@@ -0,0 +1,75 @@
+package inifile
+
+import (
+	"strings"
+)
+
+// A property or section removed by a tombstone (see TombstoneValue and TombstoneSectionPrefix in IniOptions)
+type tombstone struct {
+	section string
+
+	// The removed property, or an empty string if the whole section was removed
+	property string
+}
+
+// tombstoneSection returns the name of the section a section header refers to. If the name starts with the
+// TombstoneSectionPrefix in the IniOptions, the prefix is removed and any properties already in the section are removed.
+func (ic *IniConfig) tombstoneSection(name string, line int) string {
+
+	prefix := ic.options.TombstoneSectionPrefix
+
+	if prefix == "" || !strings.HasPrefix(name, prefix) {
+		return name
+	}
+
+	name = strings.TrimSpace(name[len(prefix):])
+
+	ic.debugf("Removing section [%s] (tombstone on line %d)", name, line)
+	ic.deleteSection(name)
+	ic.tombstones = append(ic.tombstones, tombstone{section: ic.normalise(name)})
+
+	return name
+}
+
+// isTombstone returns true if the supplied (unquoted) value is the TombstoneValue in the IniOptions.
+func (ic *IniConfig) isTombstone(value string) bool {
+
+	tv := ic.options.TombstoneValue
+
+	return tv != "" && value == tv
+}
+
+// unsetProperty removes a property whose value is a tombstone and records the tombstone so that it is also applied when
+// this IniConfig is merged into another.
+func (ic *IniConfig) unsetProperty(section, property string, line int) {
+
+	ic.debugf("Removing property [%s].%s (tombstone on line %d)", section, property, line)
+	ic.Delete(section, property)
+	ic.tombstones = append(ic.tombstones, tombstone{section: ic.normalise(section), property: ic.normalise(property)})
+}
+
+// applyTombstones removes the properties and sections removed by tombstones in other from this IniConfig, in the order
+// the tombstones were found, and records them so they are applied to any IniConfig this one is merged into.
+func (ic *IniConfig) applyTombstones(other *IniConfig) {
+
+	for _, t := range other.tombstones {
+
+		if t.property == "" {
+			ic.deleteSection(t.section)
+		} else {
+			ic.Delete(t.section, t.property)
+		}
+
+		ic.tombstones = append(ic.tombstones, t)
+	}
+}
+
+// deleteSection removes every property in the named section.
+func (ic *IniConfig) deleteSection(section string) {
+
+	ic.loadSection(section)
+
+	for _, property := range append([]string(nil), ic.propertyOrder[ic.normalise(section)]...) {
+		ic.Delete(section, property)
+	}
+}
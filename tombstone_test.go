@@ -0,0 +1,76 @@
+package inifile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTombstonesInLoadDir(t *testing.T) {
+
+	dir := t.TempDir()
+
+	os.WriteFile(filepath.Join(dir, "10-base.ini"), []byte("[server]\nport=80\ndebug=true\n[cache]\nsize=10\nttl=60\n"), 0600)
+	os.WriteFile(filepath.Join(dir, "20-override.ini"), []byte("[server]\ndebug=!unset\n[-cache]\nsize=20\n"), 0600)
+
+	opts := DefaultIniOptions()
+	opts.TombstoneValue = "!unset"
+	opts.TombstoneSectionPrefix = "-"
+
+	ic, err := LoadDir(filepath.Join(dir, "*.ini"), opts, MergeOverride)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, "server", "port", "80")
+	checkValue(t, ic, "cache", "size", "20")
+
+	if ic.PropertyExists("server", "debug") {
+		t.Errorf("Expected tombstone value to remove the property")
+	}
+
+	if ic.PropertyExists("cache", "ttl") {
+		t.Errorf("Expected tombstone section to remove earlier properties")
+	}
+}
+
+func TestTombstonesInOneFile(t *testing.T) {
+
+	opts := DefaultIniOptions()
+	opts.TombstoneValue = "!unset"
+	opts.StripEnclosingQuotes = true
+
+	ic, err := newIniConfigFromReader(strings.NewReader("[a]\nb=1\nc=2\nb=!unset\nd=\"!unset\"\n"), "test", opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if ic.PropertyExists("a", "b") {
+		t.Errorf("Expected tombstone to remove the earlier definition")
+	}
+
+	checkValue(t, ic, "a", "c", "2")
+	checkValue(t, ic, "a", "d", "!unset")
+
+	other := NewIniConfigFromMap(map[string]map[string]string{"a": {"b": "9"}})
+	other.Merge(ic, MergeOverride)
+
+	if other.PropertyExists("a", "b") {
+		t.Errorf("Expected tombstone to be applied when merged")
+	}
+}
+
+func TestTombstoneSectionsNotLazy(t *testing.T) {
+
+	opts := DefaultIniOptions()
+	opts.TombstoneSectionPrefix = "-"
+
+	content := "[db]\nhost=a\n[-db]\nhost=b\n"
+
+	if _, err := NewLazyIniConfig(strings.NewReader(content), int64(len(content)), opts); err == nil {
+		t.Errorf("Expected TombstoneSectionPrefix to be rejected for lazily parsed files")
+	}
+}
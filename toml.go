@@ -0,0 +1,448 @@
+package inifile
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ToTOML converts this IniConfig into a TOML document. Global properties become keys at the top of the document and
+// each named section becomes a table. Sections are written in the order of SectionNames and properties in the order
+// they were first parsed or added.
+//
+// Raw values are written (before interpolation or decryption). A value is written as a TOML boolean, integer or float
+// if converting it back to text gives exactly the same value (e.g. true, 42 or 1.5); all other values are written as
+// strings. As a result NewIniConfigFromTOML recreates the same sections, properties and values.
+//
+// An error is returned if a value is unset (see NullLiterals in IniOptions) or is not valid UTF-8, or if a global
+// property has the same name as a section, as none of these can be represented in TOML.
+func (ic *IniConfig) ToTOML() ([]byte, error) {
+
+	var b bytes.Buffer
+
+	for i, section := range ic.SectionNames() {
+
+		stored := ic.sections[section]
+
+		if section != GLOBAL_SECTION {
+
+			if _, clash := ic.sections[GLOBAL_SECTION][section]; clash {
+				return nil, errorf("Unable to convert to TOML: global property %s has the same name as a section", section)
+			}
+
+			if i > 0 {
+				b.WriteString("\n")
+			}
+
+			b.WriteString("[" + tomlKey(section) + "]\n")
+		}
+
+		for _, property := range ic.propertyOrder[section] {
+
+			pv, found := stored[property]
+
+			if !found {
+				continue
+			}
+
+			if !pv.IsSet() {
+				return nil, errorf("Unable to convert to TOML: [%s].%s has no value and TOML has no null", section, property)
+			}
+
+			if !utf8.ValidString(pv.String()) {
+				return nil, errorf("Unable to convert to TOML: [%s].%s is not valid UTF-8", section, property)
+			}
+
+			b.WriteString(tomlKey(property) + " = " + tomlValue(pv.String()) + "\n")
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// tomlKey returns the supplied name as a TOML key, quoted unless it can be written as a bare key.
+func tomlKey(name string) string {
+
+	if name == "" {
+		return `""`
+	}
+
+	for _, r := range name {
+
+		if !(r == '_' || r == '-' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return tomlString(name)
+		}
+	}
+
+	return name
+}
+
+// tomlValue returns the supplied value as a TOML boolean, integer or float if it can be read back as exactly the same
+// text, otherwise as a TOML string.
+func tomlValue(v string) string {
+
+	if v == "true" || v == "false" {
+		return v
+	}
+
+	if i, err := strconv.ParseInt(v, 10, 64); err == nil && strconv.FormatInt(i, 10) == v {
+		return v
+	}
+
+	if strings.Contains(v, ".") {
+
+		if f, err := strconv.ParseFloat(v, 64); err == nil && strconv.FormatFloat(f, 'f', -1, 64) == v && !strings.HasSuffix(v, ".") {
+			return v
+		}
+	}
+
+	return tomlString(v)
+}
+
+// tomlString returns the supplied text as a TOML basic string.
+func tomlString(s string) string {
+
+	var b strings.Builder
+
+	b.WriteByte('"')
+
+	for _, r := range s {
+
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+// NewIniConfigFromTOML creates an IniConfig from a TOML document in the format produced by ToTOML, using the supplied
+// options for subsequent access. Sections and properties are added in the order they appear in the document.
+//
+// Only the flat subset of TOML that can be represented in an INI file is supported: keys at the top of the document
+// (global properties) and tables (sections) whose values are strings, integers, floats or booleans. Strings may be
+// basic or literal strings on a single line. Integers written in hexadecimal, octal or binary are converted to decimal
+// and underscores are removed from numbers; other values are stored as written.
+//
+// An error giving the line number is returned if the document uses a feature that cannot be represented, such as
+// arrays, inline tables, arrays of tables, nested tables, dotted keys, multi-line strings or dates and times, or if a
+// key or table is defined more than once.
+func NewIniConfigFromTOML(data []byte, options *IniOptions) (*IniConfig, error) {
+
+	if options == nil {
+		return nil, errorf("Nil IniOptions provided")
+	}
+
+	ic := newIniConfigFromMap(nil, options)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	section := GLOBAL_SECTION
+	tables := map[string]bool{GLOBAL_SECTION: true}
+	keys := make(map[string]bool)
+	lineNumber := 0
+
+	for scanner.Scan() {
+
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[[") {
+			return nil, tomlError("arrays of tables are not supported", lineNumber)
+		}
+
+		if line[0] == '[' {
+
+			name, rest, err := tomlReadKey(strings.TrimLeft(line[1:], " \t"))
+
+			if err != nil {
+				return nil, tomlError(err.Error(), lineNumber)
+			}
+
+			rest = strings.TrimLeft(rest, " \t")
+
+			if !strings.HasPrefix(rest, "]") {
+				return nil, tomlError("expected ] after table name", lineNumber)
+			}
+
+			if err := tomlTrailing(rest[1:]); err != nil {
+				return nil, tomlError(err.Error(), lineNumber)
+			}
+
+			if tables[name] {
+				return nil, tomlError(fmt.Sprintf("table %s is defined more than once", name), lineNumber)
+			}
+
+			section = name
+			tables[name] = true
+
+			continue
+		}
+
+		key, rest, err := tomlReadKey(line)
+
+		if err != nil {
+			return nil, tomlError(err.Error(), lineNumber)
+		}
+
+		rest = strings.TrimLeft(rest, " \t")
+
+		if !strings.HasPrefix(rest, "=") {
+			return nil, tomlError("expected = after key", lineNumber)
+		}
+
+		value, err := tomlReadValue(strings.TrimLeft(rest[1:], " \t"))
+
+		if err != nil {
+			return nil, tomlError(err.Error(), lineNumber)
+		}
+
+		qualified := section + "\x00" + key
+
+		if keys[qualified] {
+			return nil, tomlError(fmt.Sprintf("key %s is defined more than once", key), lineNumber)
+		}
+
+		keys[qualified] = true
+
+		ic.Add(section, key, value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errorf("Unable to convert TOML to an IniConfig: %w", err)
+	}
+
+	return ic, nil
+}
+
+// tomlError creates an error describing a problem on the supplied line of a TOML document.
+func tomlError(problem string, line int) error {
+	return errorf("Unable to convert TOML to an IniConfig: %s on line %d", problem, line)
+}
+
+// tomlReadKey reads a bare or quoted key from the start of s, returning the key and the rest of s.
+func tomlReadKey(s string) (key, rest string, err error) {
+
+	if s == "" {
+		return "", "", errorf("expected a key")
+	}
+
+	if s[0] == '"' || s[0] == '\'' {
+		key, rest, err = tomlReadString(s)
+	} else {
+
+		end := 0
+
+		for end < len(s) && (s[end] == '_' || s[end] == '-' || s[end] >= 'a' && s[end] <= 'z' || s[end] >= 'A' && s[end] <= 'Z' || s[end] >= '0' && s[end] <= '9') {
+			end++
+		}
+
+		if end == 0 {
+			return "", "", errorf("invalid key")
+		}
+
+		key, rest = s[:end], s[end:]
+	}
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if strings.HasPrefix(strings.TrimLeft(rest, " \t"), ".") {
+		return "", "", errorf("dotted keys and nested tables are not supported")
+	}
+
+	return key, rest, nil
+}
+
+// tomlReadValue converts the text of a value (with any trailing comment) into the text stored in an IniConfig.
+func tomlReadValue(s string) (string, error) {
+
+	if s == "" {
+		return "", errorf("expected a value")
+	}
+
+	switch {
+	case strings.HasPrefix(s, `"""`) || strings.HasPrefix(s, "'''"):
+		return "", errorf("multi-line strings are not supported")
+	case s[0] == '[':
+		return "", errorf("arrays are not supported")
+	case s[0] == '{':
+		return "", errorf("inline tables are not supported")
+	case s[0] == '"' || s[0] == '\'':
+
+		v, rest, err := tomlReadString(s)
+
+		if err != nil {
+			return "", err
+		}
+
+		return v, tomlTrailing(rest)
+	}
+
+	v := s
+
+	if i := strings.IndexAny(s, " \t#"); i >= 0 {
+
+		v = s[:i]
+
+		if err := tomlTrailing(s[i:]); err != nil {
+			return "", err
+		}
+	}
+
+	switch v {
+	case "true", "false", "inf", "+inf", "-inf", "nan", "+nan", "-nan":
+		return v, nil
+	}
+
+	if tomlDateTime.MatchString(v) {
+		return "", errorf("dates and times are not supported")
+	}
+
+	number := strings.Replace(v, "_", "", -1)
+
+	if len(number) > 2 && number[0] == '0' && strings.IndexByte("xob", number[1]) >= 0 {
+
+		i, err := strconv.ParseInt(number, 0, 64)
+
+		if err != nil {
+			return "", errorf("invalid integer %s", v)
+		}
+
+		return strconv.FormatInt(i, 10), nil
+	}
+
+	if _, err := strconv.ParseFloat(number, 64); err != nil || strings.Trim(number, "0123456789+-.eE") != "" {
+		return "", errorf("invalid value %s", v)
+	}
+
+	return number, nil
+}
+
+// The start of a TOML date (1979-05-27) or time (07:32:00)
+var tomlDateTime = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}|\d{2}:\d{2})`)
+
+// tomlReadString reads a basic or literal string from the start of s, returning its value and the rest of s.
+func tomlReadString(s string) (value, rest string, err error) {
+
+	quote := s[0]
+
+	if quote == '\'' {
+
+		end := strings.IndexByte(s[1:], '\'')
+
+		if end < 0 {
+			return "", "", errorf("unterminated string")
+		}
+
+		return s[1 : end+1], s[end+2:], nil
+	}
+
+	var b strings.Builder
+
+	for i := 1; i < len(s); i++ {
+
+		c := s[i]
+
+		if c == '"' {
+			return b.String(), s[i+1:], nil
+		}
+
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+
+		if i+1 == len(s) {
+			break
+		}
+
+		i++
+
+		switch s[i] {
+		case 'b':
+			b.WriteByte('\b')
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'f':
+			b.WriteByte('\f')
+		case 'r':
+			b.WriteByte('\r')
+		case 'e':
+			b.WriteByte(0x1b)
+		case '"', '\\':
+			b.WriteByte(s[i])
+		case 'u', 'U':
+
+			size := 4
+
+			if s[i] == 'U' {
+				size = 8
+			}
+
+			if i+size >= len(s) {
+				return "", "", errorf("invalid escape sequence")
+			}
+
+			r, err := strconv.ParseUint(s[i+1:i+1+size], 16, 32)
+
+			if err != nil || !utf8.ValidRune(rune(r)) {
+				return "", "", errorf("invalid escape sequence")
+			}
+
+			b.WriteRune(rune(r))
+			i += size
+
+		default:
+			return "", "", errorf("invalid escape sequence")
+		}
+	}
+
+	return "", "", errorf("unterminated string")
+}
+
+// tomlTrailing returns an error if the text after a value or table name is anything other than whitespace and an
+// optional comment.
+func tomlTrailing(s string) error {
+
+	s = strings.TrimLeft(s, " \t")
+
+	if s != "" && s[0] != '#' {
+		return errorf("unexpected text %s", s)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package inifile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTOMLRoundTrip(t *testing.T) {
+
+	ic, err := newIniConfigFromReader(strings.NewReader("name=app\n[server]\nport=8080\nratio=1.5\nenabled=true\nzip=007\npath=C:\\temp\n[my.section]\nkey=\"quoted\"\n"), "test", DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	b, err := ic.ToTOML()
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	expected := "name = \"app\"\n\n[server]\nport = 8080\nratio = 1.5\nenabled = true\nzip = \"007\"\npath = \"C:\\\\temp\"\n\n[\"my.section\"]\nkey = \"\\\"quoted\\\"\"\n"
+
+	if string(b) != expected {
+		t.Errorf("Unexpected TOML %q", string(b))
+	}
+
+	converted, err := NewIniConfigFromTOML(b, DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, converted, GLOBAL_SECTION, "name", "app")
+	checkValue(t, converted, "server", "port", "8080")
+	checkValue(t, converted, "server", "ratio", "1.5")
+	checkValue(t, converted, "server", "enabled", "true")
+	checkValue(t, converted, "server", "zip", "007")
+	checkValue(t, converted, "server", "path", "C:\\temp")
+	checkValue(t, converted, "my.section", "key", "\"quoted\"")
+}
+
+func TestTOMLImportValues(t *testing.T) {
+
+	doc := "# Comment\n[ limits ] # trailing\nmax = 1_000\nmask = 0xff\nliteral = 'C:\\dir'\nunicode = \"caf\\u00E9\"\nbig = 6.02e23 # comment\nsmall = 1.5e-10\ntiny = 3.0e-5\n"
+
+	ic, err := NewIniConfigFromTOML([]byte(doc), DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, "limits", "max", "1000")
+	checkValue(t, ic, "limits", "mask", "255")
+	checkValue(t, ic, "limits", "literal", "C:\\dir")
+	checkValue(t, ic, "limits", "unicode", "café")
+	checkValue(t, ic, "limits", "big", "6.02e23")
+	checkValue(t, ic, "limits", "small", "1.5e-10")
+	checkValue(t, ic, "limits", "tiny", "3.0e-5")
+}
+
+func TestTOMLUnsupported(t *testing.T) {
+
+	docs := map[string]string{
+		"a = [1, 2]\n":               "arrays are not supported on line 1",
+		"a = { b = 1 }\n":            "inline tables are not supported on line 1",
+		"[[items]]\n":                "arrays of tables are not supported on line 1",
+		"[a.b]\n":                    "dotted keys and nested tables are not supported on line 1",
+		"[a]\nb.c = 1\n":             "dotted keys and nested tables are not supported on line 2",
+		"a = \"\"\"\ntext\n\"\"\"\n": "multi-line strings are not supported on line 1",
+		"a = 1979-05-27\n":           "dates and times are not supported on line 1",
+		"a = 07:32:00\n":             "dates and times are not supported on line 1",
+		"a = 1\na = 2\n":             "key a is defined more than once on line 2",
+		"[a]\n[a]\n":                 "table a is defined more than once on line 2",
+		"a = word\n":                 "invalid value word on line 1",
+		"a = \"open\n":               "unterminated string on line 1",
+	}
+
+	for doc, expected := range docs {
+
+		_, err := NewIniConfigFromTOML([]byte(doc), DefaultIniOptions())
+
+		if err == nil || !strings.HasSuffix(err.Error(), expected) {
+			t.Errorf("Expected error ending %q for %q, got %v", expected, doc, err)
+		}
+	}
+}
+
+func TestTOMLUnrepresentable(t *testing.T) {
+
+	opts := DefaultIniOptions()
+	opts.NullLiterals = []string{"null"}
+
+	ic, _ := newIniConfigFromReader(strings.NewReader("[a]\nb=null\n"), "test", opts)
+
+	if _, err := ic.ToTOML(); err == nil {
+		t.Errorf("Expected unset value to fail")
+	}
+
+	ic = NewIniConfigFromMap(map[string]map[string]string{GLOBAL_SECTION: {"db": "x"}, "db": {"host": "h"}})
+
+	if _, err := ic.ToTOML(); err == nil {
+		t.Errorf("Expected global property with the same name as a section to fail")
+	}
+}
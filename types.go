@@ -0,0 +1,168 @@
+package inifile
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// The types that can be declared for a property with a type annotation (see TypeAnnotations in IniOptions)
+var annotationTypes = []string{"string", "int", "int32", "int64", "uint16", "uint32", "uint64", "float32", "float64", "bool"}
+
+// checkType returns an error if the value of the property cannot be converted to the declared type.
+func (ic *IniConfig) checkType(declared, section, property string) error {
+
+	var err error
+
+	switch declared {
+	case "int":
+		_, err = ic.ValueAsInt(section, property)
+	case "int32":
+		_, err = ic.ValueAsInt32(section, property)
+	case "int64":
+		_, err = ic.ValueAsInt64(section, property)
+	case "uint16":
+		_, err = ic.ValueAsUint16(section, property)
+	case "uint32":
+		_, err = ic.ValueAsUint32(section, property)
+	case "uint64":
+		_, err = ic.ValueAsUint64(section, property)
+	case "float32":
+		_, err = ic.ValueAsFloat32(section, property)
+	case "float64":
+		_, err = ic.ValueAsFloat64(section, property)
+	case "bool":
+		_, err = ic.ValueAsBool(section, property)
+	default:
+		_, err = ic.Value(section, property)
+	}
+
+	return err
+}
+
+// splitTypeAnnotation separates a property name like port:int into the name and the declared type. Names without an
+// annotation, or whose annotation is not one of the supported types, are returned unchanged with an empty type.
+func splitTypeAnnotation(name string) (string, string) {
+
+	i := strings.LastIndexByte(name, ':')
+
+	if i < 0 {
+		return name, ""
+	}
+
+	declared := strings.ToLower(strings.TrimSpace(name[i+1:]))
+
+	if !isAnnotationType(declared) {
+		return name, ""
+	}
+
+	return strings.TrimSpace(name[:i]), declared
+}
+
+// isAnnotationType returns true if the type can be declared with a type annotation.
+func isAnnotationType(declared string) bool {
+
+	for _, t := range annotationTypes {
+		if t == declared {
+			return true
+		}
+	}
+
+	return false
+}
+
+// declareType records the type declared for a property in the file.
+func (ic *IniConfig) declareType(section, property, declared string) {
+
+	section = ic.normalise(section)
+
+	if ic.types == nil {
+		ic.types = make(map[string]map[string]string)
+	}
+
+	if ic.types[section] == nil {
+		ic.types[section] = make(map[string]string)
+	}
+
+	ic.types[section][ic.normalise(property)] = declared
+}
+
+// checkDeclaredTypes returns an error if any of the supplied properties cannot be converted to its declared type.
+func (ic *IniConfig) checkDeclaredTypes(properties [][2]string) error {
+
+	for _, p := range properties {
+
+		section, property := p[0], p[1]
+
+		declared, found := ic.DeclaredType(section, property)
+
+		if !found || !ic.PropertyExists(section, property) {
+			continue
+		}
+
+		if err := ic.checkType(declared, section, property); err != nil {
+
+			pv, _ := ic.storedValue(section, property)
+
+			return &ParseError{Line: int(pv.line), Message: "Value does not match declared type " + declared + ": " + err.Error(), Err: err}
+		}
+	}
+
+	return nil
+}
+
+// DeclaredType returns the type declared for the property with an annotation like port:int=8080 (see TypeAnnotations
+// in IniOptions) and true, or an empty string and false if no type was declared.
+func (ic *IniConfig) DeclaredType(sectionName, propertyName string) (string, bool) {
+
+	declared, found := ic.types[ic.normalise(sectionName)][ic.normalise(propertyName)]
+
+	return declared, found
+}
+
+// Validate checks that the value of every property with a declared type (see DeclaredType) can be converted to that
+// type. Validate is called automatically when a file is parsed with TypeAnnotations set in the IniOptions, but can be
+// called again after properties have been added.
+//
+// Returns nil if all values are valid, or an error joining the errors for each invalid value (in section and property
+// order).
+func (ic *IniConfig) Validate() error {
+
+	if err := ic.loadAllSections(); err != nil {
+		return err
+	}
+
+	sections := make([]string, 0, len(ic.types))
+
+	for section := range ic.types {
+		sections = append(sections, section)
+	}
+
+	sort.Strings(sections)
+
+	var errs []error
+
+	for _, section := range sections {
+
+		properties := make([]string, 0, len(ic.types[section]))
+
+		for property := range ic.types[section] {
+			properties = append(properties, property)
+		}
+
+		sort.Strings(properties)
+
+		for _, property := range properties {
+
+			if !ic.PropertyExists(section, property) {
+				continue
+			}
+
+			if err := ic.checkType(ic.types[section][property], section, property); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
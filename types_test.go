@@ -0,0 +1,66 @@
+package inifile
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTypeAnnotations(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "annotated.ini")
+
+	options := DefaultIniOptions()
+	options.TypeAnnotations = true
+
+	ic, err := NewIniConfigFromPathWithOptions(path, options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	for property, expected := range map[string]string{"port": "int", "timeout": "float64", "debug": "bool", "name": "string"} {
+
+		if declared, found := ic.DeclaredType("server", property); !found || declared != expected {
+			t.Errorf("Expected %s to be declared as %s, was %s", property, expected, declared)
+		}
+	}
+
+	if v, _ := ic.ValueAsInt("server", "port"); v != 8080 {
+		t.Errorf("Unexpected value %d", v)
+	}
+
+	if _, found := ic.DeclaredType("server", "url"); found {
+		t.Errorf("Did not expect a type for url")
+	}
+
+	if err := ic.Validate(); err != nil {
+		t.Errorf("Unexpected error %s", err.Error())
+	}
+
+	ic.Add("server", "port", "eighty")
+	ic.Add("server", "debug", "maybe")
+
+	err = ic.Validate()
+
+	if !errors.Is(err, ErrConversion) || !strings.Contains(err.Error(), "eighty") || !strings.Contains(err.Error(), "debug") {
+		t.Errorf("Expected both invalid values to be reported, was %v", err)
+	}
+
+	if ic, _ := NewIniConfigFromPath(path); !ic.PropertyExists("server", "port:int") {
+		t.Errorf("Expected annotation to be part of the name without TypeAnnotations")
+	}
+}
+
+func TestTypeAnnotationMismatch(t *testing.T) {
+
+	options := DefaultIniOptions()
+	options.TypeAnnotations = true
+
+	_, err := newIniConfigFromReader(strings.NewReader("[s]\nport:uint16=70000\n"), "", options)
+
+	if !errors.Is(err, ErrConversion) {
+		t.Errorf("Expected value outside declared type to fail, was %v", err)
+	}
+}
@@ -0,0 +1,358 @@
+package inifile
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"os"
+	"sort"
+	"strings"
+)
+
+// UpdateFile sets the values of properties in the INI file at the supplied path without rewriting the rest of the file.
+// changes maps section names (GLOBAL_SECTION for the global section) to the new values of properties in that section.
+//
+// Only the affected parts of the file are edited: the value of an existing property is replaced where it appears (the
+// last definition if the property is defined more than once), leaving its name, spacing and any inline comment intact.
+// Missing properties are added on a new line after the last property of their section and missing sections are added at
+// the end of the file. Everything else, including comments, blank lines, layout and line endings, is kept verbatim. The
+// file is replaced atomically (see SaveAtomic) with its existing permissions.
+//
+// The file is parsed with the supplied options, which are also used to escape and quote the new names and values.
+// Properties defined by a LineClassifier are not found and are added again. If VerifyChecksum is set in the options, the
+// file's checksum footer is updated to match the edited content.
+func UpdateFile(path string, changes map[string]map[string]string, options *IniOptions) error {
+	return editFile(path, changes, nil, options)
+}
+
+// RemoveFromFile removes properties from the INI file at the supplied path without rewriting the rest of the file.
+// removals maps section names (GLOBAL_SECTION for the global section) to the names of the properties to remove from
+// that section.
+//
+// Every line holding a definition of a removed property is deleted, including continuation lines and any comment on
+// the same line. Everything else, including comments on other lines, blank lines and section headers (even of sections
+// left empty), is kept verbatim. Properties that are not defined are ignored. The file is parsed with the supplied
+// options and replaced atomically (see SaveAtomic) with its existing permissions. If VerifyChecksum is set in the
+// options, the file's checksum footer is updated to match the edited content.
+func RemoveFromFile(path string, removals map[string][]string, options *IniOptions) error {
+	return editFile(path, nil, removals, options)
+}
+
+// editFile applies the supplied changes and removals to the file at the supplied path (see UpdateFile and
+// RemoveFromFile).
+func editFile(path string, changes map[string]map[string]string, removals map[string][]string, options *IniOptions) error {
+
+	if options == nil {
+		return errorf("Nil IniOptions provided")
+	}
+
+	fi, err := os.Stat(path)
+
+	if err != nil {
+		return err
+	}
+
+	src, err := os.ReadFile(path)
+
+	if err != nil {
+		return err
+	}
+
+	updated, err := editContent(src, changes, removals, options)
+
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, updated, fi.Mode().Perm(), false)
+}
+
+// An edit replaces the bytes from start up to (not including) end with text
+type contentEdit struct {
+	start int
+	end   int
+	text  string
+}
+
+// editContent returns a copy of src with the supplied changes and removals applied (see UpdateFile and RemoveFromFile).
+// A property that is both changed and removed is removed.
+func editContent(src []byte, changes map[string]map[string]string, removals map[string][]string, options *IniOptions) ([]byte, error) {
+
+	eol := LF
+
+	if i := bytes.IndexByte(src, '\n'); i > 0 && src[i-1] == '\r' {
+		eol = CRLF
+	}
+
+	if options.VerifyChecksum {
+
+		//Check the existing content before editing it, then replace the footer
+		ic := &IniConfig{options: options}
+
+		if _, err := ic.checkedContent(bytes.NewReader(src)); err != nil {
+			return nil, err
+		}
+
+		body, _ := splitFooter(src, options.CommentStart, ChecksumLabel)
+
+		o := *options
+		o.VerifyChecksum = false
+
+		updated, err := editContent(body, changes, removals, &o)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(updated) > 0 && updated[len(updated)-1] != '\n' {
+			updated = append(updated, eol...)
+		}
+
+		sum := sha256.Sum256(updated)
+
+		return append(updated, checksumFooter(sum[:], options.CommentStart, eol)...), nil
+	}
+
+	//Positions are needed to find the lines to edit
+	o := *options
+	o.RecordPositions = true
+	o.PostParse = nil
+
+	ic, err := newIniConfigFromReader(bytes.NewReader(src), "", &o)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var edits []contentEdit
+	var added []string
+
+	removed := make(map[string]map[string]bool)
+
+	for section, properties := range removals {
+
+		for _, property := range properties {
+
+			if removed[ic.normalise(section)] == nil {
+				removed[ic.normalise(section)] = make(map[string]bool)
+			}
+
+			removed[ic.normalise(section)][ic.normalise(property)] = true
+
+			edits = append(edits, ic.removalEdits(src, section, property)...)
+		}
+	}
+
+	sections := make([]string, 0, len(changes))
+
+	for section := range changes {
+		sections = append(sections, section)
+	}
+
+	sort.Strings(sections)
+
+	for _, section := range sections {
+
+		properties := make([]string, 0, len(changes[section]))
+
+		for property := range changes[section] {
+			properties = append(properties, property)
+		}
+
+		sort.Strings(properties)
+
+		var missing []string
+
+		for _, property := range properties {
+
+			if removed[ic.normalise(section)][ic.normalise(property)] {
+				continue
+			}
+
+			value := ic.quoteIfNeeded(ic.escapeComments(changes[section][property]), DefaultIniWriteOptions())
+
+			if e, found := ic.valueEdit(src, section, property, value); found {
+				edits = append(edits, e)
+			} else {
+				missing = append(missing, ic.escapeComments(ic.escapePropertyName(property))+string(ic.assignmentSymbol())+value+eol)
+			}
+		}
+
+		if len(missing) == 0 {
+			continue
+		}
+
+		if at, found := ic.insertionPoint(src, section); found {
+
+			text := strings.Join(missing, "")
+
+			if at == len(src) && at > 0 && src[at-1] != '\n' {
+				text = eol + text
+			}
+
+			edits = append(edits, contentEdit{at, at, text})
+
+		} else {
+			header := ic.sectionHeader(section) + eol
+			added = append(added, header+strings.Join(missing, ""))
+		}
+	}
+
+	if len(added) > 0 {
+
+		end := len(src)
+		text := ""
+
+		if end > 0 && src[end-1] != '\n' && !insertsAt(edits, end) {
+			text = eol
+		}
+
+		if end > 0 {
+			text += eol
+		}
+
+		edits = append(edits, contentEdit{end, end, text + strings.Join(added, eol)})
+	}
+
+	//Insertions must come before removals starting at the same offset
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].start < edits[j].start || edits[i].start == edits[j].start && edits[i].end < edits[j].end
+	})
+
+	var b bytes.Buffer
+
+	last := 0
+
+	for _, e := range edits {
+		b.Write(src[last:e.start])
+		b.WriteString(e.text)
+		last = e.end
+	}
+
+	b.Write(src[last:])
+
+	return b.Bytes(), nil
+}
+
+// valueEdit returns an edit replacing the value of the last definition of the supplied property, or false if the
+// property is not defined.
+func (ic *IniConfig) valueEdit(src []byte, section, property, value string) (contentEdit, bool) {
+
+	var key *Element
+	var values []Element
+
+	for i, e := range ic.elements {
+
+		if !ic.isElementOf(e, section, property) {
+			continue
+		}
+
+		switch e.Kind {
+		case ElementKey:
+			key = &ic.elements[i]
+			values = nil
+		case ElementValue:
+			values = append(values, e)
+		}
+	}
+
+	if key == nil {
+		return contentEdit{}, false
+	}
+
+	if len(values) == 0 {
+		//The property has no value, so the value is inserted after the assignment symbol
+		line := src[key.Span.Start.Offset:]
+
+		if i := bytes.IndexByte(line, '\n'); i >= 0 {
+			line = line[:i]
+		}
+
+		at := key.Span.Start.Offset + ic.assignmentIndex(string(line), 0) + 1
+
+		return contentEdit{at, at, value}, true
+	}
+
+	return contentEdit{values[0].Span.Start.Offset, values[len(values)-1].Span.End.Offset, value}, true
+}
+
+// removalEdits returns edits deleting every line holding a definition of the supplied property, including continuation
+// lines.
+func (ic *IniConfig) removalEdits(src []byte, section, property string) []contentEdit {
+
+	var edits []contentEdit
+
+	for _, e := range ic.elements {
+
+		if !ic.isElementOf(e, section, property) {
+			continue
+		}
+
+		start := e.Span.Start.Offset - (e.Span.Start.Column - 1)
+		end := len(src)
+
+		if i := bytes.IndexByte(src[e.Span.End.Offset:], '\n'); i >= 0 {
+			end = e.Span.End.Offset + i + 1
+		}
+
+		if e.Kind == ElementKey || len(edits) == 0 {
+			edits = append(edits, contentEdit{start, end, ""})
+		} else if end > edits[len(edits)-1].end {
+			edits[len(edits)-1].end = end
+		}
+	}
+
+	return edits
+}
+
+// insertionPoint returns the offset at which new properties should be added to the supplied section (the start of the
+// line after its last property or header), or false if a named section does not appear in the content.
+func (ic *IniConfig) insertionPoint(src []byte, section string) (int, bool) {
+
+	end := -1
+
+	for _, e := range ic.elements {
+
+		if e.Kind != ElementComment && ic.normalise(e.Section) == ic.normalise(section) {
+			end = e.Span.End.Offset
+		}
+	}
+
+	if end < 0 {
+
+		if section != GLOBAL_SECTION {
+			return 0, false
+		}
+
+		//Global properties go before the first section header
+		for _, e := range ic.elements {
+			if e.Kind == ElementSection {
+				return e.Span.Start.Offset - (e.Span.Start.Column - 1), true
+			}
+		}
+
+		return len(src), true
+	}
+
+	if i := bytes.IndexByte(src[end:], '\n'); i >= 0 {
+		return end + i + 1, true
+	}
+
+	return len(src), true
+}
+
+// isElementOf returns true if the element is part of the supplied property.
+func (ic *IniConfig) isElementOf(e Element, section, property string) bool {
+	return ic.normalise(e.Section) == ic.normalise(section) && ic.normalise(e.Property) == ic.normalise(property)
+}
+
+// insertsAt returns true if one of the edits inserts text at the supplied offset.
+func insertsAt(edits []contentEdit, offset int) bool {
+
+	for _, e := range edits {
+		if e.start == offset && e.end == offset {
+			return true
+		}
+	}
+
+	return false
+}
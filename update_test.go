@@ -0,0 +1,119 @@
+package inifile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUpdateFile(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "update.ini")
+
+	src := "; Managed by hand\r\nname = test\r\n\r\n[server]\r\nhost   =  localhost   ;The host\r\nport=80\r\n\r\n; Logging\r\n[log]\r\nlevel=info"
+
+	os.WriteFile(path, []byte(src), 0640)
+
+	opts := DefaultIniOptions()
+	opts.AllowInlineComments = true
+
+	changes := map[string]map[string]string{
+		GLOBAL_SECTION: {"name": "live"},
+		"server":       {"host": "remote", "timeout": "30"},
+		"log":          {"file": "/var/log/app.log"},
+		"cache":        {"size": "10"},
+	}
+
+	if err := UpdateFile(path, changes, opts); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	expected := "; Managed by hand\r\nname = live\r\n\r\n[server]\r\nhost   =  remote   ;The host\r\nport=80\r\ntimeout=30\r\n\r\n; Logging\r\n[log]\r\nlevel=info\r\nfile=/var/log/app.log\r\n\r\n[cache]\r\nsize=10\r\n"
+
+	b, _ := os.ReadFile(path)
+
+	if string(b) != expected {
+		t.Errorf("Unexpected file contents:\n%q\nexpected:\n%q", string(b), expected)
+	}
+
+	if fi, _ := os.Stat(path); fi.Mode().Perm() != 0640 {
+		t.Errorf("Expected permissions to be kept, got %v", fi.Mode().Perm())
+	}
+}
+
+func TestUpdateFileDuplicatesAndEmptyValues(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "update.ini")
+
+	os.WriteFile(path, []byte("[a]\nx=1\nempty=\nx=2\n"), 0600)
+
+	changes := map[string]map[string]string{
+		"a": {"x": "3", "empty": "set", "new": "has space; semicolon"},
+	}
+
+	opts := DefaultIniOptions()
+	opts.AllowInlineComments = true
+
+	if err := UpdateFile(path, changes, opts); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	b, _ := os.ReadFile(path)
+
+	if expected := "[a]\nx=1\nempty=set\nx=3\nnew=has space\\; semicolon\n"; string(b) != expected {
+		t.Errorf("Unexpected file contents %q", string(b))
+	}
+
+	ic, err := NewIniConfigFromPathWithOptions(path, opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, "a", "x", "3")
+	checkValue(t, ic, "a", "new", "has space; semicolon")
+}
+
+func TestUpdateFileErrors(t *testing.T) {
+
+	if err := UpdateFile(filepath.Join(t.TempDir(), "missing.ini"), nil, DefaultIniOptions()); err == nil {
+		t.Errorf("Expected error for missing file")
+	}
+
+	path := filepath.Join(t.TempDir(), "update.ini")
+	os.WriteFile(path, []byte("[a\n"), 0600)
+
+	if err := UpdateFile(path, nil, DefaultIniOptions()); err == nil {
+		t.Errorf("Expected error for unparseable file")
+	}
+
+	if err := UpdateFile(path, nil, nil); err == nil {
+		t.Errorf("Expected error for nil options")
+	}
+}
+
+func TestRemoveFromFile(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "remove.ini")
+
+	os.WriteFile(path, []byte("name=a\r\n[server]\r\n; The host\r\nhost=one\r\nmotd=first\r\n  second\r\nhost=two ;Again\r\nport=80\r\n"), 0600)
+
+	opts := DefaultIniOptions()
+	opts.AllowInlineComments = true
+	opts.AllowContinuationLines = true
+
+	removals := map[string][]string{
+		"server":       {"host", "motd", "missing"},
+		GLOBAL_SECTION: {"name"},
+	}
+
+	if err := RemoveFromFile(path, removals, opts); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	expected := "[server]\r\n; The host\r\nport=80\r\n"
+
+	if b, _ := os.ReadFile(path); string(b) != expected {
+		t.Errorf("Unexpected file contents:\n%q\nexpected:\n%q", string(b), expected)
+	}
+}
@@ -0,0 +1,106 @@
+package inifile
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrInvalidOptions is wrapped by errors returned when an IniOptions is not internally consistent.
+var ErrInvalidOptions = fmt.Errorf("invalid IniOptions")
+
+// ValidateOptions checks that the supplied IniOptions are internally consistent, so that misconfiguration can be caught
+// when an application starts rather than producing unexpected results when a file is parsed or a value accessed. The
+// returned error wraps ErrInvalidOptions and describes every problem found.
+func ValidateOptions(options *IniOptions) error {
+
+	if options == nil {
+		return wrapf(ErrInvalidOptions, nil, "Nil IniOptions provided")
+	}
+
+	var problems []string
+
+	problem := func(template string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(template, args...))
+	}
+
+	if strings.TrimSpace(options.CommentStart) == "" {
+		problem("CommentStart cannot be empty")
+	}
+
+	if options.AllowInlineComments && options.CommentEscapePrefix != "" && options.CommentEscapePrefix == options.CommentStart {
+		problem("CommentEscapePrefix cannot be the same as CommentStart (%s)", options.CommentStart)
+	}
+
+	if options.StripEnclosingQuotes && len(options.EnclosingQuoteSymbols) == 0 {
+		problem("EnclosingQuoteSymbols cannot be empty when StripEnclosingQuotes is true")
+	}
+
+	if !options.UseGoBoolRules {
+
+		trueValues, falseValues := options.strictBoolValues()
+
+		if len(trueValues) == 0 || len(falseValues) == 0 {
+			problem("StrictBoolTrue and StrictBoolFalse must be set when UseGoBoolRules is false")
+		}
+
+		for _, v := range trueValues {
+			if options.matchesStrictBool(v, falseValues) {
+				problem("%s cannot represent both true and false", v)
+			}
+		}
+	}
+
+	if options.TypeAnnotations && options.UseColonAssignment {
+		problem("TypeAnnotations cannot be used with UseColonAssignment")
+	}
+
+	if options.EmptySections == EmptySectionRename && options.EmptySectionName == "" {
+		problem("EmptySectionName must be set when EmptySections is EmptySectionRename")
+	}
+
+	if options.ValueDecryptor != nil && (options.EncryptedValuePrefix == "" || options.EncryptedValueSuffix == "") {
+		problem("EncryptedValuePrefix and EncryptedValueSuffix must be set when ValueDecryptor is set")
+	}
+
+	if options.SignatureKey != nil && len(options.SignatureKey) != ed25519.PublicKeySize {
+		problem("SignatureKey is not a valid Ed25519 public key (%d bytes)", len(options.SignatureKey))
+	}
+
+	if options.DetachedSignature != nil && options.SignatureKey == nil {
+		problem("DetachedSignature cannot be checked unless SignatureKey is set")
+	}
+
+	if f := options.SectionFamilyFormat; f != "" && (strings.Count(f, "%s") != 1 || strings.Count(f, "%d") != 1) {
+		problem("SectionFamilyFormat (%s) must contain %%s and %%d exactly once", f)
+	}
+
+	if options.ForbiddenPermissions&^os.ModePerm != 0 {
+		problem("ForbiddenPermissions (%s) can only contain permission bits", options.ForbiddenPermissions)
+	}
+
+	if options.ReadRetries < 0 || options.ReadRetryDelay < 0 {
+		problem("ReadRetries and ReadRetryDelay cannot be negative")
+	}
+
+	for _, prefix := range options.NegationPrefixes {
+		if prefix == "" {
+			problem("NegationPrefixes cannot contain an empty prefix")
+		}
+	}
+
+	for _, builtin := range options.InterpolationBuiltins {
+		switch builtin {
+		case BuiltinSectionName, BuiltinHere, BuiltinHostname, BuiltinPID:
+		default:
+			problem("Unknown built-in variable %s in InterpolationBuiltins", builtin)
+		}
+	}
+
+	if len(problems) > 0 {
+		return wrapf(ErrInvalidOptions, nil, "Invalid IniOptions: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package inifile
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateOptionsPresets(t *testing.T) {
+
+	if err := ValidateOptions(DefaultIniOptions()); err != nil {
+		t.Errorf("Unexpected error for default options %s", err.Error())
+	}
+
+	for _, d := range Dialects() {
+
+		opts, _ := DialectOptions(d)
+
+		if err := ValidateOptions(opts); err != nil {
+			t.Errorf("Unexpected error for dialect %s: %s", d, err.Error())
+		}
+	}
+}
+
+func TestValidateOptionsProblems(t *testing.T) {
+
+	if err := ValidateOptions(nil); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected error for nil options")
+	}
+
+	opts := DefaultIniOptions()
+	opts.CommentStart = " "
+	opts.UseGoBoolRules = false
+	opts.StrictBoolTrue = ""
+	opts.StripEnclosingQuotes = true
+	opts.EnclosingQuoteSymbols = nil
+	opts.TypeAnnotations = true
+	opts.UseColonAssignment = true
+
+	err := ValidateOptions(opts)
+
+	if !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("Expected invalid options error, got %v", err)
+	}
+
+	for _, expected := range []string{"CommentStart", "StrictBoolTrue", "EnclosingQuoteSymbols", "TypeAnnotations"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to mention %s: %s", expected, err.Error())
+		}
+	}
+
+	opts = DefaultIniOptions()
+	opts.AllowInlineComments = true
+	opts.CommentEscapePrefix = opts.CommentStart
+
+	if err := ValidateOptions(opts); err == nil || !strings.Contains(err.Error(), "CommentEscapePrefix") {
+		t.Errorf("Expected error for escape prefix matching comment start, got %v", err)
+	}
+
+	opts = DefaultIniOptions()
+	opts.UseGoBoolRules = false
+	opts.StrictBoolTrue = "Yes"
+	opts.StrictBoolFalse = "yes"
+	opts.StrictBoolCaseSensitive = false
+
+	if err := ValidateOptions(opts); err == nil {
+		t.Errorf("Expected error for strict bool values that only differ by case")
+	}
+}
@@ -0,0 +1,211 @@
+package inifile
+
+import (
+	"encoding"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ValueInto converts the specified property into the value pointed to by target, which must be a pointer to a string,
+// bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64 or time.Duration, or
+// implement encoding.TextUnmarshaler (which is checked first, so types like net.IP use their own conversion). Numbers
+// and bools are converted with the same rules as ValueAsInt64, ValueAsBool etc. and durations with time.ParseDuration.
+//
+// This is a lightweight alternative to decoding a whole section into a struct when only one property has a custom type.
+//
+// Returns an error if the section or property does not exist, if the target is nil (including a nil pointer) or not a supported type, or if the
+// value could not be converted (wrapping ErrConversion). The target is unchanged if an error is returned.
+func (ic *IniConfig) ValueInto(sectionName, propertyName string, target interface{}) error {
+
+	if target == nil || reflect.ValueOf(target).Kind() == reflect.Ptr && reflect.ValueOf(target).IsNil() {
+		return errorf("Nil target provided for [%s].%s", sectionName, propertyName)
+	}
+
+	if tu, ok := target.(encoding.TextUnmarshaler); ok {
+
+		sv, err := ic.Value(sectionName, propertyName)
+
+		if err != nil {
+			return err
+		}
+
+		if err := tu.UnmarshalText([]byte(sv)); err != nil {
+			return ic.conversionFailed(sectionName, propertyName, wrapf(ErrConversion, ic.conversionCause(sectionName, propertyName, err), "Unable to interpret [%s].%s (%s) as a %T.", sectionName, propertyName, ic.redact(sectionName, propertyName, sv), target))
+		}
+
+		return nil
+	}
+
+	switch t := target.(type) {
+	case *string:
+
+		v, err := ic.Value(sectionName, propertyName)
+
+		if err == nil {
+			*t = v
+		}
+
+		return err
+
+	case *bool:
+
+		v, err := ic.ValueAsBool(sectionName, propertyName)
+
+		if err == nil {
+			*t = v
+		}
+
+		return err
+
+	case *int:
+
+		v, err := ic.ValueAsInt(sectionName, propertyName)
+
+		if err == nil {
+			*t = v
+		}
+
+		return err
+
+	case *int8:
+
+		v, err := ic.valueAsSizedInt(sectionName, propertyName, 8, "an int8")
+
+		if err == nil {
+			*t = int8(v)
+		}
+
+		return err
+
+	case *int16:
+
+		v, err := ic.valueAsSizedInt(sectionName, propertyName, 16, "an int16")
+
+		if err == nil {
+			*t = int16(v)
+		}
+
+		return err
+
+	case *int32:
+
+		v, err := ic.ValueAsInt32(sectionName, propertyName)
+
+		if err == nil {
+			*t = v
+		}
+
+		return err
+
+	case *int64:
+
+		v, err := ic.ValueAsInt64(sectionName, propertyName)
+
+		if err == nil {
+			*t = v
+		}
+
+		return err
+
+	case *uint:
+
+		v, err := ic.valueAsSizedUint(sectionName, propertyName, strconv.IntSize, "a uint")
+
+		if err == nil {
+			*t = uint(v)
+		}
+
+		return err
+
+	case *uint8:
+
+		v, err := ic.valueAsSizedUint(sectionName, propertyName, 8, "a uint8")
+
+		if err == nil {
+			*t = uint8(v)
+		}
+
+		return err
+
+	case *uint16:
+
+		v, err := ic.ValueAsUint16(sectionName, propertyName)
+
+		if err == nil {
+			*t = v
+		}
+
+		return err
+
+	case *uint32:
+
+		v, err := ic.ValueAsUint32(sectionName, propertyName)
+
+		if err == nil {
+			*t = v
+		}
+
+		return err
+
+	case *uint64:
+
+		v, err := ic.ValueAsUint64(sectionName, propertyName)
+
+		if err == nil {
+			*t = v
+		}
+
+		return err
+
+	case *float32:
+
+		v, err := ic.ValueAsFloat32(sectionName, propertyName)
+
+		if err == nil {
+			*t = v
+		}
+
+		return err
+
+	case *float64:
+
+		v, err := ic.ValueAsFloat64(sectionName, propertyName)
+
+		if err == nil {
+			*t = v
+		}
+
+		return err
+
+	case *time.Duration:
+
+		v, err := ic.valueAsDuration(sectionName, propertyName)
+
+		if err == nil {
+			*t = v
+		}
+
+		return err
+	}
+
+	return errorf("Unable to convert [%s].%s into unsupported type %T", sectionName, propertyName, target)
+}
+
+// valueAsDuration converts the specified property to a time.Duration with time.ParseDuration.
+func (ic *IniConfig) valueAsDuration(sectionName, propertyName string) (time.Duration, error) {
+
+	sv, err := ic.Value(sectionName, propertyName)
+
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := time.ParseDuration(sv)
+
+	if err != nil {
+		return 0, ic.conversionFailed(sectionName, propertyName, wrapf(ErrConversion, ic.conversionCause(sectionName, propertyName, err), "Unable to interpret [%s].%s (%s) as a duration.", sectionName, propertyName, ic.redact(sectionName, propertyName, sv)))
+	}
+
+	return d, nil
+}
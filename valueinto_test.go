@@ -0,0 +1,79 @@
+package inifile
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestValueInto(t *testing.T) {
+
+	ic := NewIniConfigFromMap(map[string]map[string]string{"server": {
+		"host":    "localhost",
+		"port":    "8080",
+		"small":   "-12",
+		"enabled": "true",
+		"ratio":   "0.5",
+		"timeout": "1m30s",
+		"ip":      "10.0.0.1",
+		"big":     "300",
+	}})
+
+	var host string
+	var port uint16
+	var small int8
+	var enabled bool
+	var ratio float64
+	var timeout time.Duration
+	var ip net.IP
+
+	targets := map[string]interface{}{"host": &host, "port": &port, "small": &small, "enabled": &enabled, "ratio": &ratio, "timeout": &timeout, "ip": &ip}
+
+	for property, target := range targets {
+		if err := ic.ValueInto("server", property, target); err != nil {
+			t.Errorf("Unexpected error for %s: %s", property, err.Error())
+		}
+	}
+
+	if host != "localhost" || port != 8080 || small != -12 || !enabled || ratio != 0.5 || timeout != 90*time.Second || !ip.Equal(net.IPv4(10, 0, 0, 1)) {
+		t.Errorf("Unexpected values %s %d %d %v %f %s %s", host, port, small, enabled, ratio, timeout, ip)
+	}
+
+	var tiny uint8 = 7
+
+	if err := ic.ValueInto("server", "big", &tiny); !errors.Is(err, ErrConversion) || tiny != 7 {
+		t.Errorf("Expected out of range value to fail without changing the target")
+	}
+
+	if err := ic.ValueInto("server", "host", &timeout); !errors.Is(err, ErrConversion) {
+		t.Errorf("Expected invalid duration to fail")
+	}
+
+	if err := ic.ValueInto("server", "host", &ip); !errors.Is(err, ErrConversion) {
+		t.Errorf("Expected invalid IP to fail")
+	}
+
+	if err := ic.ValueInto("server", "missing", &host); !errors.Is(err, ErrPropertyNotFound) {
+		t.Errorf("Expected missing property to fail")
+	}
+
+	var c complex64
+
+	if err := ic.ValueInto("server", "port", &c); err == nil {
+		t.Errorf("Expected unsupported type to fail")
+	}
+
+	if err := ic.ValueInto("server", "port", nil); err == nil {
+		t.Errorf("Expected nil target to fail")
+	}
+
+	var nilInt *int
+	var nilIP *net.IP
+
+	for _, target := range []interface{}{nilInt, nilIP} {
+		if err := ic.ValueInto("server", "port", target); err == nil {
+			t.Errorf("Expected nil %T target to fail", target)
+		}
+	}
+}
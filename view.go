@@ -0,0 +1,116 @@
+package inifile
+
+// WithOptions returns a view of this IniConfig whose accessors use the AccessOptions of the supplied options instead of
+// the AccessOptions the IniConfig was created with. This allows different parts of an application to interpret the same
+// values differently (for example with StrictBoolTrue and StrictBoolFalse for one subsystem and Go's rules for another)
+// without parsing the file again. The ParseOptions of this IniConfig (including CaseSensitive) are always kept, so the
+// ParseOptions of temporary have no effect.
+//
+// The view is a snapshot: it holds a copy of the sections, properties, defaults and other state of this IniConfig at
+// the time WithOptions was called, so later changes to this IniConfig (e.g. with Add, Delete or PlaceSection) are not
+// visible through the view, and changes made through the view do not affect this IniConfig. Call WithOptions again to
+// see later changes. Returns nil if temporary is nil.
+func (ic *IniConfig) WithOptions(temporary *IniOptions) *IniConfig {
+
+	if temporary == nil {
+		return nil
+	}
+
+	//Lazily parsed sections must be loaded before they can be copied
+	if err := ic.loadAllSections(); err != nil {
+		ic.debugf("Unable to load all sections: %s", err.Error())
+	}
+
+	combined := new(IniOptions)
+	combined.ParseOptions = ic.options.ParseOptions
+	combined.AccessOptions = temporary.AccessOptions
+
+	view := new(IniConfig)
+	view.options = combined
+
+	view.sections = copySections(ic.sections)
+	view.defaults = copySections(ic.defaults)
+	view.sensitive = copyNestedFlags(ic.sensitive)
+	view.types = copyNestedStrings(ic.types)
+	view.inlineComments = copyNestedStrings(ic.inlineComments)
+	view.elements = append([]Element(nil), ic.elements...)
+	view.warnings = append([]Warning(nil), ic.warnings...)
+	view.shadowed = append([]Shadowing(nil), ic.shadowed...)
+	view.tombstones = append([]tombstone(nil), ic.tombstones...)
+	view.parseStats = ic.parseStats
+	view.source = ic.source
+	view.sources = append([]string(nil), ic.sources...)
+	view.sectionOrder = append([]string(nil), ic.sectionOrder...)
+
+	if ic.layers != nil {
+
+		view.layers = make(map[string]*IniConfig, len(ic.layers))
+
+		for name, layer := range ic.layers {
+			view.layers[name] = layer
+		}
+	}
+
+	view.propertyOrder = make(map[string][]string, len(ic.propertyOrder))
+
+	for section, order := range ic.propertyOrder {
+		view.propertyOrder[section] = append([]string(nil), order...)
+	}
+
+	return view
+}
+
+// WithAccessOptions returns a view of this IniConfig (see WithOptions) that uses the supplied AccessOptions and the
+// ParseOptions this IniConfig was created with.
+func (ic *IniConfig) WithAccessOptions(ao AccessOptions) *IniConfig {
+
+	combined := new(IniOptions)
+	combined.AccessOptions = ao
+
+	return ic.WithOptions(combined)
+}
+
+func copySections(m sectionPropertyMap) sectionPropertyMap {
+
+	if m == nil {
+		return nil
+	}
+
+	c := make(sectionPropertyMap, len(m))
+
+	for section, properties := range m {
+		c[section] = copyProperties(properties)
+	}
+
+	return c
+}
+
+func copyNestedFlags(m map[string]map[string]bool) map[string]map[string]bool {
+
+	if m == nil {
+		return nil
+	}
+
+	c := make(map[string]map[string]bool, len(m))
+
+	for section, flags := range m {
+		c[section] = copyFlags(flags)
+	}
+
+	return c
+}
+
+func copyNestedStrings(m map[string]map[string]string) map[string]map[string]string {
+
+	if m == nil {
+		return nil
+	}
+
+	c := make(map[string]map[string]string, len(m))
+
+	for section, values := range m {
+		c[section] = copyStrings(values)
+	}
+
+	return c
+}
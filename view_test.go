@@ -0,0 +1,78 @@
+package inifile
+
+import (
+	"testing"
+)
+
+func TestWithOptions(t *testing.T) {
+
+	ic, err := NewIniConfigFromPath(typesPath())
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	ic.Add("Boolean", "answer", "yes")
+
+	if _, err := ic.ValueAsBool("Boolean", "answer"); err == nil {
+		t.Errorf("Expected yes not to be a valid bool with Go's rules")
+	}
+
+	o := DefaultIniOptions()
+	o.UseGoBoolRules = false
+	o.StrictBoolTrue = "yes"
+	o.StrictBoolFalse = "no"
+
+	strict := ic.WithOptions(o)
+
+	if v, err := strict.ValueAsBool("Boolean", "answer"); err != nil || !v {
+		t.Errorf("Expected yes to be true through the view (%v)", err)
+	}
+
+	if _, err := ic.ValueAsBool("Boolean", "answer"); err == nil {
+		t.Errorf("Expected the original options to be unchanged")
+	}
+
+	ic.Add("Boolean", "added", "no")
+	ic.Add("New", "added", "no")
+
+	if strict.PropertyExists("Boolean", "added") || strict.SectionExists("New") {
+		t.Errorf("Expected the view to be a snapshot unaffected by later changes to the IniConfig")
+	}
+
+	if v, err := ic.WithOptions(o).ValueAsBool("New", "added"); err != nil || v {
+		t.Errorf("Expected a new view to include later changes (%v)", err)
+	}
+
+	o.CaseSensitive = !ic.options.CaseSensitive
+
+	if !ic.WithOptions(o).PropertyExists("Boolean", "answer") {
+		t.Errorf("Expected the view to keep the ParseOptions of the IniConfig")
+	}
+
+	if ic.WithOptions(nil) != nil {
+		t.Errorf("Expected nil view for nil options")
+	}
+}
+
+func TestWithAccessOptions(t *testing.T) {
+
+	ic, err := NewIniConfigFromPath(typesPath())
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	ao := DefaultIniOptions().AccessOptions
+	ao.PercentWithoutSymbolIsFraction = false
+
+	view := ic.WithAccessOptions(ao)
+
+	if v, err := view.ValueAsPercent("percent", "whole"); err != nil || v != 0.75 {
+		t.Errorf("Expected 75 to be a percentage through the view, got %v (%v)", v, err)
+	}
+
+	if v, err := ic.ValueAsPercent("percent", "fraction"); err != nil || v != 0.75 {
+		t.Errorf("Expected 0.75 to be a fraction with the original options, got %v (%v)", v, err)
+	}
+}
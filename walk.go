@@ -0,0 +1,60 @@
+package inifile
+
+// Walk calls the supplied function for every property in this IniConfig, visiting sections in the order of
+// SectionNames and properties in the order they were first parsed or added. Values are retrieved with Value, so
+// interpolation and decryption are applied.
+//
+// Walk stops and returns the error if the function returns an error or a value cannot be retrieved.
+func (ic *IniConfig) Walk(visit func(section, property, value string) error) error {
+
+	for _, section := range ic.SectionNames() {
+
+		for _, property := range append([]string(nil), ic.propertyOrder[section]...) {
+
+			value, err := ic.Value(section, property)
+
+			if err != nil {
+				return err
+			}
+
+			if err := visit(section, property, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// MapValues replaces the value of every property in this IniConfig with the result of calling the supplied function with
+// the property's raw value (before interpolation or decryption), returning the number of properties whose value
+// changed. Properties are visited in the same order as Walk and keep the line and source they were parsed from, so the
+// result can be written back with WriteTo.
+//
+// Sections of a lazily parsed IniConfig that cannot be parsed are skipped.
+func (ic *IniConfig) MapValues(mapper func(section, property, value string) string) int {
+
+	changed := 0
+
+	for _, section := range ic.SectionNames() {
+
+		stored := ic.sections[section]
+
+		for _, property := range ic.propertyOrder[section] {
+
+			pv, found := stored[property]
+
+			if !found {
+				continue
+			}
+
+			if mapped := mapper(section, property, pv.String()); mapped != pv.String() {
+				pv.nilableString = newNilableString(mapped)
+				stored[property] = pv
+				changed++
+			}
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,76 @@
+package inifile
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+
+	ic, err := NewIniConfigFromPath(filepath.Join(testfiles_base, "global-section.ini"))
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	ic.Add("section", "added", "C")
+
+	var visited []string
+
+	err = ic.Walk(func(section, property, value string) error {
+		visited = append(visited, section+"."+property+"="+value)
+		return nil
+	})
+
+	if err != nil || strings.Join(visited, " ") != ".globalProp=A section.sectionProp=B section.added=C" {
+		t.Errorf("Unexpected visits %v (%v)", visited, err)
+	}
+
+	stop := errors.New("stop")
+	count := 0
+
+	err = ic.Walk(func(section, property, value string) error {
+		count++
+		return stop
+	})
+
+	if err != stop || count != 1 {
+		t.Errorf("Expected walk to stop after first error, visited %d (%v)", count, err)
+	}
+}
+
+func TestMapValues(t *testing.T) {
+
+	ic, err := NewIniConfigFromPath(filepath.Join(testfiles_base, "global-section.ini"))
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	changed := ic.MapValues(func(section, property, value string) string {
+
+		if section == "section" {
+			return strings.ToLower(value)
+		}
+
+		return value
+	})
+
+	if changed != 1 {
+		t.Errorf("Expected one changed property, got %d", changed)
+	}
+
+	if v, _ := ic.Value("section", "sectionProp"); v != "b" {
+		t.Errorf("Expected mapped value b, got %s", v)
+	}
+
+	if v, _ := ic.Value(GLOBAL_SECTION, "globalProp"); v != "A" {
+		t.Errorf("Expected unchanged value A, got %s", v)
+	}
+
+	if origin, _ := ic.Origin("section", "sectionProp"); origin != OriginFile {
+		t.Errorf("Expected mapped value to keep its origin")
+	}
+}
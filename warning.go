@@ -0,0 +1,143 @@
+package inifile
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// WarningKind identifies the type of suspicious input that caused a Warning
+type WarningKind int
+
+const (
+	// A property was defined more than once in the same section and the earlier value was discarded
+	ShadowedProperty WarningKind = iota
+	// A value contains control characters (other than tab)
+	ControlCharacter
+	// A value starts with a quote symbol that is never closed, suggesting it has been truncated
+	UnterminatedQuote
+	// A value contains the comment symbol after whitespace but AllowInlineComments is false, suggesting an inline
+	// comment has been included in the value
+	CommentInValue
+)
+
+// CommentInValueMode controls what happens when a value appears to contain an inline comment while
+// AllowInlineComments is false in the IniOptions.
+type CommentInValueMode int
+
+const (
+	// The comment is silently kept as part of the value
+	CommentInValueAllow CommentInValueMode = iota
+	// The comment is kept as part of the value and a CommentInValue warning is recorded
+	CommentInValueWarn
+	// Parsing fails
+	CommentInValueError
+)
+
+// Warning describes input that was tolerated by the parser but is likely to be a mistake.
+type Warning struct {
+	// The type of problem found
+	Kind WarningKind
+
+	// The line of the file on which the problem was found
+	Line int
+
+	// The section containing the property with the problem
+	Section string
+
+	// The property with the problem
+	Property string
+
+	// A human-readable description of the problem
+	Message string
+}
+
+// String returns the message and line number of the warning.
+func (w Warning) String() string {
+	return fmt.Sprintf("%s (line %d)", w.Message, w.Line)
+}
+
+// Warnings returns any problems found while parsing that did not prevent the file from being parsed. Deployments that
+// need to be strict about their configuration can check this after loading or set FailOnWarnings in IniOptions.
+func (ic *IniConfig) Warnings() []Warning {
+	return ic.warnings
+}
+
+// warn records a warning found at the specified line.
+func (ic *IniConfig) warn(kind WarningKind, line int, section, property, template string, args ...interface{}) {
+
+	w := Warning{
+		Kind:     kind,
+		Line:     line,
+		Section:  section,
+		Property: property,
+		Message:  fmt.Sprintf(template, args...),
+	}
+
+	ic.warnings = append(ic.warnings, w)
+}
+
+// checkCommentInValue applies the CommentInValue mode in the IniOptions to a raw (unquoted) value, returning an error if
+// the mode is CommentInValueError and the value contains the comment symbol preceded by whitespace.
+func (ic *IniConfig) checkCommentInValue(line int, section, property, value string) error {
+
+	options := ic.options
+
+	if options.AllowInlineComments || options.CommentInValue == CommentInValueAllow || !containsInlineComment(value, options.CommentStart) {
+		return nil
+	}
+
+	if options.CommentInValue == CommentInValueError {
+		return parseErrorf(line, "Value of [%s].%s appears to contain an inline comment (forbidden in IniOptions)", section, property)
+	}
+
+	ic.warn(CommentInValue, line, section, property, "Value of [%s].%s appears to contain an inline comment but AllowInlineComments is false", section, property)
+
+	return nil
+}
+
+// containsInlineComment returns true if commentStart appears in the value after whitespace (e.g. host=localhost ;comment).
+func containsInlineComment(value, commentStart string) bool {
+
+	for i := strings.Index(value, commentStart); i >= 0; {
+
+		if i > 0 && unicode.IsSpace(rune(value[i-1])) {
+			return true
+		}
+
+		next := strings.Index(value[i+1:], commentStart)
+
+		if next < 0 {
+			break
+		}
+
+		i += next + 1
+	}
+
+	return false
+}
+
+// checkSuspiciousValue records warnings for values that contain control characters or look truncated.
+func (ic *IniConfig) checkSuspiciousValue(line int, section, property, value string) {
+
+	for _, r := range value {
+		if unicode.IsControl(r) && r != '\t' {
+			ic.warn(ControlCharacter, line, section, property, "Value of [%s].%s contains control character %U", section, property, r)
+			break
+		}
+	}
+
+	if len(value) == 0 {
+		return
+	}
+
+	for _, q := range ic.options.EnclosingQuoteSymbols {
+
+		first := rune(value[0])
+
+		if first == q && (len(value) == 1 || rune(value[len(value)-1]) != q) {
+			ic.warn(UnterminatedQuote, line, section, property, "Value of [%s].%s starts with %c but does not end with it", section, property, q)
+			break
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package inifile
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestWarnings(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "suspicious.ini")
+
+	options := DefaultIniOptions()
+
+	ic, err := NewIniConfigFromPathWithOptions(path, options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	w := ic.Warnings()
+
+	if len(w) != 2 {
+		t.Fatalf("Expected two warnings, found %v", w)
+	}
+
+	if w[0].Kind != ShadowedProperty || w[0].Line != 3 || w[0].Property != "name" {
+		t.Errorf("Unexpected warning %v", w[0])
+	}
+
+	if w[1].Kind != UnterminatedQuote || w[1].Line != 4 {
+		t.Errorf("Unexpected warning %v", w[1])
+	}
+
+	options.FailOnWarnings = true
+
+	if _, err := NewIniConfigFromPathWithOptions(path, options); err == nil {
+		t.Errorf("Expected parse to fail")
+	}
+
+	if ic, _ := NewIniConfigFromPath(simplePath()); len(ic.Warnings()) != 0 {
+		t.Errorf("Did not expect warnings")
+	}
+}
+
+func TestCommentInValue(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "comment-in-value.ini")
+
+	options := DefaultIniOptions()
+	options.StripEnclosingQuotes = true
+
+	if ic, _ := NewIniConfigFromPathWithOptions(path, options); len(ic.Warnings()) != 0 {
+		t.Errorf("Did not expect warnings by default %v", ic.Warnings())
+	}
+
+	options.CommentInValue = CommentInValueWarn
+
+	ic, err := NewIniConfigFromPathWithOptions(path, options)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	if w := ic.Warnings(); len(w) != 1 || w[0].Kind != CommentInValue || w[0].Property != "host" {
+		t.Errorf("Expected a single warning for host, found %v", w)
+	}
+
+	if v, _ := ic.Value("database", "host"); v != "localhost ;Default to localhost" {
+		t.Errorf("Expected comment to be kept in value, was %s", v)
+	}
+
+	options.CommentInValue = CommentInValueError
+
+	if _, err := NewIniConfigFromPathWithOptions(path, options); err == nil {
+		t.Errorf("Expected parse to fail")
+	}
+
+	options.AllowInlineComments = true
+
+	if _, err := NewIniConfigFromPathWithOptions(path, options); err != nil {
+		t.Errorf("Did not expect check when inline comments are allowed %s", err.Error())
+	}
+}
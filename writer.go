@@ -0,0 +1,366 @@
+package inifile
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultIniWriteOptions returns an IniWriteOptions object populated with default values.
+//
+// Default values are:
+//		Sorted						false
+//		AlignAssignments			false
+//		SpaceAroundAssignment		false
+//		BlankLinesBetweenSections	1
+//		QuoteValuesWithSpaces		false
+//		QuoteSymbol					0 (first of IniOptions.EnclosingQuoteSymbols)
+//		LineEnding					LF
+//		BackupVersions				0
+//		BackupDirectory				""
+//		ChecksumFooter				false
+//		GeneratedBy					""
+//		GeneratedFrom				""
+//		DoNotEditWarning			false
+//		OmitTimestamp				false
+//
+func DefaultIniWriteOptions() *IniWriteOptions {
+	wo := new(IniWriteOptions)
+
+	wo.Sorted = false
+	wo.AlignAssignments = false
+	wo.SpaceAroundAssignment = false
+	wo.BlankLinesBetweenSections = 1
+	wo.QuoteValuesWithSpaces = false
+	wo.QuoteSymbol = 0
+	wo.LineEnding = LF
+	wo.BackupVersions = 0
+	wo.BackupDirectory = ""
+	wo.ChecksumFooter = false
+	wo.GeneratedBy = ""
+	wo.GeneratedFrom = ""
+	wo.DoNotEditWarning = false
+	wo.OmitTimestamp = false
+
+	return wo
+}
+
+// LF is the Unix line ending
+const LF = "\n"
+
+// CRLF is the Windows line ending
+const CRLF = "\r\n"
+
+//IniWriteOptions controls the layout of INI files written by an IniConfig.
+type IniWriteOptions struct {
+	//Write sections and properties in alphabetical order (with the global section first) rather than the order they
+	//were parsed or added, so that generated files produce clean diffs.
+	Sorted bool
+
+	//Pad property names so the assignment symbols in each section line up
+	AlignAssignments bool
+
+	//Write a space either side of the assignment symbol
+	SpaceAroundAssignment bool
+
+	//The number of blank lines written before each section header
+	BlankLinesBetweenSections int
+
+	//Enclose values containing whitespace in quotes. Values whose leading or trailing whitespace or enclosing quotes would
+	//be lost when parsed are always quoted if IniOptions.StripEnclosingQuotes is true
+	QuoteValuesWithSpaces bool
+
+	//The quote symbol used when a value is quoted. If zero, the first of IniOptions.EnclosingQuoteSymbols is used
+	QuoteSymbol rune
+
+	//The line ending written after each line (LF or CRLF)
+	LineEnding string
+
+	//When saving over an existing file, keep this many previous versions of it (path.1 being the most recent, up to
+	//path.N). Zero keeps no versions
+	BackupVersions int
+
+	//When saving over an existing file, copy it to this directory first, with the time of the save (see
+	//BackupTimeFormat) appended to its name. Empty writes no copy
+	BackupDirectory string
+
+	//End the output with a comment holding its SHA-256 checksum, which can be checked when the file is parsed (see
+	//IniOptions.VerifyChecksum)
+	ChecksumFooter bool
+
+	//The name of the tool generating the file. If set, the output starts with a comment saying the file was generated
+	//by this tool and when
+	GeneratedBy string
+
+	//The template or other source the file was generated from. If set, the output starts with a comment naming it
+	GeneratedFrom string
+
+	//Start the output with a comment warning that the file is generated and should not be edited by hand
+	DoNotEditWarning bool
+
+	//Leave the time of generation out of the GeneratedBy comment, so that generating the same configuration twice gives
+	//identical files (e.g. for reproducible builds)
+	OmitTimestamp bool
+}
+
+// WriteTo writes the sections and properties of this IniConfig to the supplied writer in INI format, using the comment,
+// assignment, escaping and quoting conventions in the IniOptions so that the output can be parsed with the same options.
+// Properties in the global section are written first, followed by each named section in the order it was first parsed
+// or added.
+//
+// Comments and blank lines from the original file are not preserved, but comments attached to properties with
+// SetInlineComment are written.
+func (ic *IniConfig) WriteTo(w io.Writer) (int64, error) {
+	return ic.WriteToWithOptions(w, DefaultIniWriteOptions())
+}
+
+// WriteToWithOptions writes the sections and properties of this IniConfig to the supplied writer (see WriteTo) with
+// the layout controlled by the supplied IniWriteOptions.
+func (ic *IniConfig) WriteToWithOptions(w io.Writer, wo *IniWriteOptions) (int64, error) {
+
+	if wo == nil {
+		return 0, errorf("Nil IniWriteOptions provided")
+	}
+
+	if wo.BlankLinesBetweenSections < 0 {
+		return 0, errorf("BlankLinesBetweenSections in IniWriteOptions cannot be negative")
+	}
+
+	if err := ic.loadAllSections(); err != nil {
+		return 0, err
+	}
+
+	bw := bufio.NewWriter(w)
+	cw := &countingWriter{w: bw}
+
+	sum := sha256.New()
+
+	if wo.ChecksumFooter {
+		cw.w = io.MultiWriter(bw, sum)
+	}
+
+	assign := "="
+
+	if ic.options.UseColonAssignment {
+		assign = ":"
+	}
+
+	if wo.SpaceAroundAssignment {
+		assign = " " + assign + " "
+	}
+
+	eol := wo.LineEnding
+
+	if eol == "" {
+		eol = LF
+	}
+
+	first := true
+
+	if header := wo.generatedHeader(ic.options.CommentStart, eol); header != "" {
+		cw.writeString(header)
+		first = false
+	}
+
+	sections := ic.writeOrder()
+
+	if wo.Sorted {
+		//The global section, if present, is always first
+		named := sections
+
+		if len(named) > 0 && named[0] == GLOBAL_SECTION {
+			named = named[1:]
+		}
+
+		sort.Strings(named)
+	}
+
+	for _, section := range sections {
+
+		if !first {
+			cw.writeString(strings.Repeat(eol, wo.BlankLinesBetweenSections))
+		}
+
+		if section != GLOBAL_SECTION {
+			cw.writeString(ic.sectionHeader(section) + eol)
+		}
+
+		properties := ic.propertyOrder[section]
+
+		if wo.Sorted {
+			properties = ic.sortedPropertyNames(section)
+		}
+
+		width := 0
+
+		if wo.AlignAssignments {
+			for _, property := range properties {
+				if l := utf8.RuneCountInString(ic.escapeComments(ic.escapePropertyName(property))); l > width {
+					width = l
+				}
+			}
+		}
+
+		for _, property := range properties {
+			name := ic.escapeComments(ic.escapePropertyName(property))
+			value := ic.writtenValue(ic.sections[section][property])
+
+			if padding := width - utf8.RuneCountInString(name); padding > 0 {
+				name += strings.Repeat(" ", padding)
+			}
+
+			line := name + assign + ic.quoteIfNeeded(ic.escapeComments(value), wo)
+
+			if comment, found := ic.inlineComments[section][property]; found {
+
+				comment = ic.options.CommentStart + " " + comment
+
+				if ic.options.AllowInlineComments {
+					line += " " + comment
+				} else {
+					cw.writeString(comment + eol)
+				}
+			}
+
+			cw.writeString(line + eol)
+		}
+
+		first = false
+	}
+
+	if wo.ChecksumFooter {
+		cw.w = bw
+		cw.writeString(checksumFooter(sum.Sum(nil), ic.options.CommentStart, eol))
+	}
+
+	if cw.err == nil {
+		cw.err = bw.Flush()
+	}
+
+	return cw.n, cw.err
+}
+
+// Save writes this IniConfig to the file at the supplied path (see WriteTo), creating the file if it doesn't exist or
+// replacing its contents if it does.
+func (ic *IniConfig) Save(path string) error {
+	return ic.SaveWithOptions(path, DefaultIniWriteOptions())
+}
+
+// SaveWithOptions writes this IniConfig to the file at the supplied path with the layout controlled by the supplied
+// IniWriteOptions (see Save).
+func (ic *IniConfig) SaveWithOptions(path string, wo *IniWriteOptions) error {
+
+	var b bytes.Buffer
+
+	if _, err := ic.WriteToWithOptions(&b, wo); err != nil {
+		return err
+	}
+
+	if err := wo.keepVersions(path); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(b.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// writeOrder returns the names of the sections to write, with the global section first.
+func (ic *IniConfig) writeOrder() []string {
+
+	order := make([]string, 0, len(ic.sectionOrder))
+
+	if _, found := ic.sections[GLOBAL_SECTION]; found {
+		order = append(order, GLOBAL_SECTION)
+	}
+
+	for _, section := range ic.sectionOrder {
+		if section != GLOBAL_SECTION {
+			order = append(order, section)
+		}
+	}
+
+	return order
+}
+
+// escapeComments escapes instances of the comment symbol if inline comments are allowed.
+func (ic *IniConfig) escapeComments(s string) string {
+
+	options := ic.options
+
+	if !options.AllowInlineComments {
+		return s
+	}
+
+	return strings.Replace(s, options.CommentStart, options.CommentEscapePrefix+options.CommentStart, -1)
+}
+
+// quoteIfNeeded encloses values in quotes if requested by the IniWriteOptions or if their leading or trailing whitespace
+// or enclosing quotes would otherwise be lost when the value is parsed.
+func (ic *IniConfig) quoteIfNeeded(value string, wo *IniWriteOptions) string {
+
+	options := ic.options
+
+	q := string(wo.QuoteSymbol)
+
+	if wo.QuoteSymbol == 0 {
+
+		if len(options.EnclosingQuoteSymbols) == 0 {
+			return value
+		}
+
+		q = string(options.EnclosingQuoteSymbols[0])
+	}
+
+	if wo.QuoteValuesWithSpaces && strings.IndexFunc(value, unicode.IsSpace) >= 0 {
+		return q + value + q
+	}
+
+	if !options.StripEnclosingQuotes {
+		return value
+	}
+
+	if options.TrimProperties && strings.TrimSpace(value) != value {
+		return q + value + q
+	}
+
+	if ic.stripQuotes(value) != value {
+		return q + value + q
+	}
+
+	return value
+}
+
+// countingWriter records the number of bytes written and the first error encountered.
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) writeString(s string) {
+
+	if cw.err != nil {
+		return
+	}
+
+	n, err := io.WriteString(cw.w, s)
+
+	cw.n += int64(n)
+	cw.err = err
+}
@@ -0,0 +1,183 @@
+package inifile
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteTo(t *testing.T) {
+
+	path := filepath.Join(testfiles_base, "global-section.ini")
+
+	ic, err := NewIniConfigFromPath(path)
+
+	if err != nil {
+		t.Fatalf("Problem loading test file %s", err.Error())
+	}
+
+	ic.Add("section", "added", "C")
+	ic.Add("new", "a", "D")
+
+	var b bytes.Buffer
+
+	if _, err := ic.WriteTo(&b); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	expected := "globalProp=A\n\n[section]\nsectionProp=B\nadded=C\n\n[new]\na=D\n"
+
+	if b.String() != expected {
+		t.Errorf("Unexpected output:\n%s", b.String())
+	}
+}
+
+func TestWriteEscapingAndQuoting(t *testing.T) {
+
+	options := DefaultIniOptions()
+	options.AllowInlineComments = true
+	options.StripEnclosingQuotes = true
+
+	ic, _ := NewIniConfigFromJSON([]byte(`{"s;1":{"a":" padded ", "b":"x;y", "c":"'quoted'"}}`), options)
+
+	var b bytes.Buffer
+	ic.WriteTo(&b)
+
+	expected := "[s\\;1]\na=' padded '\nb=x\\;y\nc=''quoted''\n"
+
+	if b.String() != expected {
+		t.Errorf("Unexpected output:\n%s", b.String())
+	}
+
+	path := filepath.Join(t.TempDir(), "escaped.ini")
+
+	if err := ic.Save(path); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	reloaded, err := NewIniConfigFromPathWithOptions(path, options)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	for _, p := range []string{"a", "b", "c"} {
+
+		if v, _ := reloaded.Value("s;1", p); v != ic.ValueOrZero("s;1", p) {
+			t.Errorf("Value of %s not preserved, was >%s<", p, v)
+		}
+	}
+}
+
+func TestDelete(t *testing.T) {
+
+	ic, _ := NewIniConfigFromPath(simplePath())
+
+	if ic.Delete("Section1", "missing") {
+		t.Errorf("Did not expect missing property to be deleted")
+	}
+
+	if !ic.Delete("Section1", "name1") {
+		t.Errorf("Expected property to be deleted")
+	}
+
+	if ic.SectionExists("Section1") {
+		t.Errorf("Expected empty section to be removed")
+	}
+}
+
+func TestJSONConversion(t *testing.T) {
+
+	options := DefaultIniOptions()
+	options.SensitiveProperties = []string{"string"}
+
+	ic, _ := NewIniConfigFromPathWithOptions(typesPath(), options)
+
+	b, err := json.Marshal(ic)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	converted, err := NewIniConfigFromJSON(b, DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	if v, _ := converted.Value("int", "negative"); v != "-1" {
+		t.Errorf("Unexpected value %s", v)
+	}
+
+	if v, _ := converted.Value("int", "string"); v != redacted {
+		t.Errorf("Expected sensitive value to be redacted, was %s", v)
+	}
+
+	if _, err := NewIniConfigFromJSON([]byte(`{"a":1}`), DefaultIniOptions()); err == nil {
+		t.Errorf("Expected invalid JSON to fail")
+	}
+}
+
+func TestSortedWrite(t *testing.T) {
+
+	ic, _ := NewIniConfigFromPath(filepath.Join(testfiles_base, "global-section.ini"))
+
+	ic.Add("section", "added", "C")
+	ic.Add("new", "b", "D")
+	ic.Add("new", "a", "E")
+	ic.Add(GLOBAL_SECTION, "another", "F")
+
+	wo := DefaultIniWriteOptions()
+	wo.Sorted = true
+
+	var b bytes.Buffer
+
+	if _, err := ic.WriteToWithOptions(&b, wo); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	expected := "another=F\nglobalProp=A\n\n[new]\na=E\nb=D\n\n[section]\nadded=C\nsectionProp=B\n"
+
+	if b.String() != expected {
+		t.Errorf("Unexpected output:\n%s", b.String())
+	}
+
+	if names := ic.SectionNames(); names[1] != "section" {
+		t.Errorf("Did not expect sorting to alter section order %v", names)
+	}
+}
+
+func TestWriteFormatting(t *testing.T) {
+
+	ic := NewIniConfigFromMap(map[string]map[string]string{
+		"a": {"long_name": "1", "x": "has spaces"},
+		"b": {"y": "2"},
+	})
+
+	wo := DefaultIniWriteOptions()
+	wo.AlignAssignments = true
+	wo.SpaceAroundAssignment = true
+	wo.BlankLinesBetweenSections = 2
+	wo.QuoteValuesWithSpaces = true
+	wo.QuoteSymbol = '"'
+	wo.LineEnding = CRLF
+
+	var b bytes.Buffer
+
+	if _, err := ic.WriteToWithOptions(&b, wo); err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	expected := "[a]\r\nlong_name = 1\r\nx         = \"has spaces\"\r\n\r\n\r\n[b]\r\ny = 2\r\n"
+
+	if b.String() != expected {
+		t.Errorf("Unexpected output:\n%q", b.String())
+	}
+
+	wo.BlankLinesBetweenSections = -1
+
+	if _, err := ic.WriteToWithOptions(&b, wo); err == nil {
+		t.Errorf("Expected negative BlankLinesBetweenSections to be rejected")
+	}
+}
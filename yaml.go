@@ -0,0 +1,332 @@
+package inifile
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// ToYAML converts this IniConfig into a YAML document. Global properties become top-level keys with scalar values and
+// each named section becomes a top-level key whose value is a map of its properties. Sections are written in the order
+// of SectionNames and properties in the order they were first parsed or added.
+//
+// Raw values are written (before interpolation or decryption) and are always double-quoted, so the conversion is
+// lossless: NewIniConfigFromYAML recreates the same sections, properties and values. Values that are unset (see
+// NullLiterals in IniOptions) are written as null.
+//
+// An error is returned if a global property has the same name as a section, as they cannot both be represented.
+func (ic *IniConfig) ToYAML() ([]byte, error) {
+
+	var b bytes.Buffer
+
+	sections := ic.SectionNames()
+
+	for _, section := range sections {
+
+		stored := ic.sections[section]
+
+		if section != GLOBAL_SECTION {
+
+			if _, clash := ic.sections[GLOBAL_SECTION][section]; clash {
+				return nil, errorf("Unable to convert to YAML: global property %s has the same name as a section", section)
+			}
+
+			b.WriteString(yamlKey(section) + ":\n")
+		}
+
+		for _, property := range ic.propertyOrder[section] {
+
+			pv, found := stored[property]
+
+			if !found {
+				continue
+			}
+
+			if section != GLOBAL_SECTION {
+				b.WriteString("  ")
+			}
+
+			b.WriteString(yamlKey(property) + ": " + yamlValue(pv.nilableString) + "\n")
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// yamlKey returns the supplied name as a YAML key, quoted unless it can be written as a plain scalar.
+func yamlKey(name string) string {
+
+	if name == "" || yamlSpecial(name) {
+		return strconv.Quote(name)
+	}
+
+	for _, r := range name {
+
+		if !(r == '_' || r == '-' || r == '.' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return strconv.Quote(name)
+		}
+	}
+
+	return name
+}
+
+// yamlSpecial returns true if a plain scalar with the supplied text would be read as something other than a string.
+func yamlSpecial(s string) bool {
+
+	switch strings.ToLower(s) {
+	case "null", "~", "true", "false", "yes", "no", "on", "off":
+		return true
+	}
+
+	return s[0] == '-' || s[0] == '.' || s[0] >= '0' && s[0] <= '9'
+}
+
+// yamlValue returns the supplied value as a double-quoted YAML scalar, or null if it is unset.
+func yamlValue(v nilableString) string {
+
+	if !v.IsSet() {
+		return "null"
+	}
+
+	return strconv.Quote(v.String())
+}
+
+// NewIniConfigFromYAML creates an IniConfig from a YAML document in the format produced by ToYAML, using the supplied
+// options for subsequent access. Sections and properties are added in the order they appear in the document.
+//
+// Only the subset of YAML needed to represent an INI file is supported: top-level keys with scalar values (global
+// properties) or with a map of scalar values (sections). Scalars may be plain, single-quoted or double-quoted, and
+// null or ~ is converted to an unset value. Comments, blank lines and document markers are ignored. An error giving the
+// line number is returned if the document uses any other feature, such as sequences, block scalars, flow collections
+// (other than an empty map {}), anchors, aliases, tags or deeper nesting.
+func NewIniConfigFromYAML(data []byte, options *IniOptions) (*IniConfig, error) {
+
+	if options == nil {
+		return nil, errorf("Nil IniOptions provided")
+	}
+
+	ic := newIniConfigFromMap(nil, options)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	section := GLOBAL_SECTION
+	inSection := false
+	sectionIndent := ""
+	lineNumber := 0
+
+	for scanner.Scan() {
+
+		lineNumber++
+
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimLeft(line, " ")
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if line == "---" || line == "..." {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, errorf("Unable to convert YAML to an IniConfig: tab used for indentation on line %d", lineNumber)
+		}
+
+		indent := line[:len(line)-len(trimmed)]
+
+		if indent != "" && (!inSection || sectionIndent != "" && indent != sectionIndent) {
+			return nil, errorf("Unable to convert YAML to an IniConfig: unsupported nesting on line %d", lineNumber)
+		}
+
+		key, rest, err := splitYAMLKey(trimmed)
+
+		if err != nil {
+			return nil, errorf("Unable to convert YAML to an IniConfig: %s on line %d", err.Error(), lineNumber)
+		}
+
+		if indent == "" {
+
+			inSection = false
+
+			if rest == "" {
+				section = key
+				inSection = true
+				sectionIndent = ""
+				continue
+			}
+
+			if rest == "{}" {
+				continue
+			}
+
+			section = GLOBAL_SECTION
+
+		} else {
+			sectionIndent = indent
+		}
+
+		value, err := yamlScalar(rest)
+
+		if err != nil {
+			return nil, errorf("Unable to convert YAML to an IniConfig: %s on line %d", err.Error(), lineNumber)
+		}
+
+		ic.storeFromLine(section, key, value, 0)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errorf("Unable to convert YAML to an IniConfig: %w", err)
+	}
+
+	return ic, nil
+}
+
+// splitYAMLKey splits a line of the form key: value into the (unquoted) key and the remainder of the line, with any
+// trailing comment kept.
+func splitYAMLKey(line string) (key, rest string, err error) {
+
+	if err := unsupportedYAML(line); err != nil {
+		return "", "", err
+	}
+
+	if line[0] == '"' || line[0] == '\'' {
+
+		end := closingQuote(line)
+
+		if end < 0 {
+			return "", "", errorf("unterminated quoted key")
+		}
+
+		v, err := yamlScalar(line[:end+1])
+
+		if err != nil {
+			return "", "", err
+		}
+
+		key, line = v.String(), line[end+1:]
+
+		if !strings.HasPrefix(line, ":") {
+			return "", "", errorf("expected : after key")
+		}
+
+		rest = line[1:]
+
+	} else {
+
+		i := strings.Index(line+" ", ": ")
+
+		if i < 0 {
+			return "", "", errorf("expected a key followed by :")
+		}
+
+		key = line[:i]
+
+		if i < len(line) {
+			rest = line[i+1:]
+		}
+	}
+
+	if rest != "" && rest[0] != ' ' {
+		return "", "", errorf("expected a space after :")
+	}
+
+	rest = strings.TrimSpace(rest)
+
+	if strings.HasPrefix(rest, "#") {
+		rest = ""
+	}
+
+	return key, rest, nil
+}
+
+// closingQuote returns the index of the quote ending the quoted scalar at the start of s, or -1 if it is unterminated.
+func closingQuote(s string) int {
+
+	quote := s[0]
+
+	for i := 1; i < len(s); i++ {
+
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case s[i] == quote && quote == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == quote:
+			return i
+		}
+	}
+
+	return -1
+}
+
+// yamlScalar converts the text of a scalar value (with any trailing comment) into a value. null and ~ are converted
+// to an unset value.
+func yamlScalar(s string) (nilableString, error) {
+
+	if s == "" {
+		return newNilableString(""), nil
+	}
+
+	if err := unsupportedYAML(s); err != nil {
+		return nilableString{}, err
+	}
+
+	if s[0] == '"' || s[0] == '\'' {
+
+		end := closingQuote(s)
+
+		if end < 0 {
+			return nilableString{}, errorf("unterminated quoted value")
+		}
+
+		if trailing := strings.TrimSpace(s[end+1:]); trailing != "" && !strings.HasPrefix(trailing, "#") {
+			return nilableString{}, errorf("unexpected text after quoted value")
+		}
+
+		if s[0] == '\'' {
+			return newNilableString(strings.Replace(s[1:end], "''", "'", -1)), nil
+		}
+
+		v, err := strconv.Unquote(s[:end+1])
+
+		if err != nil {
+			return nilableString{}, errorf("invalid double-quoted value")
+		}
+
+		return newNilableString(v), nil
+	}
+
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+
+	if s == "null" || s == "~" {
+		return nilableString{}, nil
+	}
+
+	return newNilableString(s), nil
+}
+
+// unsupportedYAML returns an error if the supplied key or value starts with YAML syntax that cannot be represented in
+// an IniConfig.
+func unsupportedYAML(s string) error {
+
+	switch {
+	case s == "-" || strings.HasPrefix(s, "- "):
+		return errorf("sequences are not supported")
+	case s[0] == '|' || s[0] == '>':
+		return errorf("block scalars are not supported")
+	case s[0] == '[' || s[0] == '{':
+		return errorf("flow collections are not supported")
+	case s[0] == '&' || s[0] == '*':
+		return errorf("anchors and aliases are not supported")
+	case s[0] == '!':
+		return errorf("tags are not supported")
+	case s[0] == '?':
+		return errorf("complex keys are not supported")
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package inifile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestYAMLRoundTrip(t *testing.T) {
+
+	opts := DefaultIniOptions()
+	opts.NullLiterals = []string{"NULL"}
+
+	ic, err := newIniConfigFromReader(strings.NewReader("name=app\n[server]\nport=8080\nhost=\"quoted\" value\nmissing=NULL\n[my section]\ntrue=yes\n"), "test", opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	b, err := ic.ToYAML()
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	expected := "name: \"app\"\nserver:\n  port: \"8080\"\n  host: \"\\\"quoted\\\" value\"\n  missing: null\n\"my section\":\n  \"true\": \"yes\"\n"
+
+	if string(b) != expected {
+		t.Errorf("Unexpected YAML %q", string(b))
+	}
+
+	converted, err := NewIniConfigFromYAML(b, opts)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, converted, GLOBAL_SECTION, "name", "app")
+	checkValue(t, converted, "server", "port", "8080")
+	checkValue(t, converted, "server", "host", "\"quoted\" value")
+	checkValue(t, converted, "my section", "true", "yes")
+
+	if v, err := converted.ValueOrNil("server", "missing"); err != nil || v != nil {
+		t.Errorf("Expected unset value to survive the round trip")
+	}
+
+	if names := converted.SectionNames(); strings.Join(names, ",") != ",server,my section" {
+		t.Errorf("Unexpected section order %v", names)
+	}
+}
+
+func TestYAMLImportScalars(t *testing.T) {
+
+	doc := "---\n# A comment\nplain: some text # trailing\nempty: {}\ndb:\n    single: 'it''s'\n    tilde: ~\n    blank:\n\n"
+
+	ic, err := NewIniConfigFromYAML([]byte(doc), DefaultIniOptions())
+
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err.Error())
+	}
+
+	checkValue(t, ic, GLOBAL_SECTION, "plain", "some text")
+	checkValue(t, ic, "db", "single", "it's")
+	checkValue(t, ic, "db", "blank", "")
+
+	if ic.SectionExists("empty") {
+		t.Errorf("Expected empty map not to create a section")
+	}
+}
+
+func TestYAMLUnsupported(t *testing.T) {
+
+	docs := map[string]string{
+		"list:\n  - a\n":        "sequences are not supported on line 2",
+		"a:\n  b:\n    c: d\n":  "unsupported nesting on line 3",
+		"text: |\n  line\n":     "block scalars are not supported on line 1",
+		"a: [1, 2]\n":           "flow collections are not supported on line 1",
+		"a: &anchor x\n":        "anchors and aliases are not supported on line 1",
+		"a: !!str x\n":          "tags are not supported on line 1",
+		"a:\n  b: c\n   d: e\n": "unsupported nesting on line 3",
+		"just text\n":           "expected a key followed by : on line 1",
+		"a: \"unterminated\n":   "unterminated quoted value on line 1",
+	}
+
+	for doc, expected := range docs {
+
+		_, err := NewIniConfigFromYAML([]byte(doc), DefaultIniOptions())
+
+		if err == nil || !strings.HasSuffix(err.Error(), expected) {
+			t.Errorf("Expected error ending %q for %q, got %v", expected, doc, err)
+		}
+	}
+}
+
+func TestYAMLNameClash(t *testing.T) {
+
+	ic := NewIniConfigFromMap(map[string]map[string]string{GLOBAL_SECTION: {"db": "x"}, "db": {"host": "h"}})
+
+	if _, err := ic.ToYAML(); err == nil {
+		t.Errorf("Expected global property with the same name as a section to fail")
+	}
+}